@@ -61,6 +61,33 @@ type Artifact struct {
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
+// ArtifactSpec configures the Artifacts produced for a Source.
+type ArtifactSpec struct {
+	// Retention overrides the retention of the previous Artifacts of the
+	// Source configured for the controller.
+	// +optional
+	Retention *ArtifactRetention `json:"retention,omitempty"`
+}
+
+// ArtifactRetention configures how long and how many of the previous
+// Artifacts of a Source are kept in the storage. The values are capped by
+// the maximum the controller allows.
+type ArtifactRetention struct {
+	// TTL is the duration for which the previous Artifacts are kept,
+	// overriding the --artifact-retention-ttl flag of the controller.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// MaxRecords is the maximum number of Artifacts kept, including the
+	// current one, overriding the --artifact-retention-records flag of the
+	// controller.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxRecords *int `json:"maxRecords,omitempty"`
+}
+
 // HasRevision returns if the given revision matches the current Revision of
 // the Artifact.
 func (in *Artifact) HasRevision(revision string) bool {
@@ -40,7 +40,7 @@ const (
 	BucketProviderGoogle string = "gcp"
 	// BucketProviderAzure for an Azure Blob Storage Bucket.
 	// Provides support for authentication using a Service Principal,
-	// Managed Identity or Shared Key.
+	// Managed Identity, Workload Identity or Shared Key.
 	BucketProviderAzure string = "azure"
 )
 
@@ -51,6 +51,8 @@ const (
 // +kubebuilder:validation:XValidation:rule="self.provider != 'generic' || !has(self.sts) || self.sts.provider == 'ldap'", message="'ldap' is the only supported STS provider for the 'generic' Bucket provider"
 // +kubebuilder:validation:XValidation:rule="!has(self.sts) || self.sts.provider != 'aws' || !has(self.sts.secretRef)", message="spec.sts.secretRef is not required for the 'aws' STS provider"
 // +kubebuilder:validation:XValidation:rule="!has(self.sts) || self.sts.provider != 'aws' || !has(self.sts.certSecretRef)", message="spec.sts.certSecretRef is not required for the 'aws' STS provider"
+// +kubebuilder:validation:XValidation:rule="!has(self.serviceAccountName) || self.provider == 'azure'", message="ServiceAccountName is only supported for the 'azure' Bucket provider"
+// +kubebuilder:validation:XValidation:rule="!has(self.serviceAccountName) || !has(self.secretRef)", message="cannot set both .spec.secretRef and .spec.serviceAccountName"
 type BucketSpec struct {
 	// Provider of the object storage bucket.
 	// Defaults to 'generic', which expects an S3 (API) compatible object
@@ -93,6 +95,13 @@ type BucketSpec struct {
 	// +optional
 	SecretRef *meta.LocalObjectReference `json:"secretRef,omitempty"`
 
+	// ServiceAccountName is the name of the Kubernetes ServiceAccount used to
+	// authenticate with the Bucket provider using workload identity.
+	//
+	// This field is only supported for the `azure` provider.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
 	// CertSecretRef can be given the name of a Secret containing
 	// either or both of
 	//
@@ -136,6 +145,10 @@ type BucketSpec struct {
 	// +optional
 	Ignore *string `json:"ignore,omitempty"`
 
+	// Artifact configures the Artifacts produced for the Bucket.
+	// +optional
+	Artifact *ArtifactSpec `json:"artifact,omitempty"`
+
 	// Suspend tells the controller to suspend the reconciliation of this
 	// Bucket.
 	// +optional
@@ -201,11 +214,23 @@ type BucketStatus struct {
 	// +optional
 	Artifact *Artifact `json:"artifact,omitempty"`
 
+	// PinnedArtifacts are the Artifacts with a revision listed in the
+	// source.toolkit.fluxcd.io/pinned-revisions annotation, which are not
+	// garbage collected until unpinned.
+	// +optional
+	PinnedArtifacts []Artifact `json:"pinnedArtifacts,omitempty"`
+
 	// ObservedIgnore is the observed exclusion patterns used for constructing
 	// the source artifact.
 	// +optional
 	ObservedIgnore *string `json:"observedIgnore,omitempty"`
 
+	// UpstreamCertificate describes the TLS certificate presented by the
+	// upstream host at the last probe, when certificate monitoring is
+	// enabled in the controller.
+	// +optional
+	UpstreamCertificate *UpstreamCertificate `json:"upstreamCertificate,omitempty"`
+
 	meta.ReconcileRequestStatus `json:",inline"`
 }
 
@@ -239,6 +264,12 @@ func (in *Bucket) GetArtifact() *Artifact {
 	return in.Status.Artifact
 }
 
+// GetPinnedArtifacts returns the pinned Artifacts from the status
+// sub-resource.
+func (in *Bucket) GetPinnedArtifacts() []Artifact {
+	return in.Status.PinnedArtifacts
+}
+
 // +genclient
 // +kubebuilder:storageversion
 // +kubebuilder:object:root=true
@@ -0,0 +1,33 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpstreamCertificate describes the TLS certificate presented by the host a
+// Source is fetched from.
+type UpstreamCertificate struct {
+	// Fingerprint is the hex encoded SHA-256 digest of the certificate.
+	// +required
+	Fingerprint string `json:"fingerprint"`
+
+	// NotAfter is the expiry time of the certificate.
+	// +required
+	NotAfter metav1.Time `json:"notAfter"`
+}
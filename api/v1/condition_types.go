@@ -60,6 +60,13 @@ const (
 	// This is a "negative polarity" or "abnormal-true" type, and is only
 	// present on the resource if it is True.
 	StorageOperationFailedCondition string = "StorageOperationFailed"
+
+	// SLOViolatedCondition indicates the Source does not meet the fetch SLO
+	// declared with the SLOMaxDurationAnnotation or the
+	// SLOMaxStalenessAnnotation. It does not affect the Ready condition.
+	// This is a "negative polarity" or "abnormal-true" type, and is only
+	// present on the resource if it is True.
+	SLOViolatedCondition string = "SLOViolated"
 )
 
 // Reasons are provided as utility, and not part of the declarative API.
@@ -79,6 +86,10 @@ const (
 	// operation.
 	DirCreationFailedReason string = "DirectoryCreationFailed"
 
+	// WorkspaceQuotaExceededReason signals that the temporary working
+	// directory exceeded the configured quota.
+	WorkspaceQuotaExceededReason string = "WorkspaceQuotaExceeded"
+
 	// StatOperationFailedReason signals a failure caused by a stat operation on
 	// a path.
 	StatOperationFailedReason string = "StatOperationFailed"
@@ -95,6 +106,10 @@ const (
 	// ArchiveOperationFailedReason signals a failure in archive operation.
 	ArchiveOperationFailedReason string = "ArchiveOperationFailed"
 
+	// ArtifactReadBackFailedReason signals a failure in reading back a newly
+	// stored Artifact from its URL.
+	ArtifactReadBackFailedReason string = "ArtifactReadBackFailed"
+
 	// SymlinkUpdateFailedReason signals a failure in updating a symlink.
 	SymlinkUpdateFailedReason string = "SymlinkUpdateFailed"
 
@@ -115,4 +130,25 @@ const (
 	// InvalidProviderConfigurationReason signals that the provider
 	// configuration is invalid.
 	InvalidProviderConfigurationReason string = "InvalidProviderConfiguration"
+
+	// SLODurationExceededReason signals that the reconciliation took longer
+	// than the duration declared with the SLOMaxDurationAnnotation.
+	SLODurationExceededReason string = "DurationExceeded"
+
+	// SLOStalenessExceededReason signals that the Source failed to fetch for
+	// longer than the duration declared with the SLOMaxStalenessAnnotation.
+	SLOStalenessExceededReason string = "StalenessExceeded"
+
+	// ArtifactQuarantinedReason signals that the content of a Source which
+	// failed verification has been stored in the quarantine area of the
+	// storage for inspection.
+	ArtifactQuarantinedReason string = "ArtifactQuarantined"
+
+	// UpstreamCertificateExpiringReason signals that the TLS certificate
+	// presented by the upstream Source is about to expire.
+	UpstreamCertificateExpiringReason string = "UpstreamCertificateExpiring"
+
+	// UpstreamCertificateChangedReason signals that the TLS certificate
+	// presented by the upstream Source changed since the previous fetch.
+	UpstreamCertificateChangedReason string = "UpstreamCertificateChanged"
 )
@@ -134,6 +134,10 @@ type GitRepositorySpec struct {
 	// +optional
 	Ignore *string `json:"ignore,omitempty"`
 
+	// Artifact configures the Artifacts produced for the GitRepository.
+	// +optional
+	Artifact *ArtifactSpec `json:"artifact,omitempty"`
+
 	// Suspend tells the controller to suspend the reconciliation of this
 	// GitRepository.
 	// +optional
@@ -252,6 +256,12 @@ type GitRepositoryStatus struct {
 	// +optional
 	Artifact *Artifact `json:"artifact,omitempty"`
 
+	// PinnedArtifacts are the Artifacts with a revision listed in the
+	// source.toolkit.fluxcd.io/pinned-revisions annotation, which are not
+	// garbage collected until unpinned.
+	// +optional
+	PinnedArtifacts []Artifact `json:"pinnedArtifacts,omitempty"`
+
 	// IncludedArtifacts contains a list of the last successfully included
 	// Artifacts as instructed by GitRepositorySpec.Include.
 	// +optional
@@ -282,6 +292,12 @@ type GitRepositoryStatus struct {
 	// +optional
 	SourceVerificationMode *GitVerificationMode `json:"sourceVerificationMode,omitempty"`
 
+	// UpstreamCertificate describes the TLS certificate presented by the
+	// upstream host at the last probe, when certificate monitoring is
+	// enabled in the controller.
+	// +optional
+	UpstreamCertificate *UpstreamCertificate `json:"upstreamCertificate,omitempty"`
+
 	meta.ReconcileRequestStatus `json:",inline"`
 }
 
@@ -317,6 +333,12 @@ func (in *GitRepository) GetArtifact() *Artifact {
 	return in.Status.Artifact
 }
 
+// GetPinnedArtifacts returns the pinned Artifacts from the status
+// sub-resource.
+func (in *GitRepository) GetPinnedArtifacts() []Artifact {
+	return in.Status.PinnedArtifacts
+}
+
 // GetProvider returns the Git authentication provider.
 func (v *GitRepository) GetProvider() string {
 	if v.Spec.Provider == "" {
@@ -74,6 +74,10 @@ type HelmChartSpec struct {
 	// +optional
 	IgnoreMissingValuesFiles bool `json:"ignoreMissingValuesFiles,omitempty"`
 
+	// Artifact configures the Artifacts produced for the HelmChart.
+	// +optional
+	Artifact *ArtifactSpec `json:"artifact,omitempty"`
+
 	// Suspend tells the controller to suspend the reconciliation of this
 	// source.
 	// +optional
@@ -86,6 +90,33 @@ type HelmChartSpec struct {
 	// Chart dependencies, which are not bundled in the umbrella chart artifact, are not verified.
 	// +optional
 	Verify *OCIRepositoryVerification `json:"verify,omitempty"`
+
+	// DependencyCredentials specifies the credentials to use for the
+	// repositories of chart dependencies, matched by repository URL.
+	// An entry takes precedence over the credentials of a HelmRepository
+	// with the same URL in the namespace of the HelmChart.
+	// This field is only used when building charts from GitRepository and
+	// Bucket sources.
+	// +optional
+	DependencyCredentials []HelmChartDependencyCredentials `json:"dependencyCredentials,omitempty"`
+}
+
+// HelmChartDependencyCredentials holds the credentials for a chart
+// dependency repository.
+type HelmChartDependencyCredentials struct {
+	// URL of the dependency repository as declared in the chart metadata,
+	// e.g. 'https://charts.example.com' or 'oci://registry.example.com/charts'.
+	// +required
+	URL string `json:"url"`
+
+	// SecretRef specifies the Secret containing authentication credentials
+	// for the repository.
+	// For HTTP/S basic auth the secret must contain 'username' and 'password'
+	// fields.
+	// For OCI repositories the secret must either contain 'username' and
+	// 'password' fields, or be of type 'kubernetes.io/dockerconfigjson'.
+	// +required
+	SecretRef meta.LocalObjectReference `json:"secretRef"`
 }
 
 const (
@@ -151,9 +182,57 @@ type HelmChartStatus struct {
 	// +optional
 	Artifact *Artifact `json:"artifact,omitempty"`
 
+	// PinnedArtifacts are the Artifacts with a revision listed in the
+	// source.toolkit.fluxcd.io/pinned-revisions annotation, which are not
+	// garbage collected until unpinned.
+	// +optional
+	PinnedArtifacts []Artifact `json:"pinnedArtifacts,omitempty"`
+
+	// ObservedBuild is a summary of the build of the chart in the last
+	// successfully reconciled Artifact.
+	// +optional
+	ObservedBuild *HelmChartBuildSummary `json:"observedBuild,omitempty"`
+
 	meta.ReconcileRequestStatus `json:",inline"`
 }
 
+// HelmChartBuildSummary contains the details of a Helm chart build.
+type HelmChartBuildSummary struct {
+	// ValuesFiles is the list of values files which were merged into the
+	// chart's default values.
+	// +optional
+	ValuesFiles []string `json:"valuesFiles,omitempty"`
+
+	// Dependencies is the list of chart dependencies which were resolved
+	// during the build.
+	// +optional
+	Dependencies []HelmChartDependency `json:"dependencies,omitempty"`
+
+	// Warnings contains the non-fatal issues encountered during the build.
+	// +optional
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// HelmChartDependency contains the details of a resolved chart dependency.
+type HelmChartDependency struct {
+	// Name of the dependency, or its alias if set.
+	// +required
+	Name string `json:"name"`
+
+	// Version of the resolved dependency chart.
+	// +required
+	Version string `json:"version"`
+
+	// Repository the dependency was resolved from.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// Digest of the dependency chart as advertised by the Helm repository
+	// index.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
 const (
 	// ChartPullSucceededReason signals that the pull of the Helm chart
 	// succeeded.
@@ -186,6 +265,12 @@ func (in *HelmChart) GetArtifact() *Artifact {
 	return in.Status.Artifact
 }
 
+// GetPinnedArtifacts returns the pinned Artifacts from the status
+// sub-resource.
+func (in *HelmChart) GetPinnedArtifacts() []Artifact {
+	return in.Status.PinnedArtifacts
+}
+
 // GetValuesFiles returns a merged list of HelmChartSpec.ValuesFiles.
 func (in *HelmChart) GetValuesFiles() []string {
 	return in.Spec.ValuesFiles
@@ -152,6 +152,18 @@ type HelmRepositoryStatus struct {
 	// +optional
 	Artifact *Artifact `json:"artifact,omitempty"`
 
+	// PinnedArtifacts are the Artifacts with a revision listed in the
+	// source.toolkit.fluxcd.io/pinned-revisions annotation, which are not
+	// garbage collected until unpinned.
+	// +optional
+	PinnedArtifacts []Artifact `json:"pinnedArtifacts,omitempty"`
+
+	// UpstreamCertificate describes the TLS certificate presented by the
+	// upstream host at the last probe, when certificate monitoring is
+	// enabled in the controller.
+	// +optional
+	UpstreamCertificate *UpstreamCertificate `json:"upstreamCertificate,omitempty"`
+
 	meta.ReconcileRequestStatus `json:",inline"`
 }
 
@@ -195,6 +207,12 @@ func (in *HelmRepository) GetArtifact() *Artifact {
 	return in.Status.Artifact
 }
 
+// GetPinnedArtifacts returns the pinned Artifacts from the status
+// sub-resource.
+func (in *HelmRepository) GetPinnedArtifacts() []Artifact {
+	return in.Status.PinnedArtifacts
+}
+
 // +genclient
 // +kubebuilder:storageversion
 // +kubebuilder:object:root=true
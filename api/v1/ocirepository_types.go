@@ -71,6 +71,15 @@ type OCIRepositorySpec struct {
 	// +optional
 	LayerSelector *OCILayerSelector `json:"layerSelector,omitempty"`
 
+	// Referrer selects an artifact referring to the resolved OCI artifact,
+	// such as an SBOM or an in-toto attestation, to be published as the
+	// Artifact content instead of the layer of the resolved OCI artifact.
+	// The layers of the referrer, filtered by the LayerSelector media type,
+	// are stored as files named after their 'org.opencontainers.image.title'
+	// annotation, or their digest.
+	// +optional
+	Referrer *OCIReferrerSelector `json:"referrer,omitempty"`
+
 	// The provider used for authentication, can be 'aws', 'azure', 'gcp' or 'generic'.
 	// When not specified, defaults to 'generic'.
 	// +kubebuilder:validation:Enum=generic;aws;azure;gcp
@@ -141,6 +150,10 @@ type OCIRepositorySpec struct {
 	// +optional
 	Insecure bool `json:"insecure,omitempty"`
 
+	// Artifact configures the Artifacts produced for the OCIRepository.
+	// +optional
+	Artifact *ArtifactSpec `json:"artifact,omitempty"`
+
 	// This flag tells the controller to suspend the reconciliation of this source.
 	// +optional
 	Suspend bool `json:"suspend,omitempty"`
@@ -184,6 +197,17 @@ type OCILayerSelector struct {
 	Operation string `json:"operation,omitempty"`
 }
 
+// OCIReferrerSelector specifies which referrer of an OCI artifact should be
+// fetched.
+type OCIReferrerSelector struct {
+	// ArtifactType of the referrer, e.g. 'application/spdx+json' or
+	// 'application/vnd.in-toto+json'. When multiple referrers match, the most
+	// recently created one according to the 'org.opencontainers.image.created'
+	// annotation is selected.
+	// +required
+	ArtifactType string `json:"artifactType"`
+}
+
 // OCIRepositoryStatus defines the observed state of OCIRepository
 type OCIRepositoryStatus struct {
 	// ObservedGeneration is the last observed generation.
@@ -202,6 +226,12 @@ type OCIRepositoryStatus struct {
 	// +optional
 	Artifact *Artifact `json:"artifact,omitempty"`
 
+	// PinnedArtifacts are the Artifacts with a revision listed in the
+	// source.toolkit.fluxcd.io/pinned-revisions annotation, which are not
+	// garbage collected until unpinned.
+	// +optional
+	PinnedArtifacts []Artifact `json:"pinnedArtifacts,omitempty"`
+
 	// ObservedIgnore is the observed exclusion patterns used for constructing
 	// the source artifact.
 	// +optional
@@ -212,6 +242,12 @@ type OCIRepositoryStatus struct {
 	// +optional
 	ObservedLayerSelector *OCILayerSelector `json:"observedLayerSelector,omitempty"`
 
+	// UpstreamCertificate describes the TLS certificate presented by the
+	// upstream host at the last probe, when certificate monitoring is
+	// enabled in the controller.
+	// +optional
+	UpstreamCertificate *UpstreamCertificate `json:"upstreamCertificate,omitempty"`
+
 	meta.ReconcileRequestStatus `json:",inline"`
 }
 
@@ -245,6 +281,12 @@ func (in *OCIRepository) GetArtifact() *Artifact {
 	return in.Status.Artifact
 }
 
+// GetPinnedArtifacts returns the pinned Artifacts from the status
+// sub-resource.
+func (in *OCIRepository) GetPinnedArtifacts() []Artifact {
+	return in.Status.PinnedArtifacts
+}
+
 // GetLayerMediaType returns the media type layer selector if found in spec.
 func (in *OCIRepository) GetLayerMediaType() string {
 	if in.Spec.LayerSelector == nil {
@@ -255,8 +297,10 @@ func (in *OCIRepository) GetLayerMediaType() string {
 }
 
 // GetLayerOperation returns the layer selector operation (defaults to extract).
+// The layers of a referrer are always stored as files and archived, hence the
+// operation is extract when a referrer is selected.
 func (in *OCIRepository) GetLayerOperation() string {
-	if in.Spec.LayerSelector == nil || in.Spec.LayerSelector.Operation == "" {
+	if in.Spec.Referrer != nil || in.Spec.LayerSelector == nil || in.Spec.LayerSelector.Operation == "" {
 		return OCILayerExtract
 	}
 
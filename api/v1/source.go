@@ -26,6 +26,19 @@ const (
 	// SourceIndexKey is the key used for indexing objects based on their
 	// referenced Source.
 	SourceIndexKey string = ".metadata.source"
+
+	// PinnedRevisionsAnnotation is the annotation listing the comma separated
+	// revisions of the Artifacts of a Source which must not be garbage
+	// collected.
+	PinnedRevisionsAnnotation string = "source.toolkit.fluxcd.io/pinned-revisions"
+
+	// SLOMaxDurationAnnotation is the annotation declaring the maximum
+	// duration of a reconciliation of a Source, e.g. "2m".
+	SLOMaxDurationAnnotation string = "source.toolkit.fluxcd.io/slo-max-duration"
+
+	// SLOMaxStalenessAnnotation is the annotation declaring the maximum
+	// duration for which a Source may fail to fetch, e.g. "1h".
+	SLOMaxStalenessAnnotation string = "source.toolkit.fluxcd.io/slo-max-staleness"
 )
 
 // Source interface must be supported by all API types.
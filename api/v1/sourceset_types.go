@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fluxcd/pkg/apis/meta"
+)
+
+const (
+	// SourceSetKind is the string representation of a SourceSet.
+	SourceSetKind = "SourceSet"
+
+	// SourceSetNameLabel is the label set on the sources generated by a
+	// SourceSet, with the name of the SourceSet as value.
+	SourceSetNameLabel = "source.toolkit.fluxcd.io/sourceset-name"
+)
+
+// SourceSetSpec specifies the inputs and the template of the sources
+// generated by a SourceSet.
+type SourceSetSpec struct {
+	// Inputs is a list of input sets, a source is generated from the template
+	// for each of them.
+	// +optional
+	Inputs []SourceSetInput `json:"inputs,omitempty"`
+
+	// InputsFrom selects ConfigMaps in the namespace of the SourceSet, a
+	// source is generated from the template for the data of each of them.
+	// +optional
+	InputsFrom *metav1.LabelSelector `json:"inputsFrom,omitempty"`
+
+	// Template of the generated sources. The string values of the template
+	// may contain '{{ .<key> }}' placeholders, which are replaced with the
+	// values of an input set.
+	// +required
+	Template SourceSetTemplate `json:"template"`
+
+	// Interval at which the inputs are reconciled.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +required
+	Interval metav1.Duration `json:"interval"`
+
+	// Suspend tells the controller to suspend the reconciliation of this
+	// SourceSet.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// SourceSetInput is a set of input values, keyed by the name used in the
+// placeholders of the template.
+type SourceSetInput map[string]string
+
+// SourceSetTemplate is the template of the sources generated by a SourceSet.
+// +kubebuilder:validation:XValidation:rule="has(self.gitRepository) != has(self.ociRepository)", message="exactly one of gitRepository or ociRepository must be set"
+type SourceSetTemplate struct {
+	// Metadata of the generated sources.
+	// +required
+	Metadata SourceSetTemplateMetadata `json:"metadata"`
+
+	// GitRepository is the spec of the generated GitRepository objects.
+	// +optional
+	GitRepository *GitRepositorySpec `json:"gitRepository,omitempty"`
+
+	// OCIRepository is the spec of the generated OCIRepository objects.
+	// +optional
+	OCIRepository *OCIRepositorySpec `json:"ociRepository,omitempty"`
+}
+
+// SourceSetTemplateMetadata is the metadata of the sources generated by a
+// SourceSet.
+type SourceSetTemplateMetadata struct {
+	// Name of the generated sources, it must be unique for each input set.
+	// +required
+	Name string `json:"name"`
+
+	// Labels of the generated sources.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations of the generated sources.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// SourceSetStatus records the observed state of a SourceSet.
+type SourceSetStatus struct {
+	// ObservedGeneration is the last observed generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions holds the conditions for the SourceSet.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Inventory is the list of the sources generated by the SourceSet.
+	// +optional
+	Inventory []SourceSetInventoryEntry `json:"inventory,omitempty"`
+
+	meta.ReconcileRequestStatus `json:",inline"`
+}
+
+// SourceSetInventoryEntry is a reference to a source generated by a
+// SourceSet.
+type SourceSetInventoryEntry struct {
+	// Kind of the source.
+	// +required
+	Kind string `json:"kind"`
+
+	// Name of the source.
+	// +required
+	Name string `json:"name"`
+}
+
+const (
+	// InputsFailedReason signals that the inputs of a SourceSet could not be
+	// read.
+	InputsFailedReason string = "InputsFailed"
+
+	// TemplateFailedReason signals that the template of a SourceSet could
+	// not be rendered.
+	TemplateFailedReason string = "TemplateFailed"
+
+	// ApplyFailedReason signals that the sources generated by a SourceSet
+	// could not be applied or garbage collected.
+	ApplyFailedReason string = "ApplyFailed"
+)
+
+// GetConditions returns the status conditions of the object.
+func (in SourceSet) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions on the object.
+func (in *SourceSet) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+// GetRequeueAfter returns the duration after which the SourceSet must be
+// reconciled again.
+func (in SourceSet) GetRequeueAfter() time.Duration {
+	return in.Spec.Interval.Duration
+}
+
+// GetTemplateKind returns the kind of the sources generated by the SourceSet.
+func (in SourceSet) GetTemplateKind() string {
+	if in.Spec.Template.OCIRepository != nil {
+		return OCIRepositoryKind
+	}
+	return GitRepositoryKind
+}
+
+// +genclient
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=srcset
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// SourceSet is the Schema for the sourcesets API
+type SourceSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SourceSetSpec `json:"spec,omitempty"`
+	// +kubebuilder:default={"observedGeneration":-1}
+	Status SourceSetStatus `json:"status,omitempty"`
+}
+
+// SourceSetList contains a list of SourceSet
+// +kubebuilder:object:root=true
+type SourceSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SourceSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SourceSet{}, &SourceSetList{})
+}
@@ -131,6 +131,21 @@ func (c *cache) Delete(key string) {
 	c.mu.Unlock()
 }
 
+// DeleteFunc deletes all items for which the key satisfies fn, and returns
+// the deleted keys.
+func (c *cache) DeleteFunc(fn func(key string) bool) []string {
+	c.mu.Lock()
+	var deleted []string
+	for k := range c.Items {
+		if fn(k) {
+			delete(c.Items, k)
+			deleted = append(deleted, k)
+		}
+	}
+	c.mu.Unlock()
+	return deleted
+}
+
 // Clear all items from the cache.
 // This reallocates the underlying array holding the items,
 // so that the memory used by the items is reclaimed.
@@ -17,6 +17,7 @@ limitations under the License.
 package cache
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -137,3 +138,23 @@ func TestCacheDeleteClear(t *testing.T) {
 	cache.Clear()
 	g.Expect(cache.ItemCount()).To(Equal(0))
 }
+
+func TestCacheDeleteFunc(t *testing.T) {
+	g := NewWithT(t)
+	cache := New(3, 0)
+
+	err := cache.Add("team-a/key1", "value1", 0)
+	g.Expect(err).ToNot(HaveOccurred())
+	err = cache.Add("team-a/key2", "value2", 0)
+	g.Expect(err).ToNot(HaveOccurred())
+	err = cache.Add("team-b/key1", "value3", 0)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	deleted := cache.DeleteFunc(func(key string) bool {
+		return strings.HasPrefix(key, "team-a/")
+	})
+	g.Expect(deleted).To(ConsistOf("team-a/key1", "team-a/key2"))
+	g.Expect(cache.ItemCount()).To(Equal(1))
+	_, found := cache.Get("team-b/key1")
+	g.Expect(found).To(BeTrue())
+}
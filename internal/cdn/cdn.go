@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cdn rewrites advertised artifact URLs to go through a CDN,
+// optionally signing them as Amazon CloudFront signed URLs with a canned
+// policy, and purges the cached copies of replaced artifacts from the CDN.
+package cdn
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// DefaultURLTTL is the default duration signed URLs are valid for.
+const DefaultURLTTL = 24 * time.Hour
+
+// Options configures a URLRewriter.
+type Options struct {
+	// BaseURL is the URL of the CDN the artifact paths are appended to,
+	// e.g. 'https://d111111abcdef8.cloudfront.net/artifacts'.
+	BaseURL string
+
+	// KeyPairID is the ID of the CloudFront public key, or key pair, the
+	// private key belongs to. URLs are signed when set.
+	KeyPairID string
+
+	// PrivateKeyFile is the path to the PEM encoded RSA private key used to
+	// sign URLs, e.g. mounted from a Secret.
+	PrivateKeyFile string
+
+	// URLTTL is the duration signed URLs are valid for. Defaults to
+	// DefaultURLTTL.
+	URLTTL time.Duration
+}
+
+// URLRewriter composes artifact URLs from the CDN base URL.
+type URLRewriter struct {
+	base      *url.URL
+	keyPairID string
+	key       *rsa.PrivateKey
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+// New returns a URLRewriter for the given Options.
+func New(opts Options) (*URLRewriter, error) {
+	base, err := url.Parse(opts.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CDN base URL: %w", err)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" || base.Host == "" {
+		return nil, fmt.Errorf("invalid CDN base URL '%s': must be an absolute http(s) URL", opts.BaseURL)
+	}
+
+	r := &URLRewriter{
+		base: base,
+		ttl:  opts.URLTTL,
+		now:  time.Now,
+	}
+	if r.ttl <= 0 {
+		r.ttl = DefaultURLTTL
+	}
+
+	if opts.KeyPairID != "" || opts.PrivateKeyFile != "" {
+		if opts.KeyPairID == "" || opts.PrivateKeyFile == "" {
+			return nil, errors.New("both the key pair ID and private key file are required to sign URLs")
+		}
+		b, err := os.ReadFile(opts.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CDN private key: %w", err)
+		}
+		key, err := parsePrivateKey(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CDN private key: %w", err)
+		}
+		r.keyPairID, r.key = opts.KeyPairID, key
+	}
+	return r, nil
+}
+
+// Scheme returns the scheme of the CDN base URL.
+func (r *URLRewriter) Scheme() string {
+	return r.base.Scheme
+}
+
+// URL returns the CDN URL for the given artifact path. If a key is
+// configured, the URL is signed with an expiry of at least half the URL TTL
+// from now. The expiry is aligned to half TTL windows, so the URL only
+// changes once per window.
+func (r *URLRewriter) URL(artifactPath string) (string, error) {
+	u := r.unsignedURL(artifactPath)
+	if r.key == nil {
+		return u.String(), nil
+	}
+
+	window := r.ttl / 2
+	expires := r.now().Truncate(window).Add(r.ttl).Unix()
+	policy := fmt.Sprintf(`{"Statement":[{"Resource":"%s","Condition":{"DateLessThan":{"AWS:EpochTime":%d}}}]}`,
+		u.String(), expires)
+	hash := sha1.Sum([]byte(policy))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, r.key, crypto.SHA1, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign CDN URL: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("Expires", fmt.Sprintf("%d", expires))
+	q.Set("Signature", cloudFrontEncoding.Replace(base64.StdEncoding.EncodeToString(sig)))
+	q.Set("Key-Pair-Id", r.keyPairID)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// UnsignedURL returns the CDN URL for the given artifact path without any
+// signature, e.g. to purge it from the CDN cache.
+func (r *URLRewriter) UnsignedURL(artifactPath string) string {
+	u := r.unsignedURL(artifactPath)
+	return u.String()
+}
+
+func (r *URLRewriter) unsignedURL(artifactPath string) url.URL {
+	u := *r.base
+	u.Path = path.Join("/", u.Path, strings.TrimLeft(artifactPath, "/"))
+	u.RawPath = ""
+	return u
+}
+
+// cloudFrontEncoding replaces the characters of base64 encoded signatures
+// which are invalid in URL query parameters, as specified by CloudFront.
+var cloudFrontEncoding = strings.NewReplacer("+", "-", "=", "_", "/", "~")
+
+func parsePrivateKey(b []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T, expected RSA", key)
+	}
+	return rsaKey, nil
+}
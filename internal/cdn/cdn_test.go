@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cdn
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNew(t *testing.T) {
+	keyFile, _ := writePrivateKey(t)
+
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr string
+	}{
+		{
+			name: "base URL",
+			opts: Options{BaseURL: "https://cdn.example.com"},
+		},
+		{
+			name: "signing key",
+			opts: Options{BaseURL: "https://cdn.example.com", KeyPairID: "K2JCJMDEHXQW5F", PrivateKeyFile: keyFile},
+		},
+		{
+			name:    "relative base URL",
+			opts:    Options{BaseURL: "cdn.example.com/artifacts"},
+			wantErr: "must be an absolute http(s) URL",
+		},
+		{
+			name:    "missing key pair ID",
+			opts:    Options{BaseURL: "https://cdn.example.com", PrivateKeyFile: keyFile},
+			wantErr: "both the key pair ID and private key file are required",
+		},
+		{
+			name:    "missing private key file",
+			opts:    Options{BaseURL: "https://cdn.example.com", KeyPairID: "K2JCJMDEHXQW5F", PrivateKeyFile: filepath.Join(t.TempDir(), "missing.pem")},
+			wantErr: "failed to read CDN private key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := New(tt.opts)
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func TestURLRewriter_URL(t *testing.T) {
+	g := NewWithT(t)
+
+	r, err := New(Options{BaseURL: "https://cdn.example.com/artifacts/"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	got, err := r.URL("/gitrepository/default/podinfo/latest.tar.gz")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(Equal("https://cdn.example.com/artifacts/gitrepository/default/podinfo/latest.tar.gz"))
+}
+
+func TestURLRewriter_URL_signed(t *testing.T) {
+	g := NewWithT(t)
+
+	keyFile, key := writePrivateKey(t)
+	r, err := New(Options{
+		BaseURL:        "https://cdn.example.com",
+		KeyPairID:      "K2JCJMDEHXQW5F",
+		PrivateKeyFile: keyFile,
+		URLTTL:         time.Hour,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	now := time.Date(2026, 1, 1, 12, 10, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+
+	got, err := r.URL("gitrepository/default/podinfo/latest.tar.gz")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	u, err := url.Parse(got)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(u.Path).To(Equal("/gitrepository/default/podinfo/latest.tar.gz"))
+
+	q := u.Query()
+	wantExpires := time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC).Unix()
+	g.Expect(q.Get("Expires")).To(Equal(fmt.Sprintf("%d", wantExpires)))
+	g.Expect(q.Get("Key-Pair-Id")).To(Equal("K2JCJMDEHXQW5F"))
+
+	// The signature must verify against the canned policy of the unsigned URL.
+	sig, err := base64.StdEncoding.DecodeString(
+		strings.NewReplacer("-", "+", "_", "=", "~", "/").Replace(q.Get("Signature")))
+	g.Expect(err).ToNot(HaveOccurred())
+	policy := fmt.Sprintf(`{"Statement":[{"Resource":"https://cdn.example.com/gitrepository/default/podinfo/latest.tar.gz","Condition":{"DateLessThan":{"AWS:EpochTime":%d}}}]}`, wantExpires)
+	hash := sha1.Sum([]byte(policy))
+	g.Expect(rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA1, hash[:], sig)).To(Succeed())
+
+	// The URL does not change within the same half TTL window.
+	now = now.Add(15 * time.Minute)
+	again, err := r.URL("gitrepository/default/podinfo/latest.tar.gz")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(again).To(Equal(got))
+
+	// The URL is refreshed in the next window.
+	now = now.Add(10 * time.Minute)
+	refreshed, err := r.URL("gitrepository/default/podinfo/latest.tar.gz")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(refreshed).ToNot(Equal(got))
+}
+
+func writePrivateKey(t *testing.T) (string, *rsa.PrivateKey) {
+	t.Helper()
+	g := NewWithT(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	g.Expect(err).ToNot(HaveOccurred())
+	b := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	keyFile := filepath.Join(t.TempDir(), "private_key.pem")
+	g.Expect(os.WriteFile(keyFile, b, 0o600)).To(Succeed())
+	return keyFile, key
+}
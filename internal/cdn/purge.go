@@ -0,0 +1,261 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// GenericPurgeProvider POSTs the URLs to purge as a JSON document to the
+	// purge address, e.g. a function invalidating a CloudFront distribution.
+	GenericPurgeProvider = "generic"
+	// FastlyPurgeProvider sends a Fastly PURGE request for every URL.
+	FastlyPurgeProvider = "fastly"
+)
+
+const (
+	// minPurgeRetryInterval is the interval after which failed purges are
+	// first retried.
+	minPurgeRetryInterval = 5 * time.Second
+	// maxPurgeRetryInterval is the maximum interval between retries of
+	// failed purges.
+	maxPurgeRetryInterval = 5 * time.Minute
+)
+
+// PurgeOptions configures a Purger.
+type PurgeOptions struct {
+	// Provider is the cache invalidation API, one of GenericPurgeProvider or
+	// FastlyPurgeProvider.
+	Provider string
+
+	// Address is the endpoint the GenericPurgeProvider sends the URLs to.
+	Address string
+
+	// TokenFile is the path to a file containing the token used to
+	// authenticate with the provider, e.g. mounted from a Secret.
+	TokenFile string
+}
+
+// PurgeRequest is the JSON document sent by the GenericPurgeProvider.
+type PurgeRequest struct {
+	URLs []string `json:"urls"`
+}
+
+// Purger invalidates the cached copies of URLs in a CDN. URLs are queued by
+// Purge, and sent in batches while the Purger is started, so callers are not
+// blocked by the provider. URLs which failed to be purged are queued again,
+// and retried with an exponential backoff.
+type Purger struct {
+	provider string
+	address  string
+	token    string
+	client   *http.Client
+
+	minRetryInterval time.Duration
+	maxRetryInterval time.Duration
+
+	mu      sync.Mutex
+	pending map[string]struct{}
+	notify  chan struct{}
+}
+
+// NewPurger returns a Purger for the given PurgeOptions.
+func NewPurger(opts PurgeOptions) (*Purger, error) {
+	switch opts.Provider {
+	case GenericPurgeProvider:
+		if opts.Address == "" {
+			return nil, fmt.Errorf("a purge address is required for the '%s' provider", opts.Provider)
+		}
+	case FastlyPurgeProvider:
+	default:
+		return nil, fmt.Errorf("unsupported purge provider '%s', must be one of: %s, %s",
+			opts.Provider, GenericPurgeProvider, FastlyPurgeProvider)
+	}
+
+	p := &Purger{
+		provider: opts.Provider,
+		address:  opts.Address,
+		client:   &http.Client{Timeout: 30 * time.Second},
+		pending:  make(map[string]struct{}),
+		notify:   make(chan struct{}, 1),
+
+		minRetryInterval: minPurgeRetryInterval,
+		maxRetryInterval: maxPurgeRetryInterval,
+	}
+	if opts.TokenFile != "" {
+		b, err := os.ReadFile(opts.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read purge token: %w", err)
+		}
+		p.token = strings.TrimSpace(string(b))
+	}
+	return p, nil
+}
+
+// Purge queues the given URLs to be purged.
+func (p *Purger) Purge(urls ...string) {
+	if len(urls) == 0 {
+		return
+	}
+	p.queue(urls)
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Only the
+// leader reconciles and thereby queues URLs, but the queue is drained on all
+// replicas.
+func (p *Purger) NeedLeaderElection() bool {
+	return false
+}
+
+// Start sends the queued URLs to the provider until the given context is
+// canceled. While backing off from a failure, the URLs queued in the meantime
+// are sent with the retry.
+func (p *Purger) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("cdn-purger")
+	var (
+		retry   <-chan time.Time
+		backoff time.Duration
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.notify:
+			if retry != nil {
+				continue
+			}
+		case <-retry:
+			retry = nil
+		}
+
+		urls := p.takePending()
+		failed, err := p.send(ctx, urls)
+		if err == nil {
+			backoff = 0
+			continue
+		}
+		if len(failed) == 0 {
+			log.Error(err, "failed to purge URLs from CDN", "urls", urls)
+			continue
+		}
+		backoff = min(max(2*backoff, p.minRetryInterval), p.maxRetryInterval)
+		p.queue(failed)
+		retry = time.After(backoff)
+		log.Error(err, "failed to purge URLs from CDN, retrying", "urls", failed,
+			"retryAfter", backoff.String())
+	}
+}
+
+// queue adds the given URLs to the queue.
+func (p *Purger) queue(urls []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, u := range urls {
+		p.pending[u] = struct{}{}
+	}
+}
+
+// takePending returns the sorted queued URLs, and empties the queue.
+func (p *Purger) takePending() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	urls := make([]string, 0, len(p.pending))
+	for u := range p.pending {
+		urls = append(urls, u)
+	}
+	p.pending = make(map[string]struct{})
+	sort.Strings(urls)
+	return urls
+}
+
+// send sends the given URLs to the provider. It returns the URLs which
+// failed to be purged and may succeed when retried, along with the error.
+func (p *Purger) send(ctx context.Context, urls []string) ([]string, error) {
+	if len(urls) == 0 {
+		return nil, nil
+	}
+	switch p.provider {
+	case FastlyPurgeProvider:
+		var (
+			failed []string
+			errs   []error
+		)
+		for _, u := range urls {
+			req, err := http.NewRequestWithContext(ctx, "PURGE", u, nil)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if p.token != "" {
+				req.Header.Set("Fastly-Key", p.token)
+			}
+			if err := p.do(req); err != nil {
+				failed = append(failed, u)
+				errs = append(errs, err)
+			}
+		}
+		return failed, kerrors.NewAggregate(errs)
+	default:
+		body, err := json.Marshal(PurgeRequest{URLs: urls})
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.address, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if p.token != "" {
+			req.Header.Set("Authorization", "Bearer "+p.token)
+		}
+		if err := p.do(req); err != nil {
+			return urls, err
+		}
+		return nil, nil
+	}
+}
+
+func (p *Purger) do(req *http.Request) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("purge of '%s' failed with status code %d", req.URL.Redacted(), resp.StatusCode)
+	}
+	return nil
+}
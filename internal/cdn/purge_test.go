@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cdn
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNewPurger(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    PurgeOptions
+		wantErr string
+	}{
+		{
+			name: "generic",
+			opts: PurgeOptions{Provider: GenericPurgeProvider, Address: "https://purge.example.com"},
+		},
+		{
+			name: "fastly",
+			opts: PurgeOptions{Provider: FastlyPurgeProvider},
+		},
+		{
+			name:    "generic without address",
+			opts:    PurgeOptions{Provider: GenericPurgeProvider},
+			wantErr: "a purge address is required",
+		},
+		{
+			name:    "unsupported provider",
+			opts:    PurgeOptions{Provider: "akamai"},
+			wantErr: "unsupported purge provider",
+		},
+		{
+			name:    "missing token file",
+			opts:    PurgeOptions{Provider: FastlyPurgeProvider, TokenFile: filepath.Join(t.TempDir(), "token")},
+			wantErr: "failed to read purge token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := NewPurger(tt.opts)
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func TestPurger_generic(t *testing.T) {
+	g := NewWithT(t)
+
+	var (
+		mu       sync.Mutex
+		requests []PurgeRequest
+		auth     string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req PurgeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		requests = append(requests, req)
+		auth = r.Header.Get("Authorization")
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	g.Expect(os.WriteFile(tokenFile, []byte("s3cr3t\n"), 0o600)).To(Succeed())
+
+	p, err := NewPurger(PurgeOptions{Provider: GenericPurgeProvider, Address: server.URL, TokenFile: tokenFile})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	p.Purge("https://cdn.example.com/b/latest.tar.gz", "https://cdn.example.com/a/latest.tar.gz")
+	p.Purge("https://cdn.example.com/a/latest.tar.gz")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.Start(ctx)
+
+	g.Eventually(func() []PurgeRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}).Should(Equal([]PurgeRequest{{URLs: []string{
+		"https://cdn.example.com/a/latest.tar.gz",
+		"https://cdn.example.com/b/latest.tar.gz",
+	}}}))
+	mu.Lock()
+	g.Expect(auth).To(Equal("Bearer s3cr3t"))
+	mu.Unlock()
+}
+
+func TestPurger_fastly(t *testing.T) {
+	g := NewWithT(t)
+
+	var (
+		mu     sync.Mutex
+		purged []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PURGE" || r.Header.Get("Fastly-Key") != "s3cr3t" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		mu.Lock()
+		purged = append(purged, r.URL.Path)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	g.Expect(os.WriteFile(tokenFile, []byte("s3cr3t"), 0o600)).To(Succeed())
+
+	p, err := NewPurger(PurgeOptions{Provider: FastlyPurgeProvider, TokenFile: tokenFile})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.Start(ctx)
+
+	p.Purge(server.URL+"/helmrepository/default/podinfo/index.yaml", server.URL+"/helmchart/default/podinfo/latest.tar.gz")
+
+	g.Eventually(func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return purged
+	}).Should(ConsistOf("/helmrepository/default/podinfo/index.yaml", "/helmchart/default/podinfo/latest.tar.gz"))
+}
+
+func TestPurger_retry(t *testing.T) {
+	g := NewWithT(t)
+
+	var (
+		p        *Purger
+		mu       sync.Mutex
+		attempts int
+		purged   []PurgeRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req PurgeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		// Fail the first two attempts, and queue another URL while backing
+		// off, which is sent along with the retry.
+		if attempts == 1 {
+			p.Purge("https://cdn.example.com/b/latest.tar.gz")
+		}
+		if attempts <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		purged = append(purged, req)
+	}))
+	defer server.Close()
+
+	var err error
+	p, err = NewPurger(PurgeOptions{Provider: GenericPurgeProvider, Address: server.URL})
+	g.Expect(err).ToNot(HaveOccurred())
+	p.minRetryInterval = 10 * time.Millisecond
+	p.maxRetryInterval = 20 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.Start(ctx)
+
+	p.Purge("https://cdn.example.com/a/latest.tar.gz")
+
+	g.Eventually(func() []PurgeRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return purged
+	}).Should(Equal([]PurgeRequest{{URLs: []string{
+		"https://cdn.example.com/a/latest.tar.gz",
+		"https://cdn.example.com/b/latest.tar.gz",
+	}}}))
+	mu.Lock()
+	g.Expect(attempts).To(Equal(3))
+	mu.Unlock()
+}
@@ -16,7 +16,23 @@ limitations under the License.
 
 package controller
 
-import sourcev1 "github.com/fluxcd/source-controller/api/v1"
+import (
+	"fmt"
+	"strconv"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+const (
+	// eventMetaSizeKey is the event metadata key for the size of the
+	// artifact in bytes.
+	eventMetaSizeKey = "size"
+	// eventMetaStorageKey is the event metadata key for the type of
+	// storage backend the artifact is stored in.
+	eventMetaStorageKey = "storage"
+)
 
 type artifactSet []*sourcev1.Artifact
 
@@ -37,3 +53,28 @@ outer:
 	}
 	return false
 }
+
+// artifactEventMetadata returns the event metadata for the given artifact,
+// stored in the given storage backend. The keys are prefixed with the API
+// group, so they are forwarded by the notification-controller.
+func artifactEventMetadata(artifact *sourcev1.Artifact, backend string) map[string]string {
+	if artifact == nil {
+		return nil
+	}
+	metadata := map[string]string{
+		eventv1.MetaRevisionKey: artifact.Revision,
+		eventv1.MetaDigestKey:   artifact.Digest,
+	}
+	if artifact.Size != nil {
+		metadata[eventMetaSizeKey] = strconv.FormatInt(*artifact.Size, 10)
+	}
+	if backend != "" {
+		metadata[eventMetaStorageKey] = backend
+	}
+
+	annotations := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		annotations[fmt.Sprintf("%s/%s", sourcev1.GroupVersion.Group, k)] = v
+	}
+	return annotations
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	kuberecorder "k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fluxcd/pkg/apis/meta"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+// ArtifactMissingReason signals that the Artifact advertised in the status
+// of an object is missing from the Storage.
+const ArtifactMissingReason = "ArtifactMissing"
+
+// artifactSource is a Source object which advertises an Artifact.
+type artifactSource interface {
+	client.Object
+	GetArtifact() *sourcev1.Artifact
+	GetPinnedArtifacts() []sourcev1.Artifact
+}
+
+// ArtifactAuditor periodically verifies that the Artifacts advertised in the
+// status of the Source objects are present in the Storage. When an Artifact
+// has disappeared, it requests the reconciliation of the object, which
+// rebuilds the Artifact from upstream instead of waiting for the next
+// interval.
+type ArtifactAuditor struct {
+	client.Client
+	kuberecorder.EventRecorder
+
+	Storage  *Storage
+	Interval time.Duration
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, ensuring
+// only the leader requests reconciliations.
+func (a *ArtifactAuditor) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the audit at the configured interval until the given context is
+// canceled.
+func (a *ArtifactAuditor) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("artifact-auditor")
+	ticker := time.NewTicker(a.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if a.Storage.Maintenance.Enabled() {
+				log.V(1).Info("controller is in maintenance mode, skipping artifact audit")
+				continue
+			}
+			if !a.Storage.Fence.Acquired() {
+				log.V(1).Info("storage fencing token not acquired yet, skipping artifact audit")
+				continue
+			}
+			if err := a.Audit(ctx); err != nil {
+				log.Error(err, "artifact audit failed")
+			}
+		}
+	}
+}
+
+// Audit checks the Artifact of all the Source objects, and requests the
+// reconciliation of the objects for which it is missing from the Storage.
+func (a *ArtifactAuditor) Audit(ctx context.Context) error {
+	return forEachArtifactSource(ctx, a.Client, func(obj artifactSource) error {
+		return a.auditObject(ctx, obj)
+	})
+}
+
+// auditObject requests the reconciliation of the given object if its
+// Artifact is missing from the Storage.
+func (a *ArtifactAuditor) auditObject(ctx context.Context, obj artifactSource) error {
+	artifact := obj.GetArtifact()
+	if artifact == nil || !obj.GetDeletionTimestamp().IsZero() || isSuspended(obj) {
+		return nil
+	}
+	if a.Storage.ArtifactExist(*artifact) {
+		return nil
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[meta.ReconcileRequestAnnotation] = time.Now().Format(time.RFC3339Nano)
+	obj.SetAnnotations(annotations)
+	if err := a.Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("failed to request reconciliation of '%s/%s': %w", obj.GetNamespace(), obj.GetName(), err)
+	}
+
+	a.Eventf(obj, corev1.EventTypeWarning, ArtifactMissingReason,
+		"artifact '%s' disappeared from storage, requested reconciliation to rebuild it", artifact.Path)
+	return nil
+}
+
+// isSuspended returns true if the reconciliation of the given object is
+// suspended.
+func isSuspended(obj client.Object) bool {
+	switch o := obj.(type) {
+	case *sourcev1.GitRepository:
+		return o.Spec.Suspend
+	case *sourcev1.HelmRepository:
+		return o.Spec.Suspend
+	case *sourcev1.HelmChart:
+		return o.Spec.Suspend
+	case *sourcev1.Bucket:
+		return o.Spec.Suspend
+	case *sourcev1.OCIRepository:
+		return o.Spec.Suspend
+	default:
+		return false
+	}
+}
+
+// forEachArtifactSource lists the objects of all the Source kinds, and calls
+// fn for every object. Errors are aggregated, allowing fn to be called for
+// the remaining objects.
+func forEachArtifactSource(ctx context.Context, c client.Reader, fn func(obj artifactSource) error) error {
+	var errs []error
+	for _, list := range []client.ObjectList{
+		&sourcev1.GitRepositoryList{},
+		&sourcev1.HelmRepositoryList{},
+		&sourcev1.HelmChartList{},
+		&sourcev1.BucketList{},
+		&sourcev1.OCIRepositoryList{},
+	} {
+		if err := c.List(ctx, list); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		items, err := apimeta.ExtractList(list)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, item := range items {
+			obj, ok := item.(artifactSource)
+			if !ok {
+				continue
+			}
+			if err := fn(obj); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
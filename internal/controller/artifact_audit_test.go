@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/fluxcd/pkg/apis/meta"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestArtifactAuditor_Audit(t *testing.T) {
+	g := NewWithT(t)
+
+	defer func() {
+		g.Expect(os.RemoveAll(filepath.Join(testStorage.BasePath, "/gitrepository/audit"))).To(Succeed())
+	}()
+
+	present := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "present", Namespace: "audit"},
+		Status: sourcev1.GitRepositoryStatus{
+			Artifact: &sourcev1.Artifact{Path: "/gitrepository/audit/present/main.tar.gz"},
+		},
+	}
+	g.Expect(testStorage.MkdirAll(*present.Status.Artifact)).To(Succeed())
+	g.Expect(os.WriteFile(testStorage.LocalPath(*present.Status.Artifact), []byte("present"), 0o600)).To(Succeed())
+
+	missing := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "audit"},
+		Status: sourcev1.GitRepositoryStatus{
+			Artifact: &sourcev1.Artifact{Path: "/gitrepository/audit/missing/main.tar.gz"},
+		},
+	}
+	suspended := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "suspended", Namespace: "audit"},
+		Spec:       sourcev1.GitRepositorySpec{Suspend: true},
+		Status: sourcev1.GitRepositoryStatus{
+			Artifact: &sourcev1.Artifact{Path: "/gitrepository/audit/suspended/main.tar.gz"},
+		},
+	}
+
+	recorder := record.NewFakeRecorder(32)
+	a := &ArtifactAuditor{
+		Client: fakeclient.NewClientBuilder().
+			WithScheme(testEnv.GetScheme()).
+			WithObjects(present, missing, suspended).
+			Build(),
+		EventRecorder: recorder,
+		Storage:       testStorage,
+	}
+	g.Expect(a.Audit(context.TODO())).To(Succeed())
+
+	for _, tt := range []struct {
+		name      string
+		requested bool
+	}{
+		{name: "present", requested: false},
+		{name: "missing", requested: true},
+		{name: "suspended", requested: false},
+	} {
+		obj := &sourcev1.GitRepository{}
+		g.Expect(a.Get(context.TODO(), client.ObjectKey{Namespace: "audit", Name: tt.name}, obj)).To(Succeed())
+		_, ok := meta.ReconcileAnnotationValue(obj.GetAnnotations())
+		g.Expect(ok).To(Equal(tt.requested), tt.name)
+	}
+	g.Expect(recorder.Events).To(HaveLen(1))
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+// ArtifactConsumers protects the Artifacts of a Source object which are in
+// use by its consumers, e.g. Kustomization and HelmRelease objects, from
+// garbage collection.
+//
+// A consumer uses a Source object when one of its spec.sourceRef and
+// spec.chartRef references it, or when its status.helmChart is the
+// <namespace>/<name> of a HelmChart. The Artifacts in use are the ones with
+// the status.lastAttemptedRevision, status.lastAttemptedRevisionDigest or
+// status.lastAppliedRevision of the consumer.
+//
+// All methods are safe to call on a nil ArtifactConsumers, in which case no
+// Artifact is protected.
+type ArtifactConsumers struct {
+	// Reader lists the consumers, it should be the cache the consumers are
+	// indexed in with IndexConsumers.
+	Reader client.Reader
+	// Kinds are the kinds of the consumers. The kinds which are not
+	// installed in the cluster are ignored.
+	Kinds []schema.GroupVersionKind
+}
+
+// ParseConsumerKinds parses the given kinds in the form of
+// <group>/<version>/<kind>, e.g.
+// 'kustomize.toolkit.fluxcd.io/v1/Kustomization'.
+func ParseConsumerKinds(kinds []string) ([]schema.GroupVersionKind, error) {
+	var result []schema.GroupVersionKind
+	for _, k := range kinds {
+		i := strings.LastIndex(k, "/")
+		if i < 0 || i == len(k)-1 {
+			return nil, fmt.Errorf("invalid consumer kind '%s': must be in the form of <group>/<version>/<kind>", k)
+		}
+		gv, err := schema.ParseGroupVersion(k[:i])
+		if err != nil || gv.Group == "" {
+			return nil, fmt.Errorf("invalid consumer kind '%s': must be in the form of <group>/<version>/<kind>", k)
+		}
+		result = append(result, gv.WithKind(k[i+1:]))
+	}
+	return result, nil
+}
+
+// consumedSourceIndexKey is the key used for indexing the consumers based on
+// the <kind>/<namespace>/<name> of the Source objects they use.
+const consumedSourceIndexKey = ".metadata.consumedSource"
+
+// IndexConsumers registers the index the consumers are queried with on the
+// given indexer, which should be the cache of the Reader. The kinds which are
+// not installed in the cluster are removed from the Kinds.
+func (c *ArtifactConsumers) IndexConsumers(ctx context.Context, indexer client.FieldIndexer) error {
+	if c == nil {
+		return nil
+	}
+	var indexed []schema.GroupVersionKind
+	for _, gvk := range c.Kinds {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		if err := indexer.IndexField(ctx, obj, consumedSourceIndexKey, indexConsumedSources); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("failed to index %s consumers: %w", gvk.Kind, err)
+		}
+		indexed = append(indexed, gvk)
+	}
+	c.Kinds = indexed
+	return nil
+}
+
+// Revisions returns the revisions of the Source object with the given kind,
+// namespace and name which are in use by a consumer. An error is returned if
+// the consumers can not be listed, in which case none of the Artifacts of the
+// object should be garbage collected.
+func (c *ArtifactConsumers) Revisions(ctx context.Context, kind, namespace, name string) ([]string, error) {
+	if c == nil {
+		return nil, nil
+	}
+	seen := make(map[string]struct{})
+	var revisions []string
+	for _, gvk := range c.Kinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.Reader.List(ctx, list, client.MatchingFields{
+			consumedSourceIndexKey: fmt.Sprintf("%s/%s/%s", kind, namespace, name),
+		}); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s consumers: %w", gvk.Kind, err)
+		}
+		for _, consumer := range list.Items {
+			for _, field := range []string{"lastAttemptedRevision", "lastAttemptedRevisionDigest", "lastAppliedRevision"} {
+				revision, _, _ := unstructured.NestedString(consumer.Object, "status", field)
+				if _, ok := seen[revision]; revision == "" || ok {
+					continue
+				}
+				seen[revision] = struct{}{}
+				revisions = append(revisions, revision)
+			}
+		}
+	}
+	return revisions, nil
+}
+
+// indexConsumedSources returns the <kind>/<namespace>/<name> of the Source
+// objects used by the given consumer.
+func indexConsumedSources(obj client.Object) []string {
+	consumer, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	var sources []string
+	for _, field := range [][]string{{"spec", "sourceRef"}, {"spec", "chartRef"}} {
+		ref, ok, _ := unstructured.NestedStringMap(consumer.Object, field...)
+		if !ok || ref["kind"] == "" || ref["name"] == "" {
+			continue
+		}
+		namespace := ref["namespace"]
+		if namespace == "" {
+			namespace = consumer.GetNamespace()
+		}
+		sources = append(sources, fmt.Sprintf("%s/%s/%s", ref["kind"], namespace, ref["name"]))
+	}
+	if chart, _, _ := unstructured.NestedString(consumer.Object, "status", "helmChart"); chart != "" {
+		sources = append(sources, fmt.Sprintf("%s/%s", v1.HelmChartKind, chart))
+	}
+	return sources
+}
+
+// consumedArtifacts returns the files stored next to the given Artifact
+// which hold a revision in use by a consumer of the Source object it belongs
+// to, as Artifacts with their path and revision.
+func (s Storage) consumedArtifacts(ctx context.Context, artifact v1.Artifact) ([]v1.Artifact, error) {
+	if s.Consumers == nil {
+		return nil, nil
+	}
+	parts := strings.Split(artifact.Path, "/")
+	if len(parts) != 4 {
+		return nil, nil
+	}
+	src, ok := newArtifactSource(parts[0])
+	if !ok {
+		return nil, nil
+	}
+	kind, namespace, name := sourceKind(src), parts[1], parts[2]
+
+	revisions, err := s.Consumers.Revisions(ctx, kind, namespace, name)
+	if err != nil || len(revisions) == 0 {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Dir(s.LocalPath(artifact)))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	var consumed []v1.Artifact
+	for _, e := range entries {
+		if !e.Type().IsRegular() || filepath.Ext(e.Name()) == ".lock" {
+			continue
+		}
+		for _, revision := range revisions {
+			if holdsRevision(kind, e.Name(), revision) {
+				consumed = append(consumed, v1.Artifact{
+					Path:     v1.ArtifactPath(kind, namespace, name, e.Name()),
+					Revision: revision,
+				})
+				break
+			}
+		}
+	}
+	return consumed, nil
+}
+
+// holdsRevision returns true if the file with the given name is the
+// Artifact of the given revision for a Source object of the given kind,
+// according to the names the reconcilers give to the Artifacts, with or
+// without the digest appended by an Immutable Storage.
+func holdsRevision(kind, name, revision string) bool {
+	if mutable := mutablePath(name); mutable != name && holdsNamedRevision(kind, mutable, revision) {
+		return true
+	}
+	return holdsNamedRevision(kind, name, revision)
+}
+
+// holdsNamedRevision returns true if the given file name is the one the
+// reconcilers give to the Artifact of the given revision for a Source
+// object of the given kind.
+func holdsNamedRevision(kind, name, revision string) bool {
+	if kind == v1.HelmChartKind {
+		return strings.HasSuffix(name, "-"+revision+".tgz")
+	}
+	dgst := digest.Digest(revision[strings.LastIndex(revision, "@")+1:])
+	if dgst.Validate() != nil {
+		return false
+	}
+	for _, prefix := range []string{dgst.String(), dgst.Encoded(), "index-" + dgst.Encoded()} {
+		if strings.HasPrefix(name, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
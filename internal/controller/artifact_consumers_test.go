@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestStorage_GarbageCollectConsumed(t *testing.T) {
+	g := NewWithT(t)
+
+	kustomizationKind := schema.GroupVersionKind{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"}
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(kustomizationKind, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(kustomizationKind.GroupVersion().WithKind("KustomizationList"), &unstructured.UnstructuredList{})
+
+	consumed := strings.Repeat("a", 40)
+	consumer := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"sourceRef": map[string]interface{}{"kind": sourcev1.GitRepositoryKind, "name": "podinfo"},
+		},
+		"status": map[string]interface{}{
+			"lastAppliedRevision": "main@sha1:" + consumed,
+		},
+	}}
+	consumer.SetGroupVersionKind(kustomizationKind)
+	consumer.SetNamespace("default")
+	consumer.SetName("podinfo")
+
+	releaseKind := schema.GroupVersionKind{Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"}
+	scheme.AddKnownTypeWithName(releaseKind, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(releaseKind.GroupVersion().WithKind("HelmReleaseList"), &unstructured.UnstructuredList{})
+
+	chart := strings.Repeat("d", 64)
+	release := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"chartRef": map[string]interface{}{"kind": sourcev1.OCIRepositoryKind, "name": "podinfo", "namespace": "flux-system"},
+		},
+		"status": map[string]interface{}{
+			"lastAttemptedRevision":       "6.1.0",
+			"lastAttemptedRevisionDigest": "sha256:" + chart,
+		},
+	}}
+	release.SetGroupVersionKind(releaseKind)
+	release.SetNamespace("default")
+	release.SetName("podinfo")
+
+	dir := t.TempDir()
+	storage, err := NewStorage(dir, "hostname", time.Minute, 1)
+	g.Expect(err).ToNot(HaveOccurred())
+	storage.Consumers = &ArtifactConsumers{
+		Reader: fakeclient.NewClientBuilder().WithScheme(scheme).
+			WithIndex(consumer.DeepCopy(), consumedSourceIndexKey, indexConsumedSources).
+			WithIndex(release.DeepCopy(), consumedSourceIndexKey, indexConsumedSources).
+			WithObjects(consumer, release).Build(),
+		Kinds: []schema.GroupVersionKind{kustomizationKind, releaseKind},
+	}
+
+	now := time.Now()
+	for name, age := range map[string]time.Duration{
+		consumed + ".tar.gz":                3 * time.Hour,
+		strings.Repeat("b", 40) + ".tar.gz": 2 * time.Hour,
+		strings.Repeat("c", 40) + ".tar.gz": 0,
+	} {
+		p := filepath.Join(dir, "gitrepository", "default", "podinfo", name)
+		g.Expect(os.MkdirAll(filepath.Dir(p), 0o700)).To(Succeed())
+		g.Expect(os.WriteFile(p, []byte(name), 0o600)).To(Succeed())
+		g.Expect(os.Chtimes(p, now.Add(-age), now.Add(-age))).To(Succeed())
+	}
+
+	artifact := sourcev1.Artifact{Path: "gitrepository/default/podinfo/" + strings.Repeat("c", 40) + ".tar.gz"}
+	deleted, err := storage.GarbageCollect(context.TODO(), artifact, 5*time.Second)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(deleted).To(ConsistOf(filepath.Join(dir, "gitrepository", "default", "podinfo", strings.Repeat("b", 40)+".tar.gz")))
+	g.Expect(filepath.Join(dir, "gitrepository", "default", "podinfo", consumed+".tar.gz")).To(BeAnExistingFile())
+
+	for name, age := range map[string]time.Duration{
+		chart + ".tar.gz":                   3 * time.Hour,
+		strings.Repeat("e", 64) + ".tar.gz": 2 * time.Hour,
+		strings.Repeat("f", 64) + ".tar.gz": 0,
+	} {
+		p := filepath.Join(dir, "ocirepository", "flux-system", "podinfo", name)
+		g.Expect(os.MkdirAll(filepath.Dir(p), 0o700)).To(Succeed())
+		g.Expect(os.WriteFile(p, []byte(name), 0o600)).To(Succeed())
+		g.Expect(os.Chtimes(p, now.Add(-age), now.Add(-age))).To(Succeed())
+	}
+
+	artifact = sourcev1.Artifact{Path: "ocirepository/flux-system/podinfo/" + strings.Repeat("f", 64) + ".tar.gz"}
+	deleted, err = storage.GarbageCollect(context.TODO(), artifact, 5*time.Second)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(deleted).To(ConsistOf(filepath.Join(dir, "ocirepository", "flux-system", "podinfo", strings.Repeat("e", 64)+".tar.gz")))
+	g.Expect(filepath.Join(dir, "ocirepository", "flux-system", "podinfo", chart+".tar.gz")).To(BeAnExistingFile())
+}
+
+func TestStorage_GarbageCollectConsumedImmutable(t *testing.T) {
+	g := NewWithT(t)
+
+	kustomizationKind := schema.GroupVersionKind{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"}
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(kustomizationKind, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(kustomizationKind.GroupVersion().WithKind("KustomizationList"), &unstructured.UnstructuredList{})
+
+	consumed := strings.Repeat("a", 40)
+	consumer := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"sourceRef": map[string]interface{}{"kind": sourcev1.GitRepositoryKind, "name": "podinfo"},
+		},
+		"status": map[string]interface{}{
+			"lastAppliedRevision": "main@sha1:" + consumed,
+		},
+	}}
+	consumer.SetGroupVersionKind(kustomizationKind)
+	consumer.SetNamespace("default")
+	consumer.SetName("podinfo")
+
+	dir := t.TempDir()
+	storage, err := NewStorage(dir, "hostname", time.Minute, 1)
+	g.Expect(err).ToNot(HaveOccurred())
+	storage.Immutable = true
+	storage.Consumers = &ArtifactConsumers{
+		Reader: fakeclient.NewClientBuilder().WithScheme(scheme).
+			WithIndex(consumer.DeepCopy(), consumedSourceIndexKey, indexConsumedSources).
+			WithObjects(consumer).Build(),
+		Kinds: []schema.GroupVersionKind{kustomizationKind},
+	}
+
+	// The consumed revision was stored twice with a different content, the
+	// second time under its immutable path.
+	consumedPath := "gitrepository/default/podinfo/" + consumed + ".tar.gz"
+	artifact := sourcev1.Artifact{Path: consumedPath}
+	g.Expect(storage.MkdirAll(artifact)).To(Succeed())
+	g.Expect(storage.Copy(&artifact, strings.NewReader("content"))).To(Succeed())
+	g.Expect(storage.Copy(&artifact, strings.NewReader("changed content"))).To(Succeed())
+	g.Expect(artifact.Path).ToNot(Equal(consumedPath))
+	g.Expect(os.Remove(storage.LocalPath(sourcev1.Artifact{Path: consumedPath}))).To(Succeed())
+
+	now := time.Now()
+	for name, age := range map[string]time.Duration{
+		filepath.Base(artifact.Path):        3 * time.Hour,
+		strings.Repeat("b", 40) + ".tar.gz": 2 * time.Hour,
+		strings.Repeat("c", 40) + ".tar.gz": 0,
+	} {
+		p := filepath.Join(dir, "gitrepository", "default", "podinfo", name)
+		g.Expect(os.WriteFile(p, []byte(name), 0o600)).To(Succeed())
+		g.Expect(os.Chtimes(p, now.Add(-age), now.Add(-age))).To(Succeed())
+	}
+
+	current := sourcev1.Artifact{Path: "gitrepository/default/podinfo/" + strings.Repeat("c", 40) + ".tar.gz"}
+	deleted, err := storage.GarbageCollect(context.TODO(), current, 5*time.Second)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(deleted).To(ConsistOf(filepath.Join(dir, "gitrepository", "default", "podinfo", strings.Repeat("b", 40)+".tar.gz")))
+	g.Expect(storage.LocalPath(artifact)).To(BeAnExistingFile())
+}
+
+func Test_indexConsumedSources(t *testing.T) {
+	g := NewWithT(t)
+
+	consumer := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"chartRef": map[string]interface{}{"kind": sourcev1.OCIRepositoryKind, "name": "podinfo", "namespace": "flux-system"},
+		},
+		"status": map[string]interface{}{
+			"helmChart": "flux-system/default-podinfo",
+		},
+	}}
+	consumer.SetNamespace("default")
+	g.Expect(indexConsumedSources(consumer)).To(Equal([]string{
+		sourcev1.OCIRepositoryKind + "/flux-system/podinfo",
+		sourcev1.HelmChartKind + "/flux-system/default-podinfo",
+	}))
+
+	consumer.Object["spec"] = map[string]interface{}{
+		"sourceRef": map[string]interface{}{"kind": sourcev1.GitRepositoryKind, "name": "podinfo"},
+	}
+	delete(consumer.Object, "status")
+	g.Expect(indexConsumedSources(consumer)).To(Equal([]string{sourcev1.GitRepositoryKind + "/default/podinfo"}))
+}
+
+func Test_holdsRevision(t *testing.T) {
+	sha256 := "sha256:" + strings.Repeat("a", 64)
+	tests := []struct {
+		kind     string
+		name     string
+		revision string
+		want     bool
+	}{
+		{sourcev1.GitRepositoryKind, strings.Repeat("a", 40) + ".tar.gz", "main@sha1:" + strings.Repeat("a", 40), true},
+		{sourcev1.GitRepositoryKind, strings.Repeat("a", 40) + ".tar.gz", "sha1:" + strings.Repeat("b", 40), false},
+		{sourcev1.BucketKind, strings.Repeat("a", 64) + ".tar.gz", sha256, true},
+		{sourcev1.OCIRepositoryKind, sha256 + ".tar.gz", "latest@" + sha256, true},
+		{sourcev1.HelmRepositoryKind, "index-" + strings.Repeat("a", 64) + ".yaml", sha256, true},
+		{sourcev1.HelmChartKind, "podinfo-6.1.0.tgz", "6.1.0", true},
+		{sourcev1.HelmChartKind, "podinfo-6.1.0.tgz", "1.0", false},
+		{sourcev1.GitRepositoryKind, "a.tar.gz", "main", false},
+		{sourcev1.GitRepositoryKind, strings.Repeat("a", 40) + "-0123456789ab.tar.gz", "main@sha1:" + strings.Repeat("a", 40), true},
+		{sourcev1.GitRepositoryKind, strings.Repeat("a", 40) + "-0123456789ab.tar.gz", "sha1:" + strings.Repeat("b", 40), false},
+		{sourcev1.HelmRepositoryKind, "index-" + strings.Repeat("a", 64) + "-0123456789ab.yaml", sha256, true},
+		{sourcev1.HelmChartKind, "podinfo-6.1.0-0123456789ab.tgz", "6.1.0", true},
+		{sourcev1.HelmChartKind, "podinfo-6.1.0-0123456789ab.tgz", "1.0", false},
+		{sourcev1.HelmChartKind, "podinfo-0123456789ab.tgz", "0123456789ab", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind+"/"+tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(holdsRevision(tt.kind, tt.name, tt.revision)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestParseConsumerKinds(t *testing.T) {
+	g := NewWithT(t)
+
+	gvks, err := ParseConsumerKinds([]string{"kustomize.toolkit.fluxcd.io/v1/Kustomization", "helm.toolkit.fluxcd.io/v2/HelmRelease"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(gvks).To(Equal([]schema.GroupVersionKind{
+		{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"},
+		{Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"},
+	}))
+
+	for _, invalid := range []string{"Kustomization", "v1/Kustomization", "kustomize.toolkit.fluxcd.io/v1/"} {
+		_, err := ParseConsumerKinds([]string{invalid})
+		g.Expect(err).To(HaveOccurred(), invalid)
+	}
+}
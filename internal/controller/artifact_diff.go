@@ -0,0 +1,243 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/fluxcd/source-controller/api/v1"
+	intdigest "github.com/fluxcd/source-controller/internal/digest"
+)
+
+// ArtifactFileChange is a file which differs between two Artifacts.
+type ArtifactFileChange struct {
+	// Path of the file in the archive.
+	Path string `json:"path"`
+	// FromDigest is the digest of the file in the Artifact compared from,
+	// empty if the file was added.
+	FromDigest string `json:"fromDigest,omitempty"`
+	// ToDigest is the digest of the file in the Artifact compared to, empty
+	// if the file was removed.
+	ToDigest string `json:"toDigest,omitempty"`
+}
+
+// ArtifactDiff is the file-level difference between two Artifacts.
+type ArtifactDiff struct {
+	From    string               `json:"from"`
+	To      string               `json:"to"`
+	Added   []ArtifactFileChange `json:"added"`
+	Removed []ArtifactFileChange `json:"removed"`
+	Changed []ArtifactFileChange `json:"changed"`
+}
+
+// DiffArtifacts returns the files added, removed and changed between the
+// tarballs of the given Artifacts, compared by digest.
+func (s Storage) DiffArtifacts(from, to v1.Artifact) (*ArtifactDiff, error) {
+	fromFiles, err := s.archiveDigests(from)
+	if err != nil {
+		return nil, err
+	}
+	toFiles, err := s.archiveDigests(to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &ArtifactDiff{
+		From:    from.Revision,
+		To:      to.Revision,
+		Added:   []ArtifactFileChange{},
+		Removed: []ArtifactFileChange{},
+		Changed: []ArtifactFileChange{},
+	}
+	for p, d := range toFiles {
+		fd, ok := fromFiles[p]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, ArtifactFileChange{Path: p, ToDigest: d})
+		case fd != d:
+			diff.Changed = append(diff.Changed, ArtifactFileChange{Path: p, FromDigest: fd, ToDigest: d})
+		}
+	}
+	for p, d := range fromFiles {
+		if _, ok := toFiles[p]; !ok {
+			diff.Removed = append(diff.Removed, ArtifactFileChange{Path: p, FromDigest: d})
+		}
+	}
+	for _, changes := range [][]ArtifactFileChange{diff.Added, diff.Removed, diff.Changed} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	}
+	return diff, nil
+}
+
+// archiveDigests returns the digests of the regular files in the gzip
+// compressed tarball of the given Artifact, indexed by their cleaned path.
+func (s Storage) archiveDigests(artifact v1.Artifact) (map[string]string, error) {
+	if !strings.HasSuffix(artifact.Path, ".tar.gz") && !strings.HasSuffix(artifact.Path, ".tgz") {
+		return nil, fmt.Errorf("artifact '%s' is not a tarball", artifact.Path)
+	}
+	f, err := os.Open(s.LocalPath(artifact))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact '%s': %w", artifact.Path, err)
+	}
+	defer gr.Close()
+
+	files := make(map[string]string)
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read artifact '%s': %w", artifact.Path, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		d := intdigest.Canonical.Digester()
+		if _, err := io.Copy(d.Hash(), tr); err != nil {
+			return nil, fmt.Errorf("failed to read artifact '%s': %w", artifact.Path, err)
+		}
+		files[path.Clean(hdr.Name)] = d.Digest().String()
+	}
+}
+
+// ArtifactDiffer serves the
+// GET /artifacts/{kind}/{namespace}/{name}/diff?from=<revision>&to=<revision>
+// endpoint of the admin API, which returns the ArtifactDiff between two
+// revisions of a Source object as JSON. The revisions must be the one of an
+// Artifact of the object which is still in the Storage.
+type ArtifactDiffer struct {
+	Reader  client.Reader
+	Storage *Storage
+}
+
+// ServeHTTP implements http.Handler.
+func (d *ArtifactDiffer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	obj, ok := newArtifactSource(r.PathValue("kind"))
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown source kind '%s'", r.PathValue("kind")), http.StatusNotFound)
+		return
+	}
+	key := client.ObjectKey{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+	if err := d.Reader.Get(r.Context(), key, obj); err != nil {
+		status := http.StatusInternalServerError
+		if apierrors.IsNotFound(err) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	var artifacts [2]v1.Artifact
+	for i, param := range []string{"from", "to"} {
+		revision := r.URL.Query().Get(param)
+		artifact, ok := d.Storage.artifactForRevision(obj, revision)
+		if !ok {
+			http.Error(w, fmt.Sprintf("no stored artifact for %s revision '%s'", param, revision), http.StatusNotFound)
+			return
+		}
+		artifacts[i] = artifact
+	}
+
+	diff, err := d.Storage.DiffArtifacts(artifacts[0], artifacts[1])
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, os.ErrNotExist) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(diff)
+}
+
+// newArtifactSource returns an empty object of the given case-insensitive
+// Source kind.
+func newArtifactSource(kind string) (artifactSource, bool) {
+	switch strings.ToLower(kind) {
+	case strings.ToLower(v1.GitRepositoryKind):
+		return &v1.GitRepository{}, true
+	case strings.ToLower(v1.HelmRepositoryKind):
+		return &v1.HelmRepository{}, true
+	case strings.ToLower(v1.HelmChartKind):
+		return &v1.HelmChart{}, true
+	case strings.ToLower(v1.BucketKind):
+		return &v1.Bucket{}, true
+	case strings.ToLower(v1.OCIRepositoryKind):
+		return &v1.OCIRepository{}, true
+	default:
+		return nil, false
+	}
+}
+
+// artifactForRevision returns the current or pinned Artifact of the given
+// object with the given revision, or else any other Artifact of the object
+// in the Storage holding the revision, e.g. one which is kept for a
+// consumer or by the retention of the object.
+func (s Storage) artifactForRevision(obj artifactSource, revision string) (v1.Artifact, bool) {
+	if revision == "" {
+		return v1.Artifact{}, false
+	}
+	if a := obj.GetArtifact(); a != nil && a.Revision == revision {
+		return *a, true
+	}
+	for _, a := range obj.GetPinnedArtifacts() {
+		if a.Revision == revision {
+			return a, true
+		}
+	}
+
+	kind := sourceKind(obj)
+	dir := v1.Artifact{Path: v1.ArtifactDir(kind, obj.GetNamespace(), obj.GetName())}
+	entries, err := os.ReadDir(s.LocalPath(dir))
+	if err != nil {
+		return v1.Artifact{}, false
+	}
+	for _, e := range entries {
+		if !e.Type().IsRegular() || (!strings.HasSuffix(e.Name(), ".tar.gz") && !strings.HasSuffix(e.Name(), ".tgz")) {
+			continue
+		}
+		if holdsRevision(kind, e.Name(), revision) {
+			return v1.Artifact{
+				Path:     v1.ArtifactPath(kind, obj.GetNamespace(), obj.GetName(), e.Name()),
+				Revision: revision,
+			}, true
+		}
+	}
+	return v1.Artifact{}, false
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	intdigest "github.com/fluxcd/source-controller/internal/digest"
+)
+
+func TestArtifactDiffer(t *testing.T) {
+	g := NewWithT(t)
+
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	obj := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "default"},
+	}
+	archive := func(revision, fileName string, files map[string]string) sourcev1.Artifact {
+		dir := t.TempDir()
+		for name, content := range files {
+			g.Expect(os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o700)).To(Succeed())
+			g.Expect(os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600)).To(Succeed())
+		}
+		artifact := storage.NewArtifactFor(sourcev1.GitRepositoryKind, obj, revision, fileName)
+		g.Expect(storage.MkdirAll(artifact)).To(Succeed())
+		g.Expect(storage.Archive(&artifact, dir, nil)).To(Succeed())
+		return artifact
+	}
+	from := archive("v1", "v1.tar.gz", map[string]string{
+		"README.md":        "readme",
+		"deploy/app.yaml":  "app",
+		"deploy/old.yaml":  "old",
+		"deploy/same.yaml": "same",
+	})
+	to := archive("v2", "v2.tar.gz", map[string]string{
+		"README.md":        "readme",
+		"deploy/app.yaml":  "app v2",
+		"deploy/new.yaml":  "new",
+		"deploy/same.yaml": "same",
+	})
+	// An Artifact which is no longer in the status, but still in the Storage.
+	previous := intdigest.Canonical.FromString("previous")
+	archive("main@"+previous.String(), previous.Encoded()+".tar.gz", map[string]string{
+		"README.md": "readme",
+	})
+	obj.Status.Artifact = &to
+	obj.Status.PinnedArtifacts = []sourcev1.Artifact{from}
+
+	differ := &ArtifactDiffer{
+		Reader:  fakeclient.NewClientBuilder().WithScheme(testEnv.GetScheme()).WithObjects(obj).Build(),
+		Storage: storage,
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /artifacts/{kind}/{namespace}/{name}/diff", differ)
+
+	digestOf := func(s string) string {
+		return intdigest.Canonical.FromString(s).String()
+	}
+
+	tests := []struct {
+		name     string
+		target   string
+		wantCode int
+		want     *ArtifactDiff
+	}{
+		{
+			name:     "diff between revisions",
+			target:   "/artifacts/gitrepository/default/podinfo/diff?from=v1&to=v2",
+			wantCode: http.StatusOK,
+			want: &ArtifactDiff{
+				From:    "v1",
+				To:      "v2",
+				Added:   []ArtifactFileChange{{Path: "deploy/new.yaml", ToDigest: digestOf("new")}},
+				Removed: []ArtifactFileChange{{Path: "deploy/old.yaml", FromDigest: digestOf("old")}},
+				Changed: []ArtifactFileChange{{Path: "deploy/app.yaml", FromDigest: digestOf("app"), ToDigest: digestOf("app v2")}},
+			},
+		},
+		{
+			name:     "diff from stored revision",
+			target:   "/artifacts/gitrepository/default/podinfo/diff?from=main@" + previous.String() + "&to=v2",
+			wantCode: http.StatusOK,
+			want: &ArtifactDiff{
+				From: "main@" + previous.String(),
+				To:   "v2",
+				Added: []ArtifactFileChange{
+					{Path: "deploy/app.yaml", ToDigest: digestOf("app v2")},
+					{Path: "deploy/new.yaml", ToDigest: digestOf("new")},
+					{Path: "deploy/same.yaml", ToDigest: digestOf("same")},
+				},
+				Removed: []ArtifactFileChange{},
+				Changed: []ArtifactFileChange{},
+			},
+		},
+		{
+			name:     "unknown revision",
+			target:   "/artifacts/gitrepository/default/podinfo/diff?from=v0&to=v2",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "unknown kind",
+			target:   "/artifacts/kustomization/default/podinfo/diff?from=v1&to=v2",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "unknown object",
+			target:   "/artifacts/gitrepository/default/other/diff?from=v1&to=v2",
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			g.Expect(rec.Code).To(Equal(tt.wantCode))
+			if tt.want == nil {
+				return
+			}
+			got := &ArtifactDiff{}
+			g.Expect(json.NewDecoder(rec.Body).Decode(got)).To(Succeed())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
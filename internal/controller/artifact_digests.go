@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ArtifactDigests looks up the digests recorded in the status of the Source
+// objects for their Artifacts, so that the file server does not have to hash
+// an Artifact to describe it.
+type ArtifactDigests struct {
+	// Reader gets the Source objects, it should be backed by a cache to not
+	// get them from the API server on every request.
+	Reader client.Reader
+}
+
+// Digest returns the digest and size recorded for the Artifact with the
+// given path in the Storage, if it is the current Artifact of its Source
+// object.
+func (d *ArtifactDigests) Digest(ctx context.Context, artifactPath string) (digest.Digest, int64, bool) {
+	if d == nil {
+		return "", 0, false
+	}
+	artifactPath = strings.TrimPrefix(path.Clean("/"+artifactPath), "/")
+	parts := strings.Split(artifactPath, "/")
+	if len(parts) != 4 {
+		return "", 0, false
+	}
+	obj, ok := newArtifactSource(parts[0])
+	if !ok {
+		return "", 0, false
+	}
+	if err := d.Reader.Get(ctx, types.NamespacedName{Namespace: parts[1], Name: parts[2]}, obj); err != nil {
+		return "", 0, false
+	}
+	artifact := obj.GetArtifact()
+	if artifact == nil || artifact.Path != artifactPath || artifact.Size == nil {
+		return "", 0, false
+	}
+	dgst, err := digest.Parse(artifact.Digest)
+	if err != nil {
+		return "", 0, false
+	}
+	return dgst, *artifact.Size, true
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestArtifactDigests_Digest(t *testing.T) {
+	g := NewWithT(t)
+
+	dgst := digest.FromString("content")
+	obj := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "podinfo"},
+		Status: sourcev1.GitRepositoryStatus{
+			Artifact: &sourcev1.Artifact{
+				Path:   "gitrepository/default/podinfo/abc.tar.gz",
+				Digest: dgst.String(),
+				Size:   ptr.To[int64](7),
+			},
+		},
+	}
+	d := &ArtifactDigests{
+		Reader: fakeclient.NewClientBuilder().WithScheme(testEnv.GetScheme()).WithObjects(obj).Build(),
+	}
+
+	got, size, ok := d.Digest(context.TODO(), "/gitrepository/default/podinfo/abc.tar.gz")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(got).To(Equal(dgst))
+	g.Expect(size).To(Equal(int64(7)))
+
+	for _, p := range []string{
+		"/gitrepository/default/podinfo/old.tar.gz",
+		"/gitrepository/default/missing/abc.tar.gz",
+		"/unknown/default/podinfo/abc.tar.gz",
+		"/gitrepository/default/abc.tar.gz",
+	} {
+		_, _, ok := d.Digest(context.TODO(), p)
+		g.Expect(ok).To(BeFalse(), p)
+	}
+
+	var nilDigests *ArtifactDigests
+	_, _, ok = nilDigests.Digest(context.TODO(), "/gitrepository/default/podinfo/abc.tar.gz")
+	g.Expect(ok).To(BeFalse())
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/fluxcd/source-controller/api/v1"
+	intdigest "github.com/fluxcd/source-controller/internal/digest"
+)
+
+// StoredArtifact is a file stored in the Storage for a Source object.
+type StoredArtifact struct {
+	// Path of the file relative to the base path of the Storage.
+	Path string `json:"path"`
+	// Revision of the Artifact of the object the file belongs to, empty if
+	// the file is neither the current nor a pinned Artifact.
+	Revision     string    `json:"revision,omitempty"`
+	Digest       string    `json:"digest"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified"`
+	// Current is true if the file is the current Artifact of the object.
+	Current bool `json:"current,omitempty"`
+	// Pinned is true if the file is a pinned Artifact of the object.
+	Pinned bool `json:"pinned,omitempty"`
+}
+
+// ArtifactIndex lists the files stored for a Source object, ordered from
+// the most recently modified.
+type ArtifactIndex struct {
+	Kind      string           `json:"kind"`
+	Namespace string           `json:"namespace"`
+	Name      string           `json:"name"`
+	Artifacts []StoredArtifact `json:"artifacts"`
+}
+
+// ArtifactIndexFor returns the ArtifactIndex of the given object, with the
+// files stored in its directory of the Storage.
+func (s Storage) ArtifactIndexFor(obj artifactSource) (*ArtifactIndex, error) {
+	kind := sourceKind(obj)
+	index := &ArtifactIndex{
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Artifacts: []StoredArtifact{},
+	}
+
+	artifacts := make(map[string]v1.Artifact)
+	for _, a := range obj.GetPinnedArtifacts() {
+		artifacts[a.Path] = a
+	}
+	current := obj.GetArtifact()
+	if current != nil {
+		artifacts[current.Path] = *current
+	}
+
+	dir := filepath.Join(s.BasePath, v1.ArtifactDir(kind, obj.GetNamespace(), obj.GetName()))
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !e.Type().IsRegular() || filepath.Ext(e.Name()) == ".lock" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		p := path.Join(v1.ArtifactDir(kind, obj.GetNamespace(), obj.GetName()), e.Name())
+		dgst, err := digestFile(filepath.Join(dir, e.Name()), intdigest.Canonical)
+		if errors.Is(err, fs.ErrNotExist) {
+			// Garbage collected since the directory was read.
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to digest '%s': %w", p, err)
+		}
+		stored := StoredArtifact{
+			Path:         p,
+			Digest:       dgst.String(),
+			Size:         info.Size(),
+			LastModified: s.writeTime(filepath.Join(dir, e.Name()), info).UTC(),
+		}
+		if a, ok := artifacts[p]; ok {
+			stored.Revision = a.Revision
+			stored.Current = current != nil && current.Path == p
+			stored.Pinned = isPinned(obj, p)
+		}
+		index.Artifacts = append(index.Artifacts, stored)
+	}
+	sort.SliceStable(index.Artifacts, func(i, j int) bool {
+		return index.Artifacts[i].LastModified.After(index.Artifacts[j].LastModified)
+	})
+	return index, nil
+}
+
+// isPinned returns true if the file at the given path is a pinned Artifact
+// of the given object.
+func isPinned(obj artifactSource, p string) bool {
+	for _, a := range obj.GetPinnedArtifacts() {
+		if a.Path == p {
+			return true
+		}
+	}
+	return false
+}
+
+// ArtifactIndexer serves the GET /artifacts/{kind}/{namespace}/{name}
+// endpoint of the admin API, which returns the ArtifactIndex of a Source
+// object as JSON.
+type ArtifactIndexer struct {
+	Reader  client.Reader
+	Storage *Storage
+}
+
+// ServeHTTP implements http.Handler.
+func (i *ArtifactIndexer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	obj, ok := newArtifactSource(r.PathValue("kind"))
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown source kind '%s'", r.PathValue("kind")), http.StatusNotFound)
+		return
+	}
+	key := client.ObjectKey{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+	if err := i.Reader.Get(r.Context(), key, obj); err != nil {
+		status := http.StatusInternalServerError
+		if apierrors.IsNotFound(err) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	index, err := i.Storage.ArtifactIndexFor(obj)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(index)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestArtifactIndexer(t *testing.T) {
+	g := NewWithT(t)
+
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	obj := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "default"},
+	}
+	store := func(revision string, modTime time.Time) sourcev1.Artifact {
+		artifact := storage.NewArtifactFor(sourcev1.GitRepositoryKind, obj, revision, revision+".tar.gz")
+		g.Expect(storage.MkdirAll(artifact)).To(Succeed())
+		g.Expect(storage.AtomicWriteFile(&artifact, strings.NewReader(revision), 0o600)).To(Succeed())
+		g.Expect(os.Chtimes(storage.LocalPath(artifact), modTime, modTime)).To(Succeed())
+		return artifact
+	}
+	now := time.Now()
+	garbage := store("v1", now.Add(-2*time.Hour))
+	pinned := store("v2", now.Add(-time.Hour))
+	current := store("v3", now)
+	obj.Status.Artifact = &current
+	obj.Status.PinnedArtifacts = []sourcev1.Artifact{pinned}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /artifacts/{kind}/{namespace}/{name}", &ArtifactIndexer{
+		Reader:  fakeclient.NewClientBuilder().WithScheme(testEnv.GetScheme()).WithObjects(obj).Build(),
+		Storage: storage,
+	})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/artifacts/gitrepository/default/podinfo", nil))
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+
+	index := &ArtifactIndex{}
+	g.Expect(json.NewDecoder(rec.Body).Decode(index)).To(Succeed())
+	g.Expect(index.Kind).To(Equal(sourcev1.GitRepositoryKind))
+	g.Expect(index.Artifacts).To(HaveLen(3))
+
+	g.Expect(index.Artifacts[0].Path).To(Equal(current.Path))
+	g.Expect(index.Artifacts[0].Revision).To(Equal("v3"))
+	g.Expect(index.Artifacts[0].Digest).To(Equal(current.Digest))
+	g.Expect(index.Artifacts[0].Current).To(BeTrue())
+
+	g.Expect(index.Artifacts[1].Path).To(Equal(pinned.Path))
+	g.Expect(index.Artifacts[1].Pinned).To(BeTrue())
+	g.Expect(index.Artifacts[1].Current).To(BeFalse())
+
+	g.Expect(index.Artifacts[2].Path).To(Equal(garbage.Path))
+	g.Expect(index.Artifacts[2].Revision).To(BeEmpty())
+	g.Expect(index.Artifacts[2].Size).To(Equal(int64(len("v1"))))
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/artifacts/gitrepository/default/other", nil))
+	g.Expect(rec.Code).To(Equal(http.StatusNotFound))
+}
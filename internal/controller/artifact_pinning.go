@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	v1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+// PinArtifacts returns the Artifacts pinned by the
+// v1.PinnedRevisionsAnnotation in the given annotations.
+//
+// The previously pinned Artifacts are kept for as long as their revision is
+// listed and they exist in the Storage. The current Artifact is pinned when
+// its revision is listed, a revision can therefore only be pinned while it
+// is the current revision of the Source.
+func (s Storage) PinArtifacts(annotations map[string]string, current *v1.Artifact, pinned []v1.Artifact) []v1.Artifact {
+	revisions := pinnedRevisions(annotations)
+	if len(revisions) == 0 {
+		return nil
+	}
+
+	var result []v1.Artifact
+	for _, artifact := range pinned {
+		if _, ok := revisions[artifact.Revision]; ok && s.ArtifactExist(artifact) {
+			s.SetArtifactURL(&artifact)
+			result = append(result, artifact)
+		}
+	}
+	if current != nil && !containsArtifactPath(result, current.Path) {
+		if _, ok := revisions[current.Revision]; ok {
+			result = append(result, *current.DeepCopy())
+		}
+	}
+	return result
+}
+
+// withoutPinned returns the given garbage files without the files of the
+// pinned Artifacts.
+func (s Storage) withoutPinned(garbageFiles []string, pinned []v1.Artifact) []string {
+	if len(pinned) == 0 {
+		return garbageFiles
+	}
+	paths := make(map[string]struct{}, len(pinned))
+	for _, artifact := range pinned {
+		paths[s.LocalPath(artifact)] = struct{}{}
+	}
+	var result []string
+	for _, file := range garbageFiles {
+		if _, ok := paths[file]; !ok {
+			result = append(result, file)
+		}
+	}
+	return result
+}
+
+// pinnedRevisions returns the set of revisions listed in the
+// v1.PinnedRevisionsAnnotation.
+func pinnedRevisions(annotations map[string]string) map[string]struct{} {
+	revisions := make(map[string]struct{})
+	for _, revision := range strings.Split(annotations[v1.PinnedRevisionsAnnotation], ",") {
+		if revision = strings.TrimSpace(revision); revision != "" {
+			revisions[revision] = struct{}{}
+		}
+	}
+	return revisions
+}
+
+// containsArtifactPath returns true if any of the given Artifacts has the
+// given path.
+func containsArtifactPath(artifacts []v1.Artifact, path string) bool {
+	for _, artifact := range artifacts {
+		if artifact.Path == path {
+			return true
+		}
+	}
+	return false
+}
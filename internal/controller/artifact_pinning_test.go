@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestStorage_PinArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	storage, err := NewStorage(dir, "hostname", time.Minute, 2)
+	if err != nil {
+		t.Fatalf("error while bootstrapping storage: %v", err)
+	}
+
+	newArtifact := func(revision string, exists bool) sourcev1.Artifact {
+		artifact := sourcev1.Artifact{
+			Path:     "gitrepository/default/podinfo/" + revision + ".tar.gz",
+			Revision: revision,
+		}
+		if exists {
+			p := filepath.Join(dir, artifact.Path)
+			if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(p, []byte(revision), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return artifact
+	}
+	v1 := newArtifact("v1", true)
+	v2 := newArtifact("v2", true)
+	gone := newArtifact("gone", false)
+	current := newArtifact("v3", true)
+	storage.SetArtifactURL(&current)
+
+	tests := []struct {
+		name          string
+		annotation    string
+		pinned        []sourcev1.Artifact
+		wantRevisions []string
+	}{
+		{
+			name:   "no annotation unpins all",
+			pinned: []sourcev1.Artifact{v1, v2},
+		},
+		{
+			name:          "pins the current revision",
+			annotation:    "v3",
+			wantRevisions: []string{"v3"},
+		},
+		{
+			name:          "keeps the listed revisions",
+			annotation:    "v1, v3",
+			pinned:        []sourcev1.Artifact{v1, v2},
+			wantRevisions: []string{"v1", "v3"},
+		},
+		{
+			name:          "drops missing artifacts",
+			annotation:    "v1,gone",
+			pinned:        []sourcev1.Artifact{v1, gone},
+			wantRevisions: []string{"v1"},
+		},
+		{
+			name:       "does not pin past revisions",
+			annotation: "v2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			annotations := map[string]string{}
+			if tt.annotation != "" {
+				annotations[sourcev1.PinnedRevisionsAnnotation] = tt.annotation
+			}
+			got := storage.PinArtifacts(annotations, current.DeepCopy(), tt.pinned)
+
+			var revisions []string
+			for _, artifact := range got {
+				revisions = append(revisions, artifact.Revision)
+				g.Expect(artifact.URL).ToNot(BeEmpty())
+			}
+			g.Expect(revisions).To(Equal(tt.wantRevisions))
+		})
+	}
+}
+
+func TestStorage_GarbageCollectPinned(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	storage, err := NewStorage(dir, "hostname", time.Second, 1)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var artifacts []sourcev1.Artifact
+	for i, revision := range []string{"v1", "v2", "v3"} {
+		artifact := sourcev1.Artifact{
+			Path:     "gitrepository/default/podinfo/" + revision + ".tar.gz",
+			Revision: revision,
+		}
+		p := filepath.Join(dir, artifact.Path)
+		g.Expect(os.MkdirAll(filepath.Dir(p), 0o700)).To(Succeed())
+		g.Expect(os.WriteFile(p, []byte(revision), 0o600)).To(Succeed())
+		modTime := time.Now().Add(-time.Duration(3-i) * time.Hour)
+		g.Expect(os.Chtimes(p, modTime, modTime)).To(Succeed())
+		artifacts = append(artifacts, artifact)
+	}
+
+	deleted, err := storage.GarbageCollect(context.TODO(), artifacts[2], 5*time.Second, artifacts[0])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(deleted).To(ConsistOf(storage.LocalPath(artifacts[1])))
+	g.Expect(storage.LocalPath(artifacts[0])).To(BeAnExistingFile())
+	g.Expect(storage.LocalPath(artifacts[2])).To(BeAnExistingFile())
+}
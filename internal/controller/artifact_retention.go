@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+// retentionFor returns a copy of the Storage garbage collecting the
+// Artifacts of the given object with the retention options overridden by
+// its spec.artifact.retention, capped by the ArtifactRetentionMaxTTL and
+// ArtifactRetentionMaxRecords of the Storage.
+func (s Storage) retentionFor(obj client.Object) Storage {
+	retention := artifactRetention(obj)
+	if retention == nil {
+		return s
+	}
+	if ttl := retention.TTL; ttl != nil && ttl.Duration >= 0 {
+		s.ArtifactRetentionTTL = ttl.Duration
+		if s.ArtifactRetentionMaxTTL > 0 && s.ArtifactRetentionTTL > s.ArtifactRetentionMaxTTL {
+			s.ArtifactRetentionTTL = s.ArtifactRetentionMaxTTL
+		}
+	}
+	if records := retention.MaxRecords; records != nil && *records >= 1 {
+		s.ArtifactRetentionRecords = *records
+		if s.ArtifactRetentionMaxRecords > 0 && s.ArtifactRetentionRecords > s.ArtifactRetentionMaxRecords {
+			s.ArtifactRetentionRecords = s.ArtifactRetentionMaxRecords
+		}
+	}
+	return s
+}
+
+// artifactRetention returns the spec.artifact.retention of the given object,
+// or nil if it has none.
+func artifactRetention(obj client.Object) *v1.ArtifactRetention {
+	var spec *v1.ArtifactSpec
+	switch o := obj.(type) {
+	case *v1.GitRepository:
+		spec = o.Spec.Artifact
+	case *v1.OCIRepository:
+		spec = o.Spec.Artifact
+	case *v1.HelmChart:
+		spec = o.Spec.Artifact
+	case *v1.Bucket:
+		spec = o.Spec.Artifact
+	}
+	if spec == nil {
+		return nil
+	}
+	return spec.Retention
+}
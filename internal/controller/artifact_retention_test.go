@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestStorage_retentionFor(t *testing.T) {
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage.ArtifactRetentionMaxTTL = 24 * time.Hour
+	storage.ArtifactRetentionMaxRecords = 10
+
+	records := func(n int) *int { return &n }
+	tests := []struct {
+		name        string
+		retention   *sourcev1.ArtifactRetention
+		wantTTL     time.Duration
+		wantRecords int
+	}{
+		{
+			name:        "no overrides",
+			wantTTL:     time.Minute,
+			wantRecords: 2,
+		},
+		{
+			name: "overrides",
+			retention: &sourcev1.ArtifactRetention{
+				TTL:        &metav1.Duration{Duration: time.Hour},
+				MaxRecords: records(5),
+			},
+			wantTTL:     time.Hour,
+			wantRecords: 5,
+		},
+		{
+			name: "overrides capped to the maximum",
+			retention: &sourcev1.ArtifactRetention{
+				TTL:        &metav1.Duration{Duration: 30 * 24 * time.Hour},
+				MaxRecords: records(100),
+			},
+			wantTTL:     24 * time.Hour,
+			wantRecords: 10,
+		},
+		{
+			name: "invalid overrides",
+			retention: &sourcev1.ArtifactRetention{
+				TTL:        &metav1.Duration{Duration: -time.Hour},
+				MaxRecords: records(0),
+			},
+			wantTTL:     time.Minute,
+			wantRecords: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := &sourcev1.GitRepository{
+				ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "default"},
+			}
+			if tt.retention != nil {
+				obj.Spec.Artifact = &sourcev1.ArtifactSpec{Retention: tt.retention}
+			}
+			gc := storage.retentionFor(obj)
+			g.Expect(gc.ArtifactRetentionTTL).To(Equal(tt.wantTTL))
+			g.Expect(gc.ArtifactRetentionRecords).To(Equal(tt.wantRecords))
+			g.Expect(storage.ArtifactRetentionRecords).To(Equal(2))
+		})
+	}
+}
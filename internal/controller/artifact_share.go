@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+// ArtifactShare remembers the Artifacts recently stored for content
+// addressed revisions, so that the objects resolving the same revision with
+// the same content configuration reuse the stored Artifact instead of
+// fetching the content again from upstream.
+//
+// The key of an Artifact must identify its content completely. As the
+// content is shared across namespaces, it must only be derived from a
+// revision the object resolved with its own credentials.
+//
+// All methods are safe to call on a nil ArtifactShare, in which case
+// nothing is shared.
+type ArtifactShare struct {
+	// Window is the duration for which a stored Artifact is shared.
+	Window time.Duration
+
+	mu      sync.Mutex
+	entries map[string]sharedArtifact
+	now     func() time.Time
+}
+
+type sharedArtifact struct {
+	artifact sourcev1.Artifact
+	at       time.Time
+}
+
+// NewArtifactShare returns a new ArtifactShare sharing the stored
+// Artifacts for the given window.
+func NewArtifactShare(window time.Duration) *ArtifactShare {
+	return &ArtifactShare{
+		Window:  window,
+		entries: make(map[string]sharedArtifact),
+		now:     time.Now,
+	}
+}
+
+// Get returns the Artifact shared with the given key, if it was stored
+// within the window and still exists in the given Storage.
+func (s *ArtifactShare) Get(storage *Storage, key string) *sourcev1.Artifact {
+	if s == nil || storage == nil {
+		return nil
+	}
+	s.mu.Lock()
+	e, ok := s.entries[key]
+	if ok && s.now().Sub(e.at) > s.Window {
+		delete(s.entries, key)
+		ok = false
+	}
+	s.mu.Unlock()
+	if !ok || !storage.ArtifactExist(e.artifact) {
+		return nil
+	}
+	return e.artifact.DeepCopy()
+}
+
+// Put shares the given Artifact with the given key for the window, and
+// forgets the expired Artifacts.
+func (s *ArtifactShare) Put(key string, artifact sourcev1.Artifact) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for k, e := range s.entries {
+		if now.Sub(e.at) > s.Window {
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = sharedArtifact{artifact: *artifact.DeepCopy(), at: now}
+}
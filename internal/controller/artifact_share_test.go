@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestArtifactShare(t *testing.T) {
+	g := NewWithT(t)
+
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "file"), []byte("content"), 0o600)).To(Succeed())
+	artifact := storage.NewArtifactFor(sourcev1.OCIRepositoryKind, &metav1.ObjectMeta{Namespace: "team-a", Name: "podinfo"}, "latest@sha256:abc", "abc.tar.gz")
+	g.Expect(storage.MkdirAll(artifact)).To(Succeed())
+	g.Expect(storage.Archive(&artifact, dir, nil)).To(Succeed())
+
+	now := time.Now()
+	share := NewArtifactShare(time.Minute)
+	share.now = func() time.Time { return now }
+	share.Put("key", artifact)
+
+	shared := share.Get(storage, "key")
+	g.Expect(shared).ToNot(BeNil())
+	g.Expect(shared.Digest).To(Equal(artifact.Digest))
+	g.Expect(share.Get(storage, "other")).To(BeNil())
+
+	// Artifacts are shared for the window only.
+	share.now = func() time.Time { return now.Add(2 * time.Minute) }
+	g.Expect(share.Get(storage, "key")).To(BeNil())
+
+	// Artifacts missing from the storage are not shared.
+	share.now = func() time.Time { return now }
+	share.Put("key", artifact)
+	g.Expect(storage.Remove(artifact)).To(Succeed())
+	g.Expect(share.Get(storage, "key")).To(BeNil())
+
+	var nilShare *ArtifactShare
+	nilShare.Put("key", artifact)
+	g.Expect(nilShare.Get(storage, "key")).To(BeNil())
+}
+
+func TestOCIShareKey(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := &sourcev1.OCIRepository{}
+	key := ociShareKey(obj, "ghcr.io/org/repo", "sha256:abc")
+	g.Expect(ociShareKey(obj.DeepCopy(), "ghcr.io/org/repo", "sha256:abc")).To(Equal(key))
+	g.Expect(ociShareKey(obj, "ghcr.io/org/repo", "sha256:def")).ToNot(Equal(key))
+	g.Expect(ociShareKey(obj, "ghcr.io/org/private", "sha256:abc")).ToNot(Equal(key))
+
+	ignored := obj.DeepCopy()
+	ignored.Spec.Ignore = ptr.To("*.md")
+	g.Expect(ociShareKey(ignored, "ghcr.io/org/repo", "sha256:abc")).ToNot(Equal(key))
+
+	copied := obj.DeepCopy()
+	copied.Spec.LayerSelector = &sourcev1.OCILayerSelector{Operation: sourcev1.OCILayerCopy}
+	g.Expect(ociShareKey(copied, "ghcr.io/org/repo", "sha256:abc")).ToNot(Equal(key))
+}
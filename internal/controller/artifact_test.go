@@ -18,6 +18,10 @@ package controller
 
 import (
 	"testing"
+
+	. "github.com/onsi/gomega"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 )
 
 func Test_artifactSet_Diff(t *testing.T) {
@@ -122,3 +126,28 @@ func Test_artifactSet_Diff(t *testing.T) {
 		})
 	}
 }
+
+func Test_artifactEventMetadata(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(artifactEventMetadata(nil, FilesystemBackend)).To(BeNil())
+
+	size := int64(1024)
+	artifact := &sourcev1.Artifact{
+		Revision: "main@sha1:4ea3e7a",
+		Digest:   "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		Size:     &size,
+	}
+	g.Expect(artifactEventMetadata(artifact, FilesystemBackend)).To(Equal(map[string]string{
+		"source.toolkit.fluxcd.io/revision": artifact.Revision,
+		"source.toolkit.fluxcd.io/digest":   artifact.Digest,
+		"source.toolkit.fluxcd.io/size":     "1024",
+		"source.toolkit.fluxcd.io/storage":  FilesystemBackend,
+	}))
+
+	artifact.Size = nil
+	g.Expect(artifactEventMetadata(artifact, "")).To(Equal(map[string]string{
+		"source.toolkit.fluxcd.io/revision": artifact.Revision,
+		"source.toolkit.fluxcd.io/digest":   artifact.Digest,
+	}))
+}
@@ -44,9 +44,10 @@ import (
 
 	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
 	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/auth"
+	"github.com/fluxcd/pkg/cache"
 	"github.com/fluxcd/pkg/runtime/conditions"
 	helper "github.com/fluxcd/pkg/runtime/controller"
-	"github.com/fluxcd/pkg/runtime/jitter"
 	"github.com/fluxcd/pkg/runtime/patch"
 	"github.com/fluxcd/pkg/runtime/predicates"
 	rreconcile "github.com/fluxcd/pkg/runtime/reconcile"
@@ -55,11 +56,18 @@ import (
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	intdigest "github.com/fluxcd/source-controller/internal/digest"
 	serror "github.com/fluxcd/source-controller/internal/error"
+	"github.com/fluxcd/source-controller/internal/features"
 	"github.com/fluxcd/source-controller/internal/index"
+	intjitter "github.com/fluxcd/source-controller/internal/jitter"
+	"github.com/fluxcd/source-controller/internal/proxy"
+	"github.com/fluxcd/source-controller/internal/ratelimit"
 	sreconcile "github.com/fluxcd/source-controller/internal/reconcile"
 	"github.com/fluxcd/source-controller/internal/reconcile/summarize"
 	"github.com/fluxcd/source-controller/internal/tls"
+	"github.com/fluxcd/source-controller/internal/upstream"
+	"github.com/fluxcd/source-controller/internal/workspace"
 	"github.com/fluxcd/source-controller/pkg/azure"
+	"github.com/fluxcd/source-controller/pkg/fetch"
 	"github.com/fluxcd/source-controller/pkg/gcp"
 	"github.com/fluxcd/source-controller/pkg/minio"
 )
@@ -85,6 +93,7 @@ var bucketReadyCondition = summarize.Conditions{
 		sourcev1.FetchFailedCondition,
 		sourcev1.ArtifactOutdatedCondition,
 		sourcev1.ArtifactInStorageCondition,
+		sourcev1.SLOViolatedCondition,
 		meta.ReadyCondition,
 		meta.ReconcilingCondition,
 		meta.StalledCondition,
@@ -125,12 +134,21 @@ type BucketReconciler struct {
 
 	Storage        *Storage
 	ControllerName string
-
-	patchOptions []patch.Option
+	TokenCache     *cache.TokenCache
+	Upstream       *upstream.Accountant
+	Certificates   *upstream.CertificateMonitor
+	Workspaces     *workspace.Manager
+	Diagnostics    *ReconcileDiagnostics
+
+	maxFailureBackoff time.Duration
+	patchOptions      []patch.Option
 }
 
 type BucketReconcilerOptions struct {
-	RateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+	RateLimiter       workqueue.TypedRateLimiter[reconcile.Request]
+	MaxFailureBackoff time.Duration
+	NamespaceLimiter  *ratelimit.NamespaceLimiter
+	Backlog           *ReconcileBacklog
 }
 
 // BucketProvider is an interface for fetching objects from a storage provider
@@ -165,6 +183,7 @@ func (r *BucketReconciler) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 func (r *BucketReconciler) SetupWithManagerAndOptions(mgr ctrl.Manager, opts BucketReconcilerOptions) error {
+	r.maxFailureBackoff = opts.MaxFailureBackoff
 	r.patchOptions = getPatchOptions(bucketReadyCondition.Owned, r.ControllerName)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -173,7 +192,7 @@ func (r *BucketReconciler) SetupWithManagerAndOptions(mgr ctrl.Manager, opts Buc
 		WithOptions(controller.Options{
 			RateLimiter: opts.RateLimiter,
 		}).
-		Complete(r)
+		Complete(opts.NamespaceLimiter.Reconciler(sourcev1.BucketKind, opts.Backlog.Reconciler(sourcev1.BucketKind, r)))
 }
 
 func (r *BucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) {
@@ -186,6 +205,20 @@ func (r *BucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Leave the object untouched while in maintenance mode.
+	if r.Storage.Maintenance.Enabled() {
+		log.V(1).Info("controller is in maintenance mode, skipping reconciliation")
+		skipReconcile(ctx)
+		return ctrl.Result{RequeueAfter: r.Storage.Maintenance.RequeueAfter()}, nil
+	}
+
+	// Wait for the storage fencing token before writing to the Storage.
+	if !r.Storage.Fence.Acquired() {
+		log.V(1).Info("storage fencing token not acquired yet, skipping reconciliation")
+		skipReconcile(ctx)
+		return ctrl.Result{RequeueAfter: storageFenceRequeueAfter}, nil
+	}
+
 	// Initialize the patch helper with the current version of the object.
 	serialPatcher := patch.NewSerialPatcher(obj, r.Client)
 
@@ -206,9 +239,10 @@ func (r *BucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 				summarize.RecordReconcileReq,
 			),
 			summarize.WithResultBuilder(sreconcile.AlwaysRequeueResultBuilder{
-				RequeueAfter: jitter.JitteredIntervalDuration(obj.GetRequeueAfter()),
+				RequeueAfter: intjitter.JitteredIntervalDuration(sourcev1.BucketKind, obj.GetRequeueAfter()),
 			}),
 			summarize.WithPatchFieldOwner(r.ControllerName),
+			summarize.WithFailureBackoff(r.maxFailureBackoff),
 		}
 		result, retErr = summarizeHelper.SummarizeAndPatch(ctx, obj, summarizeOpts...)
 
@@ -219,6 +253,11 @@ func (r *BucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 	// Examine if the object is under deletion.
 	if !obj.ObjectMeta.DeletionTimestamp.IsZero() {
 		recResult, retErr = r.reconcileDelete(ctx, obj)
+		if retErr == nil {
+			if err := r.Diagnostics.Remove(obj); err != nil {
+				log.Error(err, "failed to remove diagnostic bundle")
+			}
+		}
 		return
 	}
 
@@ -235,6 +274,9 @@ func (r *BucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 	// Return if the object is suspended.
 	if obj.Spec.Suspend {
 		log.Info("reconciliation is suspended for this object")
+		if err := collectSuspended(ctx, r.Storage, r.EventRecorder, obj, 5*time.Second); err != nil {
+			log.Error(err, "garbage collection failed")
+		}
 		recResult, retErr = sreconcile.ResultEmpty, nil
 		return
 	}
@@ -246,6 +288,8 @@ func (r *BucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 		r.reconcileArtifact,
 	}
 	recResult, retErr = r.reconcile(ctx, serialPatcher, obj, reconcilers)
+	reconcileSLO(ctx, r.EventRecorder, obj, time.Since(start))
+	r.Diagnostics.Record(ctx, obj, start, retErr)
 	return
 }
 
@@ -277,7 +321,7 @@ func (r *BucketReconciler) reconcile(ctx context.Context, sp *patch.SerialPatche
 	}
 
 	// Create temp working dir
-	tmpDir, err := os.MkdirTemp("", fmt.Sprintf("%s-%s-%s-", obj.Kind, obj.Namespace, obj.Name))
+	tmpDir, err := r.Workspaces.Create(obj)
 	if err != nil {
 		e := serror.NewGeneric(
 			fmt.Errorf("failed to create temporary working directory: %w", err),
@@ -287,7 +331,7 @@ func (r *BucketReconciler) reconcile(ctx context.Context, sp *patch.SerialPatche
 		return sreconcile.ResultEmpty, e
 	}
 	defer func() {
-		if err = os.RemoveAll(tmpDir); err != nil {
+		if err = r.Workspaces.Remove(tmpDir); err != nil {
 			ctrl.LoggerFrom(ctx).Error(err, "failed to remove temporary working directory")
 		}
 	}()
@@ -309,7 +353,7 @@ func (r *BucketReconciler) reconcile(ctx context.Context, sp *patch.SerialPatche
 		// If an error is received, prioritize the returned results because an
 		// error also means immediate requeue.
 		if err != nil {
-			resErr = err
+			resErr = r.Storage.Backoff(obj, err)
 			res = recResult
 			break
 		}
@@ -327,10 +371,7 @@ func (r *BucketReconciler) notify(ctx context.Context, oldObj, newObj *sourcev1.
 	// Notify successful reconciliation for new artifact and recovery from any
 	// failure.
 	if resErr == nil && res == sreconcile.ResultSuccess && newObj.Status.Artifact != nil {
-		annotations := map[string]string{
-			fmt.Sprintf("%s/%s", sourcev1.GroupVersion.Group, eventv1.MetaRevisionKey): newObj.Status.Artifact.Revision,
-			fmt.Sprintf("%s/%s", sourcev1.GroupVersion.Group, eventv1.MetaDigestKey):   newObj.Status.Artifact.Digest,
-		}
+		annotations := artifactEventMetadata(newObj.Status.Artifact, r.Storage.Backend())
 
 		message := fmt.Sprintf("stored artifact with %d fetched files from '%s' bucket", index.Len(), newObj.Spec.BucketName)
 
@@ -469,7 +510,18 @@ func (r *BucketReconciler) reconcileSource(ctx context.Context, sp *patch.Serial
 		if proxyURL != nil {
 			opts = append(opts, azure.WithProxyURL(proxyURL))
 		}
-		if provider, err = azure.NewClient(obj, opts...); err != nil {
+		if obj.Spec.ServiceAccountName != "" {
+			// Check object-level workload identity feature gate.
+			const gate = auth.FeatureGateObjectLevelWorkloadIdentity
+			if enabled, _ := features.EnabledFor(gate, obj.GetNamespace()); !enabled {
+				const msgFmt = "to use spec.serviceAccountName for provider authentication please enable the %s feature gate in the controller"
+				e := serror.NewStalling(fmt.Errorf(msgFmt, gate), meta.FeatureGateDisabledReason)
+				conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
+				return sreconcile.ResultEmpty, e
+			}
+			opts = append(opts, azure.WithAuth(r.authOptions(obj, proxyURL)...))
+		}
+		if provider, err = azure.NewClient(ctx, obj, opts...); err != nil {
 			e := serror.NewGeneric(err, "ClientError")
 			conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
 			return sreconcile.ResultEmpty, e
@@ -539,23 +591,21 @@ func (r *BucketReconciler) reconcileSource(ctx context.Context, sp *patch.Serial
 			return sreconcile.ResultEmpty, e
 		}
 	}
+	provider = newAccountedBucketProvider(provider, r.Upstream, obj)
+	pipeline := &fetch.Pipeline{
+		Fetcher: &bucketFetcher{provider: provider, obj: obj, index: index, dir: dir},
+	}
 
-	// Fetch etag index
-	if err = fetchEtagIndex(ctx, provider, obj, index, dir); err != nil {
-		e := serror.NewGeneric(err, sourcev1.BucketOperationFailedReason)
+	// Resolve the revision from the etag index
+	revision, upToDate, err := pipeline.Resolve(ctx, obj.GetArtifact())
+	if err != nil {
+		e := serror.NewGeneric(serror.Classified(err), sourcev1.BucketOperationFailedReason)
 		conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
 		return sreconcile.ResultEmpty, e
 	}
 
-	// Check if index has changed compared to current Artifact revision.
-	var changed bool
-	if artifact := obj.Status.Artifact; artifact != nil && artifact.Revision != "" {
-		curRev := digest.Digest(artifact.Revision)
-		changed = curRev.Validate() != nil || curRev != index.Digest(curRev.Algorithm())
-	}
-
 	// Fetch the bucket objects if required to.
-	if artifact := obj.GetArtifact(); artifact == nil || changed {
+	if !upToDate {
 		// Mark observations about the revision on the object
 		defer func() {
 			// As fetchIndexFiles can make last-minute modifications to the etag
@@ -573,13 +623,17 @@ func (r *BucketReconciler) reconcileSource(ctx context.Context, sp *patch.Serial
 			}
 		}()
 
-		if err = fetchIndexFiles(ctx, provider, obj, index, dir); err != nil {
-			e := serror.NewGeneric(err, sourcev1.BucketOperationFailedReason)
+		if _, err = pipeline.Fetch(ctx, dir, revision); err != nil {
+			e := serror.NewGeneric(serror.Classified(err), sourcev1.BucketOperationFailedReason)
 			conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
 			return sreconcile.ResultEmpty, e
 		}
 	}
 
+	if cert := observeUpstreamCertificate(ctx, r.Certificates, r.EventRecorder, obj,
+		obj.Spec.Endpoint, obj.Spec.Insecure, proxyURL); cert != nil {
+		obj.Status.UpstreamCertificate = cert
+	}
 	conditions.Delete(obj, sourcev1.FetchFailedCondition)
 	return sreconcile.ResultSuccess, nil
 }
@@ -594,11 +648,18 @@ func (r *BucketReconciler) reconcileSource(ctx context.Context, sp *patch.Serial
 // On a successful archive, the Artifact in the Status of the object is set,
 // and the symlink in the Storage is updated to its path.
 func (r *BucketReconciler) reconcileArtifact(ctx context.Context, sp *patch.SerialPatcher, obj *sourcev1.Bucket, index *index.Digester, dir string) (sreconcile.Result, error) {
+	pipeline := &fetch.Pipeline{
+		Store: r.Storage,
+		NewArtifact: func(revision string) sourcev1.Artifact {
+			return r.Storage.NewArtifactFor(obj.Kind, obj, revision, fmt.Sprintf("%s.tar.gz", digest.Digest(revision).Encoded()))
+		},
+	}
+
 	// Calculate revision
 	revision := index.Digest(intdigest.Canonical)
 
 	// Create artifact
-	artifact := r.Storage.NewArtifactFor(obj.Kind, obj, revision.String(), fmt.Sprintf("%s.tar.gz", revision.Encoded()))
+	artifact := pipeline.NewArtifact(revision.String())
 
 	// Set the ArtifactInStorageCondition if there's no drift.
 	defer func() {
@@ -621,6 +682,13 @@ func (r *BucketReconciler) reconcileArtifact(ctx context.Context, sp *patch.Seri
 		}
 	}
 
+	// Refuse to archive a working directory exceeding the workspace quota
+	if err := r.Workspaces.CheckQuota(dir); err != nil {
+		e := serror.NewGeneric(err, sourcev1.WorkspaceQuotaExceededReason)
+		conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
+		return sreconcile.ResultEmpty, e
+	}
+
 	// Ensure target path exists and is a directory
 	if f, err := os.Stat(dir); err != nil {
 		e := serror.NewGeneric(
@@ -638,33 +706,17 @@ func (r *BucketReconciler) reconcileArtifact(ctx context.Context, sp *patch.Seri
 		return sreconcile.ResultEmpty, e
 	}
 
-	// Ensure artifact directory exists and acquire lock
-	if err := r.Storage.MkdirAll(artifact); err != nil {
-		e := serror.NewGeneric(
-			fmt.Errorf("failed to create artifact directory: %w", err),
-			sourcev1.DirCreationFailedReason,
-		)
-		conditions.MarkTrue(obj, sourcev1.StorageOperationFailedCondition, e.Reason, "%s", e)
-		return sreconcile.ResultEmpty, e
-	}
-	unlock, err := r.Storage.Lock(artifact)
-	if err != nil {
-		return sreconcile.ResultEmpty, serror.NewGeneric(
-			fmt.Errorf("failed to acquire lock for artifact: %w", err),
-			meta.FailedReason,
-		)
-	}
-	defer unlock()
-
 	// Archive directory to storage
-	if err := r.Storage.Archive(&artifact, dir, nil); err != nil {
-		e := serror.NewGeneric(
-			fmt.Errorf("unable to archive artifact to storage: %s", err),
-			sourcev1.ArchiveOperationFailedReason,
-		)
+	stored, err := pipeline.StoreArtifact(ctx, dir, revision.String())
+	if err != nil {
+		var e *serror.Generic
+		if !errors.As(err, &e) {
+			e = serror.NewGeneric(err, sourcev1.ArchiveOperationFailedReason)
+		}
 		conditions.MarkTrue(obj, sourcev1.StorageOperationFailedCondition, e.Reason, "%s", e)
 		return sreconcile.ResultEmpty, e
 	}
+	artifact = stored
 
 	// Record it on the object
 	obj.Status.Artifact = artifact.DeepCopy()
@@ -693,9 +745,15 @@ func (r *BucketReconciler) reconcileDelete(ctx context.Context, obj *sourcev1.Bu
 		return sreconcile.ResultEmpty, err
 	}
 
+	forgetUpstreamCertificates(r.Certificates, obj)
+
 	// Remove our finalizer from the list
 	controllerutil.RemoveFinalizer(obj, sourcev1.SourceFinalizer)
 
+	// Cleanup caches.
+	r.TokenCache.DeleteEventsForObject(sourcev1.BucketKind,
+		obj.GetName(), obj.GetNamespace(), cache.OperationReconcile)
+
 	// Stop reconciliation as the object is being deleted
 	return sreconcile.ResultEmpty, nil
 }
@@ -717,10 +775,15 @@ func (r *BucketReconciler) garbageCollect(ctx context.Context, obj *sourcev1.Buc
 				"garbage collected artifacts for deleted resource")
 		}
 		obj.Status.Artifact = nil
+		obj.Status.PinnedArtifacts = nil
 		return nil
 	}
 	if obj.GetArtifact() != nil {
-		delFiles, err := r.Storage.GarbageCollect(ctx, *obj.GetArtifact(), time.Second*5)
+		obj.Status.PinnedArtifacts = r.Storage.PinArtifacts(obj.GetAnnotations(), obj.GetArtifact(), obj.Status.PinnedArtifacts)
+		if r.Storage.DeferGarbageCollection {
+			return nil
+		}
+		delFiles, err := r.Storage.retentionFor(obj).GarbageCollect(ctx, *obj.GetArtifact(), time.Second*5, obj.Status.PinnedArtifacts...)
 		if err != nil {
 			return serror.NewGeneric(
 				fmt.Errorf("garbage collection of artifacts failed: %w", err),
@@ -772,29 +835,37 @@ func (r *BucketReconciler) getTLSConfig(ctx context.Context,
 }
 
 // getProxyURL attempts to fetch a proxy URL from the object's proxy secret
-// reference.
+// reference. It returns nil if the endpoint is excluded from the proxy.
 func (r *BucketReconciler) getProxyURL(ctx context.Context, obj *sourcev1.Bucket) (*url.URL, error) {
-	namespace := obj.GetNamespace()
-	proxySecret, err := r.getSecret(ctx, obj.Spec.ProxySecretRef, namespace)
+	proxySecret, err := r.getSecret(ctx, obj.Spec.ProxySecretRef, obj.GetNamespace())
 	if err != nil || proxySecret == nil {
 		return nil, err
 	}
-	proxyData := proxySecret.Data
-	address, ok := proxyData["address"]
-	if !ok {
-		return nil, fmt.Errorf("invalid proxy secret '%s/%s': key 'address' is missing",
-			namespace, obj.Spec.ProxySecretRef.Name)
+	return proxy.URLFromSecret(proxySecret, obj.Spec.Endpoint)
+}
+
+// authOptions returns the options to fetch an access token for the
+// ServiceAccount of the given Bucket, caching the token if the TokenCache
+// is set.
+func (r *BucketReconciler) authOptions(obj *sourcev1.Bucket, proxyURL *url.URL) []auth.Option {
+	serviceAccount := client.ObjectKey{
+		Name:      obj.Spec.ServiceAccountName,
+		Namespace: obj.GetNamespace(),
 	}
-	proxyURL, err := url.Parse(string(address))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse proxy address '%s': %w", address, err)
+	opts := []auth.Option{auth.WithServiceAccount(serviceAccount, r.Client)}
+	if r.TokenCache != nil {
+		involvedObject := cache.InvolvedObject{
+			Kind:      sourcev1.BucketKind,
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+			Operation: cache.OperationReconcile,
+		}
+		opts = append(opts, auth.WithCache(*r.TokenCache, involvedObject))
 	}
-	user, hasUser := proxyData["username"]
-	password, hasPassword := proxyData["password"]
-	if hasUser || hasPassword {
-		proxyURL.User = url.UserPassword(string(user), string(password))
+	if proxyURL != nil {
+		opts = append(opts, auth.WithProxyURL(*proxyURL))
 	}
-	return proxyURL, nil
+	return opts
 }
 
 // getSTSSecret attempts to fetch the secret from the object's STS secret
@@ -841,6 +912,43 @@ func (r *BucketReconciler) annotatedEventLogf(ctx context.Context,
 	r.AnnotatedEventf(obj, annotations, eventType, reason, msg)
 }
 
+// bucketFetcher implements fetch.Fetcher for a v1.Bucket, resolving its
+// revision from the etag index of the objects in the bucket.
+type bucketFetcher struct {
+	provider BucketProvider
+	obj      *sourcev1.Bucket
+	index    *index.Digester
+	// dir is the directory to which the ignore file of the bucket is fetched
+	// while resolving the revision.
+	dir string
+}
+
+// Resolve fetches the etag index of the bucket, and returns its digest. The
+// digest is calculated with the algorithm of the revision of the current
+// Artifact if valid, so that an Artifact stored with another algorithm is
+// not considered outdated.
+func (f *bucketFetcher) Resolve(ctx context.Context) (string, error) {
+	if err := fetchEtagIndex(ctx, f.provider, f.obj, f.index, f.dir); err != nil {
+		return "", err
+	}
+	if artifact := f.obj.GetArtifact(); artifact != nil {
+		if curRev := digest.Digest(artifact.Revision); curRev.Validate() == nil && f.index.Digest(curRev.Algorithm()) == curRev {
+			return curRev.String(), nil
+		}
+	}
+	return f.index.Digest(intdigest.Canonical).String(), nil
+}
+
+// Fetch fetches the objects of the etag index to the given directory, and
+// returns the digest of the index as updated with the objects which changed
+// or disappeared since it was fetched.
+func (f *bucketFetcher) Fetch(ctx context.Context, dir, _ string) (string, error) {
+	if err := fetchIndexFiles(ctx, f.provider, f.obj, f.index, dir); err != nil {
+		return "", err
+	}
+	return f.index.Digest(intdigest.Canonical).String(), nil
+}
+
 // fetchEtagIndex fetches the current etagIndex for the in the obj specified
 // bucket using the given provider, while filtering them using .sourceignore
 // rules. After fetching an object, the etag value in the index is updated to
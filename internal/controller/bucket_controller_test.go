@@ -38,6 +38,7 @@ import (
 
 	kstatus "github.com/fluxcd/cli-utils/pkg/kstatus/status"
 	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/auth"
 	"github.com/fluxcd/pkg/runtime/conditions"
 	conditionscheck "github.com/fluxcd/pkg/runtime/conditions/check"
 	"github.com/fluxcd/pkg/runtime/jitter"
@@ -45,6 +46,7 @@ import (
 
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	intdigest "github.com/fluxcd/source-controller/internal/digest"
+	serror "github.com/fluxcd/source-controller/internal/error"
 	"github.com/fluxcd/source-controller/internal/index"
 	gcsmock "github.com/fluxcd/source-controller/internal/mock/gcs"
 	s3mock "github.com/fluxcd/source-controller/internal/mock/s3"
@@ -1364,6 +1366,47 @@ func TestBucketReconciler_reconcileSource_gcs(t *testing.T) {
 	}
 }
 
+func TestBucketReconciler_reconcileSource_azureObjectLevelWorkloadIdentity(t *testing.T) {
+	g := NewWithT(t)
+
+	t.Setenv(auth.EnvVarEnableObjectLevelWorkloadIdentity, "false")
+
+	r := &BucketReconciler{
+		EventRecorder: record.NewFakeRecorder(32),
+		Client: fakeclient.NewClientBuilder().
+			WithScheme(testEnv.Scheme()).
+			WithStatusSubresource(&sourcev1.Bucket{}).
+			Build(),
+		Storage:      testStorage,
+		patchOptions: getPatchOptions(bucketReadyCondition.Owned, "sc"),
+	}
+	obj := &sourcev1.Bucket{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-bucket",
+			Namespace:  "default",
+			Generation: 1,
+		},
+		Spec: sourcev1.BucketSpec{
+			Provider:           sourcev1.BucketProviderAzure,
+			BucketName:         "podinfo",
+			Endpoint:           "https://podinfo.blob.core.windows.net",
+			ServiceAccountName: "podinfo",
+			Timeout:            &metav1.Duration{Duration: timeout},
+		},
+	}
+	g.Expect(r.Client.Create(context.TODO(), obj)).To(Succeed())
+
+	sp := patch.NewSerialPatcher(obj, r.Client)
+	got, err := r.reconcileSource(context.TODO(), sp, obj, index.NewDigester(), t.TempDir())
+	g.Expect(got).To(Equal(sreconcile.ResultEmpty))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("please enable the ObjectLevelWorkloadIdentity feature gate"))
+
+	var stallingErr *serror.Stalling
+	g.Expect(errors.As(err, &stallingErr)).To(BeTrue())
+	g.Expect(conditions.GetReason(obj, sourcev1.FetchFailedCondition)).To(Equal(meta.FeatureGateDisabledReason))
+}
+
 func TestBucketReconciler_reconcileArtifact(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/fluxcd/source-controller/internal/upstream"
+)
+
+// accountedBucketProvider is a BucketProvider recording the requests made
+// to, and the objects downloaded from, the endpoint of a Bucket.
+type accountedBucketProvider struct {
+	BucketProvider
+	accountant *upstream.Accountant
+	host       string
+}
+
+// newAccountedBucketProvider returns the given BucketProvider wrapped in an
+// accountedBucketProvider for the endpoint of the given Bucket. It returns
+// the provider as is for a nil Accountant.
+func newAccountedBucketProvider(provider BucketProvider, accountant *upstream.Accountant, obj *sourcev1.Bucket) BucketProvider {
+	if accountant == nil {
+		return provider
+	}
+	return &accountedBucketProvider{
+		BucketProvider: provider,
+		accountant:     accountant,
+		host:           upstream.Host(obj.Spec.Endpoint),
+	}
+}
+
+// BucketExists implements BucketProvider.
+func (p *accountedBucketProvider) BucketExists(ctx context.Context, bucketName string) (bool, error) {
+	if err := p.accountant.Allow(p.host); err != nil {
+		return false, err
+	}
+	p.accountant.RecordRequest(p.host)
+	return p.BucketProvider.BucketExists(ctx, bucketName)
+}
+
+// FGetObject implements BucketProvider, recording the size of the written
+// file as the number of bytes downloaded.
+func (p *accountedBucketProvider) FGetObject(ctx context.Context, bucketName, objectKey, targetPath string) (string, error) {
+	if err := p.accountant.Allow(p.host); err != nil {
+		return "", err
+	}
+	p.accountant.RecordRequest(p.host)
+	etag, err := p.BucketProvider.FGetObject(ctx, bucketName, objectKey, targetPath)
+	if err == nil {
+		if fi, statErr := os.Stat(targetPath); statErr == nil {
+			p.accountant.RecordBytes(p.host, fi.Size())
+		}
+	}
+	return etag, err
+}
+
+// VisitObjects implements BucketProvider.
+func (p *accountedBucketProvider) VisitObjects(ctx context.Context, bucketName string, prefix string, visit func(key, etag string) error) error {
+	if err := p.accountant.Allow(p.host); err != nil {
+		return err
+	}
+	p.accountant.RecordRequest(p.host)
+	return p.BucketProvider.VisitObjects(ctx, bucketName, prefix, visit)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fluxcd/source-controller/internal/features"
+)
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+
+// FeatureGateOverridesLoader loads the per-namespace feature gate overrides
+// from a ConfigMap, and keeps them up-to-date when the ConfigMap changes.
+type FeatureGateOverridesLoader struct {
+	// Reader reads the ConfigMap, it should not be backed by a cache to
+	// avoid watching all ConfigMaps.
+	Reader client.Reader
+
+	// Name and Namespace identify the ConfigMap.
+	Name      string
+	Namespace string
+
+	// Interval at which the ConfigMap is checked for changes.
+	Interval time.Duration
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. All replicas
+// check the feature gates of the objects they reconcile.
+func (l *FeatureGateOverridesLoader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start applies the overrides at the configured interval until the given
+// context is canceled. Invalid overrides are logged, and the previously
+// applied ones are kept.
+func (l *FeatureGateOverridesLoader) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("feature-gate-overrides")
+	ticker := time.NewTicker(l.Interval)
+	defer ticker.Stop()
+	for {
+		overrides, err := l.Load(ctx)
+		if err != nil {
+			log.Error(err, "unable to load feature gate overrides")
+		} else {
+			features.SetNamespaceOverrides(overrides)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Load returns the per-namespace feature gate overrides of the ConfigMap.
+// A missing ConfigMap results in no overrides.
+func (l *FeatureGateOverridesLoader) Load(ctx context.Context) (map[string]map[string]bool, error) {
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: l.Namespace, Name: l.Name}
+	if err := l.Reader.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get feature gate overrides ConfigMap '%s': %w", key, err)
+	}
+	overrides, err := features.ParseNamespaceOverrides(cm.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid feature gate overrides ConfigMap '%s': %w", key, err)
+	}
+	return overrides, nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/fluxcd/pkg/auth"
+)
+
+func TestFeatureGateOverridesLoader_Load(t *testing.T) {
+	gate := auth.FeatureGateObjectLevelWorkloadIdentity
+
+	tests := []struct {
+		name    string
+		data    map[string]string
+		want    map[string]map[string]bool
+		wantErr string
+	}{
+		{
+			name: "no ConfigMap",
+		},
+		{
+			name: "valid overrides",
+			data: map[string]string{
+				"team-a": gate + "=true",
+				"team-b": " " + gate + " = false ,",
+			},
+			want: map[string]map[string]bool{
+				"team-a": {gate: true},
+				"team-b": {gate: false},
+			},
+		},
+		{
+			name:    "unsupported feature gate",
+			data:    map[string]string{"team-a": "CacheSecretsAndConfigMaps=true"},
+			wantErr: "can not be overridden per namespace",
+		},
+		{
+			name:    "invalid value",
+			data:    map[string]string{"team-a": gate + "=yes"},
+			wantErr: "invalid value for feature gate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			builder := fakeclient.NewClientBuilder().WithScheme(testEnv.GetScheme())
+			if tt.data != nil {
+				builder.WithObjects(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "feature-gates", Namespace: "flux-system"},
+					Data:       tt.data,
+				})
+			}
+			l := &FeatureGateOverridesLoader{
+				Reader:    builder.Build(),
+				Name:      "feature-gates",
+				Namespace: "flux-system",
+			}
+
+			got, err := l.Load(context.TODO())
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			if tt.want == nil {
+				g.Expect(got).To(BeEmpty())
+				return
+			}
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -54,7 +55,6 @@ import (
 	"github.com/fluxcd/pkg/git/repository"
 	"github.com/fluxcd/pkg/runtime/conditions"
 	helper "github.com/fluxcd/pkg/runtime/controller"
-	"github.com/fluxcd/pkg/runtime/jitter"
 	"github.com/fluxcd/pkg/runtime/patch"
 	"github.com/fluxcd/pkg/runtime/predicates"
 	rreconcile "github.com/fluxcd/pkg/runtime/reconcile"
@@ -64,9 +64,14 @@ import (
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	serror "github.com/fluxcd/source-controller/internal/error"
 	"github.com/fluxcd/source-controller/internal/features"
+	intjitter "github.com/fluxcd/source-controller/internal/jitter"
+	"github.com/fluxcd/source-controller/internal/proxy"
+	"github.com/fluxcd/source-controller/internal/ratelimit"
 	sreconcile "github.com/fluxcd/source-controller/internal/reconcile"
 	"github.com/fluxcd/source-controller/internal/reconcile/summarize"
-	"github.com/fluxcd/source-controller/internal/util"
+	"github.com/fluxcd/source-controller/internal/tracing"
+	"github.com/fluxcd/source-controller/internal/upstream"
+	"github.com/fluxcd/source-controller/internal/workspace"
 )
 
 // gitRepositoryReadyCondition contains the information required to summarize a
@@ -80,6 +85,7 @@ var gitRepositoryReadyCondition = summarize.Conditions{
 		sourcev1.ArtifactOutdatedCondition,
 		sourcev1.ArtifactInStorageCondition,
 		sourcev1.SourceVerifiedCondition,
+		sourcev1.SLOViolatedCondition,
 		meta.ReadyCondition,
 		meta.ReconcilingCondition,
 		meta.StalledCondition,
@@ -134,16 +140,24 @@ type GitRepositoryReconciler struct {
 	Storage        *Storage
 	ControllerName string
 	TokenCache     *cache.TokenCache
+	Upstream       *upstream.Accountant
+	Certificates   *upstream.CertificateMonitor
+	Workspaces     *workspace.Manager
+	Diagnostics    *ReconcileDiagnostics
 
 	requeueDependency time.Duration
 	features          map[string]bool
 
-	patchOptions []patch.Option
+	maxFailureBackoff time.Duration
+	patchOptions      []patch.Option
 }
 
 type GitRepositoryReconcilerOptions struct {
 	DependencyRequeueInterval time.Duration
 	RateLimiter               workqueue.TypedRateLimiter[reconcile.Request]
+	MaxFailureBackoff         time.Duration
+	NamespaceLimiter          *ratelimit.NamespaceLimiter
+	Backlog                   *ReconcileBacklog
 }
 
 // gitRepositoryReconcileFunc is the function type for all the
@@ -155,6 +169,7 @@ func (r *GitRepositoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 func (r *GitRepositoryReconciler) SetupWithManagerAndOptions(mgr ctrl.Manager, opts GitRepositoryReconcilerOptions) error {
+	r.maxFailureBackoff = opts.MaxFailureBackoff
 	r.patchOptions = getPatchOptions(gitRepositoryReadyCondition.Owned, r.ControllerName)
 
 	r.requeueDependency = opts.DependencyRequeueInterval
@@ -170,7 +185,7 @@ func (r *GitRepositoryReconciler) SetupWithManagerAndOptions(mgr ctrl.Manager, o
 		WithOptions(controller.Options{
 			RateLimiter: opts.RateLimiter,
 		}).
-		Complete(r)
+		Complete(opts.NamespaceLimiter.Reconciler(sourcev1.GitRepositoryKind, opts.Backlog.Reconciler(sourcev1.GitRepositoryKind, r)))
 }
 
 func (r *GitRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) {
@@ -183,6 +198,26 @@ func (r *GitRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Record the reconciliation as the parent span of the Git and storage
+	// operations. The span is ended after the object has been patched.
+	ctx, span := tracing.StartSpan(ctx, "reconcile "+sourcev1.GitRepositoryKind,
+		tracing.ObjectAttributes(sourcev1.GitRepositoryKind, obj.Namespace, obj.Name)...)
+	defer func() { tracing.EndSpan(span, retErr) }()
+
+	// Leave the object untouched while in maintenance mode.
+	if r.Storage.Maintenance.Enabled() {
+		log.V(1).Info("controller is in maintenance mode, skipping reconciliation")
+		skipReconcile(ctx)
+		return ctrl.Result{RequeueAfter: r.Storage.Maintenance.RequeueAfter()}, nil
+	}
+
+	// Wait for the storage fencing token before writing to the Storage.
+	if !r.Storage.Fence.Acquired() {
+		log.V(1).Info("storage fencing token not acquired yet, skipping reconciliation")
+		skipReconcile(ctx)
+		return ctrl.Result{RequeueAfter: storageFenceRequeueAfter}, nil
+	}
+
 	// Initialize the patch helper with the current version of the object.
 	serialPatcher := patch.NewSerialPatcher(obj, r.Client)
 
@@ -204,9 +239,10 @@ func (r *GitRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 				summarize.RecordReconcileReq,
 			),
 			summarize.WithResultBuilder(sreconcile.AlwaysRequeueResultBuilder{
-				RequeueAfter: jitter.JitteredIntervalDuration(obj.GetRequeueAfter()),
+				RequeueAfter: intjitter.JitteredIntervalDuration(sourcev1.GitRepositoryKind, obj.GetRequeueAfter()),
 			}),
 			summarize.WithPatchFieldOwner(r.ControllerName),
+			summarize.WithFailureBackoff(r.maxFailureBackoff),
 		}
 		result, retErr = summarizeHelper.SummarizeAndPatch(ctx, obj, summarizeOpts...)
 
@@ -217,6 +253,11 @@ func (r *GitRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	// Examine if the object is under deletion.
 	if !obj.ObjectMeta.DeletionTimestamp.IsZero() {
 		recResult, retErr = r.reconcileDelete(ctx, obj)
+		if retErr == nil {
+			if err := r.Diagnostics.Remove(obj); err != nil {
+				log.Error(err, "failed to remove diagnostic bundle")
+			}
+		}
 		return
 	}
 
@@ -233,6 +274,9 @@ func (r *GitRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	// Return if the object is suspended.
 	if obj.Spec.Suspend {
 		log.Info("reconciliation is suspended for this object")
+		if err := collectSuspended(ctx, r.Storage, r.EventRecorder, obj, 5*time.Second); err != nil {
+			log.Error(err, "garbage collection failed")
+		}
 		recResult, retErr = sreconcile.ResultEmpty, nil
 		return
 	}
@@ -245,6 +289,8 @@ func (r *GitRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		r.reconcileArtifact,
 	}
 	recResult, retErr = r.reconcile(ctx, serialPatcher, obj, reconcilers)
+	reconcileSLO(ctx, r.EventRecorder, obj, time.Since(start))
+	r.Diagnostics.Record(ctx, obj, start, retErr)
 	return
 }
 
@@ -277,7 +323,7 @@ func (r *GitRepositoryReconciler) reconcile(ctx context.Context, sp *patch.Seria
 	}
 
 	// Create temp dir for Git clone
-	tmpDir, err := util.TempDirForObj("", obj)
+	tmpDir, err := r.Workspaces.Create(obj)
 	if err != nil {
 		e := serror.NewGeneric(
 			fmt.Errorf("failed to create temporary working directory: %w", err),
@@ -287,7 +333,7 @@ func (r *GitRepositoryReconciler) reconcile(ctx context.Context, sp *patch.Seria
 		return sreconcile.ResultEmpty, e
 	}
 	defer func() {
-		if err = os.RemoveAll(tmpDir); err != nil {
+		if err = r.Workspaces.Remove(tmpDir); err != nil {
 			ctrl.LoggerFrom(ctx).Error(err, "failed to remove temporary working directory")
 		}
 	}()
@@ -310,7 +356,7 @@ func (r *GitRepositoryReconciler) reconcile(ctx context.Context, sp *patch.Seria
 		// If an error is received, prioritize the returned results because an
 		// error also means immediate requeue.
 		if err != nil {
-			resErr = err
+			resErr = r.Storage.Backoff(obj, err)
 			res = recResult
 			break
 		}
@@ -328,10 +374,7 @@ func (r *GitRepositoryReconciler) notify(ctx context.Context, oldObj, newObj *so
 	// Notify successful reconciliation for new artifact, no-op reconciliation
 	// and recovery from any failure.
 	if r.shouldNotify(oldObj, newObj, res, resErr) {
-		annotations := map[string]string{
-			fmt.Sprintf("%s/%s", sourcev1.GroupVersion.Group, eventv1.MetaRevisionKey): newObj.Status.Artifact.Revision,
-			fmt.Sprintf("%s/%s", sourcev1.GroupVersion.Group, eventv1.MetaDigestKey):   newObj.Status.Artifact.Digest,
-		}
+		annotations := artifactEventMetadata(newObj.Status.Artifact, r.Storage.Backend())
 
 		// A partial commit due to no-op clone doesn't contain the commit
 		// message information. Have separate message for it.
@@ -485,7 +528,7 @@ func (r *GitRepositoryReconciler) reconcileSource(ctx context.Context, sp *patch
 	var proxyURL *url.URL
 	if obj.Spec.ProxySecretRef != nil {
 		var err error
-		proxyOpts, proxyURL, err = r.getProxyOpts(ctx, obj.Spec.ProxySecretRef.Name, obj.GetNamespace())
+		proxyOpts, proxyURL, err = r.getProxyOpts(ctx, obj.Spec.ProxySecretRef.Name, obj.GetNamespace(), obj.Spec.URL)
 		if err != nil {
 			e := serror.NewGeneric(
 				fmt.Errorf("failed to configure proxy options: %w", err),
@@ -549,6 +592,10 @@ func (r *GitRepositoryReconciler) reconcileSource(ctx context.Context, sp *patch
 	}
 	// Assign the commit to the shared commit reference.
 	*commit = *c
+	if cert := observeUpstreamCertificate(ctx, r.Certificates, r.EventRecorder, obj,
+		obj.Spec.URL, false, proxyURL); cert != nil {
+		obj.Status.UpstreamCertificate = cert
+	}
 
 	// If it's a partial commit obtained from an existing artifact, check if the
 	// reconciliation can be skipped if other configurations have not changed.
@@ -600,6 +647,11 @@ func (r *GitRepositoryReconciler) reconcileSource(ctx context.Context, sp *patch
 
 	// Verify commit signature
 	if result, err := r.verifySignature(ctx, obj, *commit); err != nil || result == sreconcile.ResultEmpty {
+		switch conditions.GetReason(obj, sourcev1.SourceVerifiedCondition) {
+		case "InvalidCommitSignature", "InvalidTagSignature", "InvalidGitObject":
+			quarantineUnverified(ctx, r.Storage, r.EventRecorder, obj.Kind, obj, commitReference(obj, commit),
+				fmt.Sprintf("%s.tar.gz", commit.Hash.String()), dir, obj.Spec.Ignore)
+		}
 		return result, err
 	}
 
@@ -619,38 +671,35 @@ func (r *GitRepositoryReconciler) reconcileSource(ctx context.Context, sp *patch
 
 // getProxyOpts fetches the secret containing the proxy settings, constructs a
 // transport.ProxyOptions object using those settings and then returns it.
+// It returns nil options if the given repository URL is excluded from the
+// proxy.
 func (r *GitRepositoryReconciler) getProxyOpts(ctx context.Context, proxySecretName,
-	proxySecretNamespace string) (*transport.ProxyOptions, *url.URL, error) {
-	proxyData, err := r.getSecretData(ctx, proxySecretName, proxySecretNamespace)
-	if err != nil {
+	proxySecretNamespace, repositoryURL string) (*transport.ProxyOptions, *url.URL, error) {
+	var secret corev1.Secret
+	key := types.NamespacedName{Namespace: proxySecretNamespace, Name: proxySecretName}
+	if err := r.Client.Get(ctx, key, &secret); err != nil {
 		return nil, nil, fmt.Errorf("failed to get proxy secret '%s/%s': %w", proxySecretNamespace, proxySecretName, err)
 	}
-	b, ok := proxyData["address"]
-	if !ok {
-		return nil, nil, fmt.Errorf("invalid proxy secret '%s/%s': key 'address' is missing", proxySecretNamespace, proxySecretName)
+	proxyURL, err := proxy.URLFromSecret(&secret, repositoryURL)
+	if err != nil || proxyURL == nil {
+		return nil, nil, err
 	}
 
-	address := string(b)
-	username := string(proxyData["username"])
-	password := string(proxyData["password"])
-
 	proxyOpts := &transport.ProxyOptions{
-		URL:      address,
-		Username: username,
-		Password: password,
-	}
-
-	proxyURL, err := url.Parse(string(address))
-	if err != nil {
-		return nil, nil, fmt.Errorf("invalid address in proxy secret '%s/%s': %w", proxySecretNamespace, proxySecretName, err)
+		URL:      string(secret.Data[proxy.AddressKey]),
+		Username: string(secret.Data[proxy.UsernameKey]),
+		Password: string(secret.Data[proxy.PasswordKey]),
 	}
+	// Git only authenticates to the proxy with a username, and without a
+	// password when none is set.
 	switch {
-	case username != "" && password == "":
-		proxyURL.User = url.User(username)
-	case username != "" && password != "":
-		proxyURL.User = url.UserPassword(username, password)
+	case proxyOpts.Username != "" && proxyOpts.Password == "":
+		proxyURL.User = url.User(proxyOpts.Username)
+	case proxyOpts.Username != "" && proxyOpts.Password != "":
+		proxyURL.User = url.UserPassword(proxyOpts.Username, proxyOpts.Password)
+	default:
+		proxyURL.User = nil
 	}
-
 	return proxyOpts, proxyURL, nil
 }
 
@@ -819,6 +868,13 @@ func (r *GitRepositoryReconciler) reconcileArtifact(ctx context.Context, sp *pat
 		return sreconcile.ResultSuccess, nil
 	}
 
+	// Refuse to archive a working directory exceeding the workspace quota
+	if err := r.Workspaces.CheckQuota(dir); err != nil {
+		e := serror.NewGeneric(err, sourcev1.WorkspaceQuotaExceededReason)
+		conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
+		return sreconcile.ResultEmpty, e
+	}
+
 	// Ensure target path exists and is a directory
 	if f, err := os.Stat(dir); err != nil {
 		e := serror.NewGeneric(
@@ -868,15 +924,24 @@ func (r *GitRepositoryReconciler) reconcileArtifact(ctx context.Context, sp *pat
 	}
 
 	// Archive directory to storage
-	if err := r.Storage.Archive(&artifact, dir, SourceIgnoreFilter(ps, ignoreDomain)); err != nil {
+	_, span := tracing.StartSpan(ctx, "storage archive")
+	err = r.Storage.Archive(&artifact, dir, SourceIgnoreFilter(ps, ignoreDomain))
+	tracing.EndSpan(span, err)
+	if err != nil {
 		e := serror.NewGeneric(
-			fmt.Errorf("unable to archive artifact to storage: %w", err),
+			serror.Classified(fmt.Errorf("unable to archive artifact to storage: %w", err)),
 			sourcev1.ArchiveOperationFailedReason,
 		)
 		conditions.MarkTrue(obj, sourcev1.StorageOperationFailedCondition, e.Reason, "%s", e)
 		return sreconcile.ResultEmpty, e
 	}
 
+	if err := r.Storage.VerifyReadBack(ctx, artifact); err != nil {
+		e := serror.NewGeneric(err, sourcev1.ArtifactReadBackFailedReason)
+		conditions.MarkTrue(obj, sourcev1.StorageOperationFailedCondition, e.Reason, "%s", e)
+		return sreconcile.ResultEmpty, e
+	}
+
 	// Record the observations on the object.
 	obj.Status.Artifact = artifact.DeepCopy()
 	obj.Status.IncludedArtifacts = *includes
@@ -989,6 +1054,12 @@ func (r *GitRepositoryReconciler) gitCheckout(ctx context.Context, obj *sourcev1
 	gitCtx, cancel := context.WithTimeout(ctx, obj.Spec.Timeout.Duration)
 	defer cancel()
 
+	if cloneOpts.LastObservedCommit != "" && r.features[features.GitProviderRefResolution] {
+		if commit := r.resolveUnchangedRef(gitCtx, obj, authOpts, proxyOpts, cloneOpts); commit != nil {
+			return commit, nil
+		}
+	}
+
 	clientOpts := []gogit.ClientOption{gogit.WithDiskStorage()}
 	if authOpts.Transport == git.HTTP {
 		clientOpts = append(clientOpts, gogit.WithInsecureCredentialsOverHTTP())
@@ -1008,19 +1079,48 @@ func (r *GitRepositoryReconciler) gitCheckout(ctx context.Context, obj *sourcev1
 	}
 	defer gitReader.Close()
 
-	commit, err := gitReader.Clone(gitCtx, obj.Spec.URL, cloneOpts)
+	if err := r.Upstream.Allow(obj.Spec.URL); err != nil {
+		e := serror.NewGeneric(serror.Classified(err), sourcev1.GitOperationFailedReason)
+		conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
+		return nil, e
+	}
+	r.Upstream.RecordRequest(obj.Spec.URL)
+
+	cloneCtx, span := tracing.StartSpan(gitCtx, "git clone")
+	commit, err := gitReader.Clone(cloneCtx, obj.Spec.URL, cloneOpts)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		e := serror.NewGeneric(
-			fmt.Errorf("failed to checkout and determine revision: %w", err),
+			serror.Classified(fmt.Errorf("failed to checkout and determine revision: %w", err)),
 			sourcev1.GitOperationFailedReason,
 		)
 		conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
 		return nil, e
 	}
+	// The fetched objects are stored as received in the repository
+	// directory, which makes their size a close approximation of the
+	// transferred bytes.
+	r.Upstream.RecordBytes(obj.Spec.URL, gitObjectsSize(dir))
 
 	return commit, nil
 }
 
+// gitObjectsSize returns the total size of the Git objects stored in the
+// repository at the given directory.
+func gitObjectsSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(filepath.Join(dir, ".git", "objects"), func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if fi, err := d.Info(); err == nil {
+			size += fi.Size()
+		}
+		return nil
+	})
+	return size
+}
+
 // fetchIncludes fetches artifact metadata of all the included repos.
 func (r *GitRepositoryReconciler) fetchIncludes(ctx context.Context, obj *sourcev1.GitRepository) (*artifactSet, error) {
 	artifacts := make(artifactSet, len(obj.Spec.Include))
@@ -1173,6 +1273,8 @@ func (r *GitRepositoryReconciler) reconcileDelete(ctx context.Context, obj *sour
 		return sreconcile.ResultEmpty, err
 	}
 
+	forgetUpstreamCertificates(r.Certificates, obj)
+
 	// Remove our finalizer from the list
 	controllerutil.RemoveFinalizer(obj, sourcev1.SourceFinalizer)
 
@@ -1189,7 +1291,10 @@ func (r *GitRepositoryReconciler) reconcileDelete(ctx context.Context, obj *sour
 // It removes all but the current Artifact from the Storage, unless the
 // deletion timestamp on the object is set. Which will result in the
 // removal of all Artifacts for the objects.
-func (r *GitRepositoryReconciler) garbageCollect(ctx context.Context, obj *sourcev1.GitRepository) error {
+func (r *GitRepositoryReconciler) garbageCollect(ctx context.Context, obj *sourcev1.GitRepository) (retErr error) {
+	ctx, span := tracing.StartSpan(ctx, "storage gc")
+	defer func() { tracing.EndSpan(span, retErr) }()
+
 	if !obj.DeletionTimestamp.IsZero() {
 		if deleted, err := r.Storage.RemoveAll(r.Storage.NewArtifactFor(obj.Kind, obj.GetObjectMeta(), "", "*")); err != nil {
 			return serror.NewGeneric(
@@ -1201,10 +1306,15 @@ func (r *GitRepositoryReconciler) garbageCollect(ctx context.Context, obj *sourc
 				"garbage collected artifacts for deleted resource")
 		}
 		obj.Status.Artifact = nil
+		obj.Status.PinnedArtifacts = nil
 		return nil
 	}
 	if obj.GetArtifact() != nil {
-		delFiles, err := r.Storage.GarbageCollect(ctx, *obj.GetArtifact(), time.Second*5)
+		obj.Status.PinnedArtifacts = r.Storage.PinArtifacts(obj.GetAnnotations(), obj.GetArtifact(), obj.Status.PinnedArtifacts)
+		if r.Storage.DeferGarbageCollection {
+			return nil
+		}
+		delFiles, err := r.Storage.retentionFor(obj).GarbageCollect(ctx, *obj.GetArtifact(), time.Second*5, obj.Status.PinnedArtifacts...)
 		if err != nil {
 			return serror.NewGeneric(
 				fmt.Errorf("garbage collection of artifacts failed: %w", err),
@@ -2250,10 +2250,20 @@ func TestGitRepositoryReconciler_getProxyOpts(t *testing.T) {
 			"password": []byte("pass"),
 		},
 	}
+	usernameProxy := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "username-proxy",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"address":  []byte("https://example.com"),
+			"username": []byte("user"),
+		},
+	}
 
 	clientBuilder := fakeclient.NewClientBuilder().
 		WithScheme(testEnv.GetScheme()).
-		WithObjects(invalidProxy, validProxy)
+		WithObjects(invalidProxy, validProxy, usernameProxy)
 
 	r := &GitRepositoryReconciler{
 		Client: clientBuilder.Build(),
@@ -2290,12 +2300,25 @@ func TestGitRepositoryReconciler_getProxyOpts(t *testing.T) {
 				User:   url.UserPassword("user", "pass"),
 			},
 		},
+		{
+			name:   "proxy secret with only a username",
+			secret: "username-proxy",
+			proxyOpts: &transport.ProxyOptions{
+				URL:      "https://example.com",
+				Username: "user",
+			},
+			proxyURL: &url.URL{
+				Scheme: "https",
+				Host:   "example.com",
+				User:   url.User("user"),
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
-			opts, proxyURL, err := r.getProxyOpts(context.TODO(), tt.secret, "default")
+			opts, proxyURL, err := r.getProxyOpts(context.TODO(), tt.secret, "default", "https://github.com/fluxcd/flux2")
 			if opts != nil {
 				g.Expect(err).ToNot(HaveOccurred())
 				g.Expect(opts).To(Equal(tt.proxyOpts))
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/fluxcd/pkg/git"
+	"github.com/fluxcd/pkg/git/repository"
+	"github.com/fluxcd/pkg/runtime/logger"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/fluxcd/source-controller/internal/gitref"
+)
+
+// resolveUnchangedRef resolves the branch or tag of the given clone
+// configuration with the REST API of the Git provider. It returns a partial
+// commit for the last observed commit if the reference still points to it,
+// which short-circuits the reconciliation like the optimized clone does, and
+// nil otherwise. In the latter case, the repository must be cloned.
+func (r *GitRepositoryReconciler) resolveUnchangedRef(ctx context.Context, obj *sourcev1.GitRepository,
+	authOpts *git.AuthOptions, proxyOpts *transport.ProxyOptions, cloneOpts repository.CloneConfig) *git.Commit {
+	var reference string
+	switch {
+	case cloneOpts.Commit != "" || cloneOpts.SemVer != "" || cloneOpts.RefName != "":
+		return nil
+	case cloneOpts.Tag != "":
+		reference = plumbing.NewTagReferenceName(cloneOpts.Tag).String()
+	case cloneOpts.Branch != "":
+		reference = plumbing.NewBranchReferenceName(cloneOpts.Branch).String()
+	default:
+		return nil
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	if len(authOpts.CAFile) > 0 {
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(authOpts.CAFile)
+		tr.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	if proxyOpts != nil {
+		if proxyURL, err := url.Parse(proxyOpts.URL); err == nil {
+			if proxyOpts.Username != "" {
+				proxyURL.User = url.UserPassword(proxyOpts.Username, proxyOpts.Password)
+			}
+			tr.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+
+	if err := r.Upstream.Allow(obj.Spec.URL); err != nil {
+		return nil
+	}
+	r.Upstream.RecordRequest(obj.Spec.URL)
+
+	sha, err := gitref.Resolve(ctx, &http.Client{Transport: tr}, gitref.Request{
+		URL:         obj.Spec.URL,
+		Provider:    obj.GetProvider(),
+		Branch:      cloneOpts.Branch,
+		Tag:         cloneOpts.Tag,
+		Username:    authOpts.Username,
+		Password:    authOpts.Password,
+		BearerToken: authOpts.BearerToken,
+	})
+	if err != nil {
+		if !errors.Is(err, gitref.ErrUnsupported) {
+			ctrl.LoggerFrom(ctx).V(logger.DebugLevel).Info("failed to resolve reference with the provider API, falling back to clone",
+				"reference", reference, "error", err.Error())
+		}
+		return nil
+	}
+	if git.ExtractHashFromRevision(cloneOpts.LastObservedCommit).String() != sha {
+		return nil
+	}
+	return &git.Commit{
+		Hash:      git.Hash(sha),
+		Reference: reference,
+	}
+}
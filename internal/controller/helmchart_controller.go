@@ -58,7 +58,6 @@ import (
 	"github.com/fluxcd/pkg/git"
 	"github.com/fluxcd/pkg/runtime/conditions"
 	helper "github.com/fluxcd/pkg/runtime/controller"
-	"github.com/fluxcd/pkg/runtime/jitter"
 	"github.com/fluxcd/pkg/runtime/patch"
 	"github.com/fluxcd/pkg/runtime/predicates"
 	rreconcile "github.com/fluxcd/pkg/runtime/reconcile"
@@ -70,12 +69,15 @@ import (
 	"github.com/fluxcd/source-controller/internal/helm/chart"
 	"github.com/fluxcd/source-controller/internal/helm/getter"
 	"github.com/fluxcd/source-controller/internal/helm/repository"
+	intjitter "github.com/fluxcd/source-controller/internal/jitter"
 	soci "github.com/fluxcd/source-controller/internal/oci"
 	scosign "github.com/fluxcd/source-controller/internal/oci/cosign"
 	"github.com/fluxcd/source-controller/internal/oci/notation"
+	"github.com/fluxcd/source-controller/internal/ratelimit"
 	sreconcile "github.com/fluxcd/source-controller/internal/reconcile"
 	"github.com/fluxcd/source-controller/internal/reconcile/summarize"
 	"github.com/fluxcd/source-controller/internal/util"
+	"github.com/fluxcd/source-controller/internal/workspace"
 )
 
 // helmChartReadyCondition contains all the conditions information
@@ -89,6 +91,7 @@ var helmChartReadyCondition = summarize.Conditions{
 		sourcev1.ArtifactOutdatedCondition,
 		sourcev1.ArtifactInStorageCondition,
 		sourcev1.SourceVerifiedCondition,
+		sourcev1.SLOViolatedCondition,
 		meta.ReadyCondition,
 		meta.ReconcilingCondition,
 		meta.StalledCondition,
@@ -135,12 +138,15 @@ type HelmChartReconciler struct {
 	Storage                 *Storage
 	Getters                 helmgetter.Providers
 	ControllerName          string
+	Workspaces              *workspace.Manager
+	Diagnostics             *ReconcileDiagnostics
 
 	Cache *cache.Cache
 	TTL   time.Duration
 	*cache.CacheRecorder
 
-	patchOptions []patch.Option
+	maxFailureBackoff time.Duration
+	patchOptions      []patch.Option
 }
 
 // RegistryClientGeneratorFunc is a function that returns a registry client
@@ -154,7 +160,10 @@ func (r *HelmChartReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Man
 }
 
 type HelmChartReconcilerOptions struct {
-	RateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+	RateLimiter       workqueue.TypedRateLimiter[reconcile.Request]
+	MaxFailureBackoff time.Duration
+	NamespaceLimiter  *ratelimit.NamespaceLimiter
+	Backlog           *ReconcileBacklog
 }
 
 // helmChartReconcileFunc is the function type for all the v1.HelmChart
@@ -163,6 +172,7 @@ type HelmChartReconcilerOptions struct {
 type helmChartReconcileFunc func(ctx context.Context, sp *patch.SerialPatcher, obj *sourcev1.HelmChart, build *chart.Build) (sreconcile.Result, error)
 
 func (r *HelmChartReconciler) SetupWithManagerAndOptions(ctx context.Context, mgr ctrl.Manager, opts HelmChartReconcilerOptions) error {
+	r.maxFailureBackoff = opts.MaxFailureBackoff
 	r.patchOptions = getPatchOptions(helmChartReadyCondition.Owned, r.ControllerName)
 
 	if err := mgr.GetCache().IndexField(ctx, &sourcev1.HelmRepository{}, sourcev1.HelmRepositoryURLIndexKey,
@@ -196,7 +206,7 @@ func (r *HelmChartReconciler) SetupWithManagerAndOptions(ctx context.Context, mg
 		WithOptions(controller.Options{
 			RateLimiter: opts.RateLimiter,
 		}).
-		Complete(r)
+		Complete(opts.NamespaceLimiter.Reconciler(sourcev1.HelmChartKind, opts.Backlog.Reconciler(sourcev1.HelmChartKind, r)))
 }
 
 func (r *HelmChartReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) {
@@ -209,6 +219,20 @@ func (r *HelmChartReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Leave the object untouched while in maintenance mode.
+	if r.Storage.Maintenance.Enabled() {
+		log.V(1).Info("controller is in maintenance mode, skipping reconciliation")
+		skipReconcile(ctx)
+		return ctrl.Result{RequeueAfter: r.Storage.Maintenance.RequeueAfter()}, nil
+	}
+
+	// Wait for the storage fencing token before writing to the Storage.
+	if !r.Storage.Fence.Acquired() {
+		log.V(1).Info("storage fencing token not acquired yet, skipping reconciliation")
+		skipReconcile(ctx)
+		return ctrl.Result{RequeueAfter: storageFenceRequeueAfter}, nil
+	}
+
 	// Initialize the patch helper with the current version of the object.
 	serialPatcher := patch.NewSerialPatcher(obj, r.Client)
 
@@ -230,9 +254,10 @@ func (r *HelmChartReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 				summarize.RecordReconcileReq,
 			),
 			summarize.WithResultBuilder(sreconcile.AlwaysRequeueResultBuilder{
-				RequeueAfter: jitter.JitteredIntervalDuration(obj.GetRequeueAfter()),
+				RequeueAfter: intjitter.JitteredIntervalDuration(sourcev1.HelmChartKind, obj.GetRequeueAfter()),
 			}),
 			summarize.WithPatchFieldOwner(r.ControllerName),
+			summarize.WithFailureBackoff(r.maxFailureBackoff),
 		}
 		result, retErr = summarizeHelper.SummarizeAndPatch(ctx, obj, summarizeOpts...)
 
@@ -243,6 +268,11 @@ func (r *HelmChartReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// Examine if the object is under deletion.
 	if !obj.ObjectMeta.DeletionTimestamp.IsZero() {
 		recResult, retErr = r.reconcileDelete(ctx, obj)
+		if retErr == nil {
+			if err := r.Diagnostics.Remove(obj); err != nil {
+				log.Error(err, "failed to remove diagnostic bundle")
+			}
+		}
 		return
 	}
 
@@ -259,6 +289,9 @@ func (r *HelmChartReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// Return if the object is suspended.
 	if obj.Spec.Suspend {
 		log.Info("Reconciliation is suspended for this object")
+		if err := collectSuspended(ctx, r.Storage, r.EventRecorder, obj, 5*time.Second); err != nil {
+			log.Error(err, "garbage collection failed")
+		}
 		recResult, retErr = sreconcile.ResultEmpty, nil
 		return
 	}
@@ -270,6 +303,8 @@ func (r *HelmChartReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		r.reconcileArtifact,
 	}
 	recResult, retErr = r.reconcile(ctx, serialPatcher, obj, reconcilers)
+	reconcileSLO(ctx, r.EventRecorder, obj, time.Since(start))
+	r.Diagnostics.Record(ctx, obj, start, retErr)
 	return
 }
 
@@ -315,7 +350,7 @@ func (r *HelmChartReconciler) reconcile(ctx context.Context, sp *patch.SerialPat
 		// If an error is received, prioritize the returned results because an
 		// error also means immediate requeue.
 		if err != nil {
-			resErr = err
+			resErr = r.Storage.Backoff(obj, err)
 			res = recResult
 			break
 		}
@@ -333,10 +368,7 @@ func (r *HelmChartReconciler) notify(ctx context.Context, oldObj, newObj *source
 	// Notify successful reconciliation for new artifact and recovery from any
 	// failure.
 	if resErr == nil && res == sreconcile.ResultSuccess && newObj.Status.Artifact != nil {
-		annotations := map[string]string{
-			fmt.Sprintf("%s/%s", sourcev1.GroupVersion.Group, eventv1.MetaRevisionKey): newObj.Status.Artifact.Revision,
-			fmt.Sprintf("%s/%s", sourcev1.GroupVersion.Group, eventv1.MetaDigestKey):   newObj.Status.Artifact.Digest,
-		}
+		annotations := artifactEventMetadata(newObj.Status.Artifact, r.Storage.Backend())
 
 		// Notify on new artifact and failure recovery.
 		if !oldObj.GetArtifact().HasDigest(newObj.GetArtifact().Digest) {
@@ -472,9 +504,12 @@ func (r *HelmChartReconciler) reconcileSource(ctx context.Context, sp *patch.Ser
 		// The reason this is a done conditionally, is because if we have a cached one in storage,
 		// we can not recover this information (and put it in a condition). Which would result in
 		// a sudden (partial) disappearance of observed state.
-		// TODO(hidde): include specific name/version information?
 		if depNum := build.ResolvedDependencies; build.Complete() && depNum > 0 {
-			r.Eventf(obj, eventv1.EventTypeTrace, "ResolvedDependencies", "resolved %d chart dependencies", depNum)
+			deps := make([]string, 0, len(build.Dependencies))
+			for _, dep := range build.Dependencies {
+				deps = append(deps, fmt.Sprintf("%s@%s", dep.Name, dep.Version))
+			}
+			r.Eventf(obj, eventv1.EventTypeTrace, "ResolvedDependencies", "resolved %d chart dependencies: %s", depNum, strings.Join(deps, ", "))
 		}
 
 		// Handle any build error
@@ -698,7 +733,7 @@ func (r *HelmChartReconciler) buildFromHelmRepository(ctx context.Context, obj *
 // object, and returns early.
 func (r *HelmChartReconciler) buildFromTarballArtifact(ctx context.Context, obj *sourcev1.HelmChart, source sourcev1.Artifact, b *chart.Build) (sreconcile.Result, error) {
 	// Create temporary working directory
-	tmpDir, err := util.TempDirForObj("", obj)
+	tmpDir, err := r.Workspaces.Create(obj)
 	if err != nil {
 		e := serror.NewGeneric(
 			fmt.Errorf("failed to create temporary working directory: %w", err),
@@ -707,7 +742,7 @@ func (r *HelmChartReconciler) buildFromTarballArtifact(ctx context.Context, obj
 		conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
 		return sreconcile.ResultEmpty, e
 	}
-	defer os.RemoveAll(tmpDir)
+	defer r.Workspaces.Remove(tmpDir)
 
 	// Create directory to untar source into
 	sourceDir := filepath.Join(tmpDir, "source")
@@ -743,10 +778,15 @@ func (r *HelmChartReconciler) buildFromTarballArtifact(ctx context.Context, obj
 			meta.FailedReason,
 		)
 	}
+	if err = r.Workspaces.CheckQuota(tmpDir); err != nil {
+		e := serror.NewGeneric(err, sourcev1.WorkspaceQuotaExceededReason)
+		conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
+		return sreconcile.ResultEmpty, e
+	}
 
 	// Setup dependency manager
 	dm := chart.NewDependencyManager(
-		chart.WithDownloaderCallback(r.namespacedChartRepositoryCallback(ctx, obj.GetName(), obj.GetNamespace())),
+		chart.WithDownloaderCallback(r.namespacedChartRepositoryCallback(ctx, obj.GetName(), obj.GetNamespace(), obj.Spec.DependencyCredentials)),
 	)
 	defer func() {
 		err := dm.Clear()
@@ -874,13 +914,19 @@ func (r *HelmChartReconciler) reconcileArtifact(ctx context.Context, _ *patch.Se
 	// Copy the packaged chart to the artifact path
 	if err = r.Storage.CopyFromPath(&artifact, b.Path); err != nil {
 		e := serror.NewGeneric(
-			fmt.Errorf("unable to copy Helm chart to storage: %w", err),
+			serror.Classified(fmt.Errorf("unable to copy Helm chart to storage: %w", err)),
 			sourcev1.ArchiveOperationFailedReason,
 		)
 		conditions.MarkTrue(obj, sourcev1.StorageOperationFailedCondition, e.Reason, "%s", e)
 		return sreconcile.ResultEmpty, e
 	}
 
+	if err := r.Storage.VerifyReadBack(ctx, artifact); err != nil {
+		e := serror.NewGeneric(err, sourcev1.ArtifactReadBackFailedReason)
+		conditions.MarkTrue(obj, sourcev1.StorageOperationFailedCondition, e.Reason, "%s", e)
+		return sreconcile.ResultEmpty, e
+	}
+
 	// Record it on the object
 	obj.Status.Artifact = artifact.DeepCopy()
 	obj.Status.ObservedChartName = b.Name
@@ -889,6 +935,7 @@ func (r *HelmChartReconciler) reconcileArtifact(ctx context.Context, _ *patch.Se
 	} else {
 		obj.Status.ObservedValuesFiles = nil
 	}
+	obj.Status.ObservedBuild = buildSummary(b)
 
 	// Update symlink on a "best effort" basis
 	symURL, err := r.Storage.Symlink(artifact, "latest.tar.gz")
@@ -971,10 +1018,15 @@ func (r *HelmChartReconciler) garbageCollect(ctx context.Context, obj *sourcev1.
 				"garbage collected artifacts for deleted resource")
 		}
 		obj.Status.Artifact = nil
+		obj.Status.PinnedArtifacts = nil
 		return nil
 	}
 	if obj.GetArtifact() != nil {
-		delFiles, err := r.Storage.GarbageCollect(ctx, *obj.GetArtifact(), time.Second*5)
+		obj.Status.PinnedArtifacts = r.Storage.PinArtifacts(obj.GetAnnotations(), obj.GetArtifact(), obj.Status.PinnedArtifacts)
+		if r.Storage.DeferGarbageCollection {
+			return nil
+		}
+		delFiles, err := r.Storage.retentionFor(obj).GarbageCollect(ctx, *obj.GetArtifact(), time.Second*5, obj.Status.PinnedArtifacts...)
 		if err != nil {
 			return serror.NewGeneric(
 				fmt.Errorf("garbage collection of artifacts failed: %w", err),
@@ -993,8 +1045,11 @@ func (r *HelmChartReconciler) garbageCollect(ctx context.Context, obj *sourcev1.
 // namespacedChartRepositoryCallback returns a chart.GetChartDownloaderCallback scoped to the given namespace.
 // The returned callback returns a repository.Downloader configured with the retrieved v1beta1.HelmRepository,
 // or a shim with defaults if no object could be found.
+// When the given credentials contain an entry for the repository URL, its SecretRef is used instead of the
+// one of the v1beta1.HelmRepository.
 // The callback returns an object with a state, so the caller has to do the necessary cleanup.
-func (r *HelmChartReconciler) namespacedChartRepositoryCallback(ctx context.Context, name, namespace string) chart.GetChartDownloaderCallback {
+func (r *HelmChartReconciler) namespacedChartRepositoryCallback(ctx context.Context, name, namespace string,
+	credentials []sourcev1.HelmChartDependencyCredentials) chart.GetChartDownloaderCallback {
 	return func(url string) (repository.Downloader, error) {
 		normalizedURL, err := repository.NormalizeURL(url)
 		if err != nil {
@@ -1007,6 +1062,9 @@ func (r *HelmChartReconciler) namespacedChartRepositoryCallback(ctx context.Cont
 				return nil, err
 			}
 			obj = &sourcev1.HelmRepository{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+				},
 				Spec: sourcev1.HelmRepositorySpec{
 					URL:     url,
 					Timeout: &metav1.Duration{Duration: 60 * time.Second},
@@ -1014,6 +1072,18 @@ func (r *HelmChartReconciler) namespacedChartRepositoryCallback(ctx context.Cont
 			}
 		}
 
+		secretRef, err := dependencySecretRef(credentials, normalizedURL)
+		if err != nil {
+			return nil, err
+		}
+		if secretRef != nil {
+			obj = obj.DeepCopy()
+			obj.Spec.SecretRef = secretRef
+			if helmreg.IsOCI(normalizedURL) {
+				obj.Spec.Type = sourcev1.HelmRepositoryTypeOCI
+			}
+		}
+
 		// Used to login with the repository declared provider
 		ctxTimeout, cancel := context.WithTimeout(ctx, obj.GetTimeout())
 		defer cancel()
@@ -1095,6 +1165,22 @@ func (r *HelmChartReconciler) namespacedChartRepositoryCallback(ctx context.Cont
 	}
 }
 
+// dependencySecretRef returns the SecretRef of the credentials entry matching
+// the given normalized repository URL, or nil if there is none.
+func dependencySecretRef(credentials []sourcev1.HelmChartDependencyCredentials, normalizedURL string) (*meta.LocalObjectReference, error) {
+	for _, c := range credentials {
+		u, err := repository.NormalizeURL(c.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dependency credentials URL '%s': %w", c.URL, err)
+		}
+		if u == normalizedURL {
+			ref := c.SecretRef
+			return &ref, nil
+		}
+	}
+	return nil, nil
+}
+
 func (r *HelmChartReconciler) resolveDependencyRepository(ctx context.Context, url string, namespace string) (*sourcev1.HelmRepository, error) {
 	listOpts := []client.ListOption{
 		client.InNamespace(namespace),
@@ -1298,6 +1384,27 @@ func reasonForBuild(build *chart.Build) string {
 	return sourcev1.ChartPullSucceededReason
 }
 
+// buildSummary returns the HelmChartBuildSummary for the given build, or nil
+// if there is nothing to report.
+func buildSummary(build *chart.Build) *sourcev1.HelmChartBuildSummary {
+	if len(build.ValuesFiles) == 0 && len(build.Dependencies) == 0 && len(build.Warnings) == 0 {
+		return nil
+	}
+	summary := &sourcev1.HelmChartBuildSummary{
+		ValuesFiles: build.ValuesFiles,
+		Warnings:    build.Warnings,
+	}
+	for _, dep := range build.Dependencies {
+		summary.Dependencies = append(summary.Dependencies, sourcev1.HelmChartDependency{
+			Name:       dep.Name,
+			Version:    dep.Version,
+			Repository: dep.Repository,
+			Digest:     dep.Digest,
+		})
+	}
+	return summary
+}
+
 func chartRepoConfigErrorReturn(err error, obj *sourcev1.HelmChart) (sreconcile.Result, error) {
 	switch err.(type) {
 	case *url.Error:
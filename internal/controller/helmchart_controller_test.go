@@ -1447,6 +1447,7 @@ func TestHelmChartReconciler_buildFromTarballArtifact(t *testing.T) {
 				g.Expect(build.Name).To(Equal("helmchartwithdeps"))
 				g.Expect(build.Version).To(Equal("0.1.0"))
 				g.Expect(build.ResolvedDependencies).To(Equal(4))
+				g.Expect(build.Dependencies).To(HaveLen(4))
 				g.Expect(build.Path).To(BeARegularFile())
 				chart, err := secureloader.LoadFile(build.Path)
 				g.Expect(err).ToNot(HaveOccurred())
@@ -1787,6 +1788,35 @@ func TestHelmChartReconciler_reconcileArtifact(t *testing.T) {
 				*conditions.TrueCondition(sourcev1.ArtifactInStorageCondition, sourcev1.ChartPullSucceededReason, "pulled 'helmchart' chart with version '0.1.0'"),
 			},
 		},
+		{
+			name: "Updates ObservedBuild after creating new artifact",
+			build: func() *chart.Build {
+				b := mockChartBuild("helmchart", "0.1.0", "testdata/charts/helmchart-0.1.0.tgz", []string{"values.yaml", "override.yaml"})
+				b.Dependencies = []chart.ResolvedDependency{
+					{Name: "grafana", Version: "6.17.4", Repository: "https://grafana.github.io/helm-charts/", Digest: "sha256:digest"},
+				}
+				b.Warnings = []string{"ignored missing values file 'missing.yaml'"}
+				return b
+			}(),
+			beforeFunc: func(obj *sourcev1.HelmChart) {
+				conditions.MarkTrue(obj, sourcev1.ArtifactOutdatedCondition, "Foo", "")
+				obj.Status.ObservedBuild = &sourcev1.HelmChartBuildSummary{Warnings: []string{"outdated"}}
+			},
+			afterFunc: func(t *WithT, obj *sourcev1.HelmChart) {
+				t.Expect(obj.GetArtifact()).ToNot(BeNil())
+				t.Expect(obj.Status.ObservedBuild).To(Equal(&sourcev1.HelmChartBuildSummary{
+					ValuesFiles: []string{"values.yaml", "override.yaml"},
+					Dependencies: []sourcev1.HelmChartDependency{
+						{Name: "grafana", Version: "6.17.4", Repository: "https://grafana.github.io/helm-charts/", Digest: "sha256:digest"},
+					},
+					Warnings: []string{"ignored missing values file 'missing.yaml'"},
+				}))
+			},
+			want: sreconcile.ResultSuccess,
+			assertConditions: []metav1.Condition{
+				*conditions.TrueCondition(sourcev1.ArtifactInStorageCondition, sourcev1.ChartPullSucceededReason, "pulled 'helmchart' chart with version '0.1.0'"),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -2265,6 +2295,136 @@ func TestHelmChartReconciler_statusConditions(t *testing.T) {
 	}
 }
 
+func Test_dependencySecretRef(t *testing.T) {
+	credentials := []sourcev1.HelmChartDependencyCredentials{
+		{
+			URL:       "https://charts.example.com/stable",
+			SecretRef: meta.LocalObjectReference{Name: "stable-auth"},
+		},
+		{
+			URL:       "oci://registry.example.com/charts/",
+			SecretRef: meta.LocalObjectReference{Name: "registry-auth"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		credentials []sourcev1.HelmChartDependencyCredentials
+		url         string
+		want        *meta.LocalObjectReference
+		wantErr     bool
+	}{
+		{
+			name:        "matches HTTP repository URL",
+			credentials: credentials,
+			url:         "https://charts.example.com/stable/",
+			want:        &meta.LocalObjectReference{Name: "stable-auth"},
+		},
+		{
+			name:        "matches OCI repository URL",
+			credentials: credentials,
+			url:         "oci://registry.example.com/charts",
+			want:        &meta.LocalObjectReference{Name: "registry-auth"},
+		},
+		{
+			name:        "no match",
+			credentials: credentials,
+			url:         "https://charts.example.com/incubator/",
+		},
+		{
+			name:        "no credentials",
+			credentials: nil,
+			url:         "https://charts.example.com/stable/",
+		},
+		{
+			name: "invalid URL",
+			credentials: []sourcev1.HelmChartDependencyCredentials{
+				{URL: "https://charts.example.com/%zz"},
+			},
+			url:     "https://charts.example.com/stable/",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := dependencySecretRef(tt.credentials, tt.url)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestHelmChartReconciler_namespacedChartRepositoryCallback_credentials(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "stable-auth",
+			Namespace: "team-a",
+		},
+		Data: map[string][]byte{
+			"username": []byte("user"),
+			"password": []byte("pass"),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		namespace   string
+		credentials []sourcev1.HelmChartDependencyCredentials
+		wantErr     string
+	}{
+		{
+			name:      "credentials secret in the namespace of the chart",
+			namespace: "team-a",
+			credentials: []sourcev1.HelmChartDependencyCredentials{
+				{URL: "https://charts.example.com/stable", SecretRef: meta.LocalObjectReference{Name: "stable-auth"}},
+			},
+		},
+		{
+			name:      "credentials secret in another namespace",
+			namespace: "team-b",
+			credentials: []sourcev1.HelmChartDependencyCredentials{
+				{URL: "https://charts.example.com/stable", SecretRef: meta.LocalObjectReference{Name: "stable-auth"}},
+			},
+			wantErr: "failed to get authentication secret",
+		},
+		{
+			name:      "no credentials",
+			namespace: "team-b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &HelmChartReconciler{
+				Client: fakeclient.NewClientBuilder().
+					WithScheme(testEnv.GetScheme()).
+					WithObjects(secret).
+					Build(),
+				Getters: testGetters,
+				Storage: testStorage,
+			}
+
+			callback := r.namespacedChartRepositoryCallback(ctx, "chart", tt.namespace, tt.credentials)
+			downloader, err := callback("https://charts.example.com/stable/")
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(downloader).ToNot(BeNil())
+			g.Expect(downloader.Clear()).To(Succeed())
+		})
+	}
+}
+
 func TestHelmChartReconciler_notify(t *testing.T) {
 	tests := []struct {
 		name             string
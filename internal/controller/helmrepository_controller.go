@@ -44,7 +44,6 @@ import (
 	"github.com/fluxcd/pkg/apis/meta"
 	"github.com/fluxcd/pkg/runtime/conditions"
 	helper "github.com/fluxcd/pkg/runtime/controller"
-	"github.com/fluxcd/pkg/runtime/jitter"
 	"github.com/fluxcd/pkg/runtime/patch"
 	"github.com/fluxcd/pkg/runtime/predicates"
 	rreconcile "github.com/fluxcd/pkg/runtime/reconcile"
@@ -55,9 +54,12 @@ import (
 	serror "github.com/fluxcd/source-controller/internal/error"
 	"github.com/fluxcd/source-controller/internal/helm/getter"
 	"github.com/fluxcd/source-controller/internal/helm/repository"
+	intjitter "github.com/fluxcd/source-controller/internal/jitter"
 	intpredicates "github.com/fluxcd/source-controller/internal/predicates"
+	"github.com/fluxcd/source-controller/internal/ratelimit"
 	sreconcile "github.com/fluxcd/source-controller/internal/reconcile"
 	"github.com/fluxcd/source-controller/internal/reconcile/summarize"
+	"github.com/fluxcd/source-controller/internal/upstream"
 )
 
 // helmRepositoryReadyCondition contains the information required to summarize a
@@ -69,6 +71,7 @@ var helmRepositoryReadyCondition = summarize.Conditions{
 		sourcev1.FetchFailedCondition,
 		sourcev1.ArtifactOutdatedCondition,
 		sourcev1.ArtifactInStorageCondition,
+		sourcev1.SLOViolatedCondition,
 		meta.ReadyCondition,
 		meta.ReconcilingCondition,
 		meta.StalledCondition,
@@ -111,16 +114,22 @@ type HelmRepositoryReconciler struct {
 	Getters        helmgetter.Providers
 	Storage        *Storage
 	ControllerName string
+	Certificates   *upstream.CertificateMonitor
+	Diagnostics    *ReconcileDiagnostics
 
 	Cache *cache.Cache
 	TTL   time.Duration
 	*cache.CacheRecorder
 
-	patchOptions []patch.Option
+	maxFailureBackoff time.Duration
+	patchOptions      []patch.Option
 }
 
 type HelmRepositoryReconcilerOptions struct {
-	RateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+	RateLimiter       workqueue.TypedRateLimiter[reconcile.Request]
+	MaxFailureBackoff time.Duration
+	NamespaceLimiter  *ratelimit.NamespaceLimiter
+	Backlog           *ReconcileBacklog
 }
 
 // helmRepositoryReconcileFunc is the function type for all the
@@ -134,6 +143,7 @@ func (r *HelmRepositoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 func (r *HelmRepositoryReconciler) SetupWithManagerAndOptions(mgr ctrl.Manager, opts HelmRepositoryReconcilerOptions) error {
+	r.maxFailureBackoff = opts.MaxFailureBackoff
 	r.patchOptions = getPatchOptions(helmRepositoryReadyCondition.Owned, r.ControllerName)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -147,7 +157,7 @@ func (r *HelmRepositoryReconciler) SetupWithManagerAndOptions(mgr ctrl.Manager,
 		WithOptions(controller.Options{
 			RateLimiter: opts.RateLimiter,
 		}).
-		Complete(r)
+		Complete(opts.NamespaceLimiter.Reconciler(sourcev1.HelmRepositoryKind, opts.Backlog.Reconciler(sourcev1.HelmRepositoryKind, r)))
 }
 
 func (r *HelmRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) {
@@ -160,6 +170,20 @@ func (r *HelmRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Leave the object untouched while in maintenance mode.
+	if r.Storage.Maintenance.Enabled() {
+		log.V(1).Info("controller is in maintenance mode, skipping reconciliation")
+		skipReconcile(ctx)
+		return ctrl.Result{RequeueAfter: r.Storage.Maintenance.RequeueAfter()}, nil
+	}
+
+	// Wait for the storage fencing token before writing to the Storage.
+	if !r.Storage.Fence.Acquired() {
+		log.V(1).Info("storage fencing token not acquired yet, skipping reconciliation")
+		skipReconcile(ctx)
+		return ctrl.Result{RequeueAfter: storageFenceRequeueAfter}, nil
+	}
+
 	// Initialize the patch helper with the current version of the object.
 	serialPatcher := patch.NewSerialPatcher(obj, r.Client)
 
@@ -185,9 +209,10 @@ func (r *HelmRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				summarize.RecordReconcileReq,
 			),
 			summarize.WithResultBuilder(sreconcile.AlwaysRequeueResultBuilder{
-				RequeueAfter: jitter.JitteredIntervalDuration(obj.GetRequeueAfter()),
+				RequeueAfter: intjitter.JitteredIntervalDuration(sourcev1.HelmRepositoryKind, obj.GetRequeueAfter()),
 			}),
 			summarize.WithPatchFieldOwner(r.ControllerName),
+			summarize.WithFailureBackoff(r.maxFailureBackoff),
 		}
 		result, retErr = summarizeHelper.SummarizeAndPatch(ctx, obj, summarizeOpts...)
 
@@ -198,6 +223,11 @@ func (r *HelmRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// Examine if the object is under deletion.
 	if !obj.ObjectMeta.DeletionTimestamp.IsZero() {
 		recResult, retErr = r.reconcileDelete(ctx, obj)
+		if retErr == nil {
+			if err := r.Diagnostics.Remove(obj); err != nil {
+				log.Error(err, "failed to remove diagnostic bundle")
+			}
+		}
 		return
 	}
 
@@ -214,6 +244,9 @@ func (r *HelmRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// Return if the object is suspended.
 	if obj.Spec.Suspend {
 		log.Info("reconciliation is suspended for this object")
+		if err := collectSuspended(ctx, r.Storage, r.EventRecorder, obj, 5*time.Second); err != nil {
+			log.Error(err, "garbage collection failed")
+		}
 		recResult, retErr = sreconcile.ResultEmpty, nil
 		return
 	}
@@ -225,6 +258,8 @@ func (r *HelmRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		r.reconcileArtifact,
 	}
 	recResult, retErr = r.reconcile(ctx, serialPatcher, obj, reconcilers)
+	reconcileSLO(ctx, r.EventRecorder, obj, time.Since(start))
+	r.Diagnostics.Record(ctx, obj, start, retErr)
 	return
 }
 
@@ -271,7 +306,7 @@ func (r *HelmRepositoryReconciler) reconcile(ctx context.Context, sp *patch.Seri
 		// If an error is received, prioritize the returned results because an
 		// error also means immediate requeue.
 		if err != nil {
-			resErr = err
+			resErr = r.Storage.Backoff(obj, err)
 			res = recResult
 			break
 		}
@@ -289,10 +324,7 @@ func (r *HelmRepositoryReconciler) notify(ctx context.Context, oldObj, newObj *s
 	// Notify successful reconciliation for new artifact and recovery from any
 	// failure.
 	if resErr == nil && res == sreconcile.ResultSuccess && newObj.Status.Artifact != nil {
-		annotations := map[string]string{
-			fmt.Sprintf("%s/%s", sourcev1.GroupVersion.Group, eventv1.MetaRevisionKey): newObj.Status.Artifact.Revision,
-			fmt.Sprintf("%s/%s", sourcev1.GroupVersion.Group, eventv1.MetaDigestKey):   newObj.Status.Artifact.Digest,
-		}
+		annotations := artifactEventMetadata(newObj.Status.Artifact, r.Storage.Backend())
 
 		humanReadableSize := "unknown size"
 		if size := newObj.Status.Artifact.Size; size != nil {
@@ -454,7 +486,7 @@ func (r *HelmRepositoryReconciler) reconcileSource(ctx context.Context, sp *patc
 	// Fetch the repository index from remote.
 	if err := newChartRepo.CacheIndex(); err != nil {
 		e := serror.NewGeneric(
-			fmt.Errorf("failed to fetch Helm repository index: %w", err),
+			serror.Classified(fmt.Errorf("failed to fetch Helm repository index: %w", err)),
 			meta.FailedReason,
 		)
 		conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
@@ -462,6 +494,10 @@ func (r *HelmRepositoryReconciler) reconcileSource(ctx context.Context, sp *patc
 		return sreconcile.ResultEmpty, e
 	}
 	*chartRepo = *newChartRepo
+	if cert := observeUpstreamCertificate(ctx, r.Certificates, r.EventRecorder, obj,
+		obj.Spec.URL, obj.Spec.Insecure, nil); cert != nil {
+		obj.Status.UpstreamCertificate = cert
+	}
 
 	// Early comparison to current Artifact.
 	if curArtifact := obj.GetArtifact(); curArtifact != nil {
@@ -584,13 +620,19 @@ func (r *HelmRepositoryReconciler) reconcileArtifact(ctx context.Context, sp *pa
 	}
 	if err = r.Storage.Copy(artifact, bytes.NewBuffer(b)); err != nil {
 		e := serror.NewGeneric(
-			fmt.Errorf("unable to save artifact to storage: %w", err),
+			serror.Classified(fmt.Errorf("unable to save artifact to storage: %w", err)),
 			sourcev1.ArchiveOperationFailedReason,
 		)
 		conditions.MarkTrue(obj, sourcev1.StorageOperationFailedCondition, e.Reason, "%s", e)
 		return sreconcile.ResultEmpty, e
 	}
 
+	if err := r.Storage.VerifyReadBack(ctx, *artifact); err != nil {
+		e := serror.NewGeneric(err, sourcev1.ArtifactReadBackFailedReason)
+		conditions.MarkTrue(obj, sourcev1.StorageOperationFailedCondition, e.Reason, "%s", e)
+		return sreconcile.ResultEmpty, e
+	}
+
 	// Record it on the object.
 	obj.Status.Artifact = artifact.DeepCopy()
 
@@ -630,6 +672,7 @@ func (r *HelmRepositoryReconciler) reconcileDelete(ctx context.Context, obj *sou
 
 	// Remove our finalizer from the list if we are deleting the object
 	if !obj.DeletionTimestamp.IsZero() {
+		forgetUpstreamCertificates(r.Certificates, obj)
 		controllerutil.RemoveFinalizer(obj, sourcev1.SourceFinalizer)
 	}
 
@@ -662,13 +705,18 @@ func (r *HelmRepositoryReconciler) garbageCollect(ctx context.Context, obj *sour
 		}
 		// Clean status sub-resource
 		obj.Status.Artifact = nil
+		obj.Status.PinnedArtifacts = nil
 		obj.Status.URL = ""
 		// Remove any stale conditions.
 		obj.Status.Conditions = nil
 		return nil
 	}
 	if obj.GetArtifact() != nil {
-		delFiles, err := r.Storage.GarbageCollect(ctx, *obj.GetArtifact(), time.Second*5)
+		obj.Status.PinnedArtifacts = r.Storage.PinArtifacts(obj.GetAnnotations(), obj.GetArtifact(), obj.Status.PinnedArtifacts)
+		if r.Storage.DeferGarbageCollection {
+			return nil
+		}
+		delFiles, err := r.Storage.GarbageCollect(ctx, *obj.GetArtifact(), time.Second*5, obj.Status.PinnedArtifacts...)
 		if err != nil {
 			return serror.NewGeneric(
 				fmt.Errorf("garbage collection of artifacts failed: %w", err),
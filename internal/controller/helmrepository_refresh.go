@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fluxcd/pkg/apis/meta"
+
+	v1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/fluxcd/source-controller/internal/cache"
+)
+
+// HelmIndexRefresh is the response of the HelmIndexRefresher.
+type HelmIndexRefresh struct {
+	// Namespace and Name of the HelmRepository.
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// CacheKey is the key of the removed index cache entry, if any.
+	CacheKey string `json:"cacheKey,omitempty"`
+	// RequestedAt is the value of the reconcile request annotation set on
+	// the HelmRepository.
+	RequestedAt string `json:"requestedAt"`
+}
+
+// HelmIndexRefreshSecretAnnotation is the annotation of a HelmRepository
+// naming the Secret, in the namespace of the HelmRepository, that holds the
+// token of its refresh webhook in the 'token' key.
+const HelmIndexRefreshSecretAnnotation = "source.toolkit.fluxcd.io/refresh-secret"
+
+// HelmIndexRefresher serves the
+// POST /helmrepositories/{namespace}/{name}/refresh endpoint of the Helm
+// webhook server. It is meant to be the target of the webhooks of Helm
+// repositories, e.g. Harbor, ChartMuseum or Artifactory, sent when a chart
+// is pushed. The payload of the webhook is ignored.
+//
+// The webhook must present the token of the HelmRepository in the
+// Authorization header, either as is or as a bearer token. A
+// HelmRepository without the HelmIndexRefreshSecretAnnotation can not be
+// refreshed.
+//
+// It removes the cached index of the HelmRepository and requests its
+// reconciliation, so that the new chart versions are available within
+// seconds instead of at its next interval.
+type HelmIndexRefresher struct {
+	Client client.Client
+	// Cache is the Helm repository index cache, it may be nil.
+	Cache *cache.Cache
+}
+
+// ServeHTTP implements http.Handler.
+func (h *HelmIndexRefresher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	obj := &v1.HelmRepository{}
+	key := client.ObjectKey{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+	if err := h.Client.Get(r.Context(), key, obj); err != nil {
+		// Do not disclose which HelmRepositories exist to unauthenticated
+		// callers
+		if apierrors.IsNotFound(err) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if ok, err := h.authorized(r, obj); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	} else if !ok {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	if obj.Spec.Type == v1.HelmRepositoryTypeOCI {
+		http.Error(w, fmt.Sprintf("HelmRepository '%s' of type '%s' has no index", key, v1.HelmRepositoryTypeOCI), http.StatusBadRequest)
+		return
+	}
+
+	refresh := HelmIndexRefresh{
+		Namespace:   obj.Namespace,
+		Name:        obj.Name,
+		RequestedAt: time.Now().Format(time.RFC3339Nano),
+	}
+	// The index cache is keyed by the HelmRepository Artifact path.
+	if artifact := obj.GetArtifact(); artifact != nil && h.Cache != nil {
+		if _, ok := h.Cache.Get(artifact.Path); ok {
+			h.Cache.Delete(artifact.Path)
+			refresh.CacheKey = artifact.Path
+		}
+	}
+
+	patch := client.MergeFrom(obj.DeepCopy())
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[meta.ReconcileRequestAnnotation] = refresh.RequestedAt
+	obj.SetAnnotations(annotations)
+	if err := h.Client.Patch(r.Context(), obj, patch); err != nil {
+		http.Error(w, fmt.Sprintf("failed to request reconciliation: %s", err), http.StatusInternalServerError)
+		return
+	}
+	ctrl.Log.WithName("helm-index-refresh").Info("requested index refresh",
+		"namespace", obj.Namespace, "name", obj.Name, "cacheKey", refresh.CacheKey)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(refresh)
+}
+
+// authorized returns if the request presents the refresh token of the given
+// HelmRepository.
+func (h *HelmIndexRefresher) authorized(r *http.Request, obj *v1.HelmRepository) (bool, error) {
+	name := obj.GetAnnotations()[HelmIndexRefreshSecretAnnotation]
+	if name == "" {
+		return false, nil
+	}
+	secret := &corev1.Secret{}
+	if err := h.Client.Get(r.Context(), client.ObjectKey{Namespace: obj.Namespace, Name: name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get refresh secret: %w", err)
+	}
+	token := secret.Data["token"]
+	if len(token) == 0 {
+		return false, nil
+	}
+	got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(got), token) == 1, nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/fluxcd/pkg/apis/meta"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/fluxcd/source-controller/internal/cache"
+)
+
+func TestHelmIndexRefresher(t *testing.T) {
+	g := NewWithT(t)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "refresh-token"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	}
+	repo := &sourcev1.HelmRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "podinfo",
+			Annotations: map[string]string{HelmIndexRefreshSecretAnnotation: secret.Name},
+		},
+		Spec: sourcev1.HelmRepositorySpec{URL: "https://stefanprodan.github.io/podinfo"},
+		Status: sourcev1.HelmRepositoryStatus{
+			Artifact: &sourcev1.Artifact{Path: "helmrepository/default/podinfo/index-abc.yaml"},
+		},
+	}
+	ociRepo := &sourcev1.HelmRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "oci",
+			Annotations: map[string]string{HelmIndexRefreshSecretAnnotation: secret.Name},
+		},
+		Spec: sourcev1.HelmRepositorySpec{URL: "oci://ghcr.io/stefanprodan/charts", Type: sourcev1.HelmRepositoryTypeOCI},
+	}
+	unannotated := &sourcev1.HelmRepository{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unannotated"},
+		Spec:       sourcev1.HelmRepositorySpec{URL: "https://stefanprodan.github.io/podinfo"},
+	}
+	c := fakeclient.NewClientBuilder().
+		WithScheme(testEnv.GetScheme()).
+		WithObjects(secret, repo, ociRepo, unannotated).
+		Build()
+
+	indexCache := cache.New(10, 0)
+	g.Expect(indexCache.Set(repo.Status.Artifact.Path, "index", 0)).To(Succeed())
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /helmrepositories/{namespace}/{name}/refresh", &HelmIndexRefresher{Client: c, Cache: indexCache})
+
+	refreshRequest := func(path, token string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return req
+	}
+
+	// Requests without the token of the HelmRepository are refused.
+	for _, req := range []*http.Request{
+		refreshRequest("/helmrepositories/default/podinfo/refresh", ""),
+		refreshRequest("/helmrepositories/default/podinfo/refresh", "invalid"),
+		refreshRequest("/helmrepositories/default/unannotated/refresh", "s3cr3t"),
+		refreshRequest("/helmrepositories/default/missing/refresh", "s3cr3t"),
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		g.Expect(rec.Code).To(Equal(http.StatusUnauthorized), req.URL.Path)
+	}
+	_, ok := indexCache.Get(repo.Status.Artifact.Path)
+	g.Expect(ok).To(BeTrue())
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, refreshRequest("/helmrepositories/default/podinfo/refresh", "s3cr3t"))
+	g.Expect(rec.Code).To(Equal(http.StatusAccepted))
+
+	var refresh HelmIndexRefresh
+	g.Expect(json.NewDecoder(rec.Body).Decode(&refresh)).To(Succeed())
+	g.Expect(refresh.CacheKey).To(Equal(repo.Status.Artifact.Path))
+	_, ok = indexCache.Get(repo.Status.Artifact.Path)
+	g.Expect(ok).To(BeFalse())
+
+	got := &sourcev1.HelmRepository{}
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "podinfo"}, got)).To(Succeed())
+	g.Expect(got.GetAnnotations()).To(HaveKeyWithValue(meta.ReconcileRequestAnnotation, refresh.RequestedAt))
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, refreshRequest("/helmrepositories/default/oci/refresh", "s3cr3t"))
+	g.Expect(rec.Code).To(Equal(http.StatusBadRequest))
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MaintenanceAnnotation is the annotation on the maintenance ConfigMap which
+// enables the maintenance mode when set to "true".
+const MaintenanceAnnotation = "source.toolkit.fluxcd.io/maintenance"
+
+// Maintenance holds the maintenance mode of the controller. While enabled,
+// the Source objects are not reconciled and their status is left untouched,
+// and the Storage is not garbage collected or audited. The artifact server
+// keeps serving the stored Artifacts.
+//
+// The maintenance mode is enabled when Forced is true, or when the
+// MaintenanceAnnotation of the ConfigMap identified by Name and Namespace is
+// set to "true". All methods are safe to call on a nil Maintenance, which is
+// never enabled.
+type Maintenance struct {
+	// Reader reads the ConfigMap, it should not be backed by a cache to
+	// avoid watching all ConfigMaps.
+	Reader client.Reader
+
+	// Name and Namespace identify the ConfigMap. When Name is empty, the
+	// maintenance mode is only controlled by Forced.
+	Name      string
+	Namespace string
+
+	// Interval at which the ConfigMap is checked for changes, and after
+	// which the objects are requeued while in maintenance mode.
+	Interval time.Duration
+
+	// Forced enables the maintenance mode regardless of the ConfigMap.
+	Forced bool
+
+	enabled atomic.Bool
+}
+
+// Enabled returns if the maintenance mode is enabled.
+func (m *Maintenance) Enabled() bool {
+	if m == nil {
+		return false
+	}
+	return m.Forced || m.enabled.Load()
+}
+
+// RequeueAfter returns the duration after which an object should be
+// requeued while the maintenance mode is enabled.
+func (m *Maintenance) RequeueAfter() time.Duration {
+	if m == nil {
+		return 0
+	}
+	return m.Interval
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. All replicas
+// must observe the maintenance mode.
+func (m *Maintenance) NeedLeaderElection() bool {
+	return false
+}
+
+// Start checks the ConfigMap at the configured interval until the given
+// context is canceled. When the ConfigMap can not be read, the current
+// maintenance mode is kept.
+func (m *Maintenance) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("maintenance")
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+	for {
+		changed, err := m.Refresh(ctx)
+		if err != nil {
+			log.Error(err, "unable to load maintenance mode")
+		} else if changed {
+			log.Info("maintenance mode changed", "enabled", m.Enabled())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh loads the maintenance mode from the ConfigMap, and returns if it
+// changed. It is a no-op when no ConfigMap is configured.
+func (m *Maintenance) Refresh(ctx context.Context) (bool, error) {
+	if m.Name == "" {
+		return false, nil
+	}
+	enabled, err := m.Load(ctx)
+	if err != nil {
+		return false, err
+	}
+	return m.enabled.Swap(enabled) != enabled, nil
+}
+
+// Load returns if the MaintenanceAnnotation of the ConfigMap is set to
+// "true". A missing ConfigMap disables the maintenance mode.
+func (m *Maintenance) Load(ctx context.Context) (bool, error) {
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: m.Namespace, Name: m.Name}
+	if err := m.Reader.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get maintenance ConfigMap '%s': %w", key, err)
+	}
+	v, ok := cm.GetAnnotations()[MaintenanceAnnotation]
+	if !ok {
+		return false, nil
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s annotation on ConfigMap '%s': %w", MaintenanceAnnotation, key, err)
+	}
+	return enabled, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMaintenance_Refresh(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		noConfigMap bool
+		want        bool
+		wantErr     string
+	}{
+		{
+			name:        "no ConfigMap",
+			noConfigMap: true,
+		},
+		{
+			name: "no annotation",
+		},
+		{
+			name:        "enabled",
+			annotations: map[string]string{MaintenanceAnnotation: "true"},
+			want:        true,
+		},
+		{
+			name:        "disabled",
+			annotations: map[string]string{MaintenanceAnnotation: "false"},
+		},
+		{
+			name:        "invalid value",
+			annotations: map[string]string{MaintenanceAnnotation: "yes please"},
+			wantErr:     "invalid " + MaintenanceAnnotation + " annotation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			builder := fakeclient.NewClientBuilder().WithScheme(testEnv.GetScheme())
+			if !tt.noConfigMap {
+				builder.WithObjects(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "maintenance",
+						Namespace:   "flux-system",
+						Annotations: tt.annotations,
+					},
+				})
+			}
+			m := &Maintenance{
+				Reader:    builder.Build(),
+				Name:      "maintenance",
+				Namespace: "flux-system",
+				Interval:  time.Minute,
+			}
+
+			changed, err := m.Refresh(context.TODO())
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				g.Expect(m.Enabled()).To(BeFalse())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(changed).To(Equal(tt.want))
+			g.Expect(m.Enabled()).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestMaintenance_Enabled(t *testing.T) {
+	g := NewWithT(t)
+
+	var m *Maintenance
+	g.Expect(m.Enabled()).To(BeFalse())
+	g.Expect(m.RequeueAfter()).To(BeZero())
+
+	m = &Maintenance{Forced: true, Interval: time.Minute}
+	g.Expect(m.Enabled()).To(BeTrue())
+	g.Expect(m.RequeueAfter()).To(Equal(time.Minute))
+
+	changed, err := m.Refresh(context.TODO())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changed).To(BeFalse())
+	g.Expect(m.Enabled()).To(BeTrue())
+}
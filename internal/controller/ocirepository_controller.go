@@ -39,6 +39,7 @@ import (
 	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sigstore/cosign/v2/pkg/cosign"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -56,7 +57,6 @@ import (
 	"github.com/fluxcd/pkg/oci"
 	"github.com/fluxcd/pkg/runtime/conditions"
 	helper "github.com/fluxcd/pkg/runtime/controller"
-	"github.com/fluxcd/pkg/runtime/jitter"
 	"github.com/fluxcd/pkg/runtime/patch"
 	"github.com/fluxcd/pkg/runtime/predicates"
 	rreconcile "github.com/fluxcd/pkg/runtime/reconcile"
@@ -72,13 +72,18 @@ import (
 
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	serror "github.com/fluxcd/source-controller/internal/error"
+	"github.com/fluxcd/source-controller/internal/features"
+	intjitter "github.com/fluxcd/source-controller/internal/jitter"
 	soci "github.com/fluxcd/source-controller/internal/oci"
 	scosign "github.com/fluxcd/source-controller/internal/oci/cosign"
 	"github.com/fluxcd/source-controller/internal/oci/notation"
+	"github.com/fluxcd/source-controller/internal/proxy"
+	"github.com/fluxcd/source-controller/internal/ratelimit"
 	sreconcile "github.com/fluxcd/source-controller/internal/reconcile"
 	"github.com/fluxcd/source-controller/internal/reconcile/summarize"
 	"github.com/fluxcd/source-controller/internal/tls"
-	"github.com/fluxcd/source-controller/internal/util"
+	"github.com/fluxcd/source-controller/internal/upstream"
+	"github.com/fluxcd/source-controller/internal/workspace"
 )
 
 // ociRepositoryReadyCondition contains the information required to summarize a
@@ -91,6 +96,7 @@ var ociRepositoryReadyCondition = summarize.Conditions{
 		sourcev1.ArtifactOutdatedCondition,
 		sourcev1.ArtifactInStorageCondition,
 		sourcev1.SourceVerifiedCondition,
+		sourcev1.SLOViolatedCondition,
 		meta.ReadyCondition,
 		meta.ReconcilingCondition,
 		meta.StalledCondition,
@@ -143,14 +149,23 @@ type OCIRepositoryReconciler struct {
 	Storage           *Storage
 	ControllerName    string
 	TokenCache        *cache.TokenCache
+	Upstream          *upstream.Accountant
+	Certificates      *upstream.CertificateMonitor
+	Share             *ArtifactShare
+	Workspaces        *workspace.Manager
+	Diagnostics       *ReconcileDiagnostics
 	requeueDependency time.Duration
 
-	patchOptions []patch.Option
+	maxFailureBackoff time.Duration
+	patchOptions      []patch.Option
 }
 
 type OCIRepositoryReconcilerOptions struct {
 	DependencyRequeueInterval time.Duration
 	RateLimiter               workqueue.TypedRateLimiter[reconcile.Request]
+	MaxFailureBackoff         time.Duration
+	NamespaceLimiter          *ratelimit.NamespaceLimiter
+	Backlog                   *ReconcileBacklog
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -159,6 +174,7 @@ func (r *OCIRepositoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 func (r *OCIRepositoryReconciler) SetupWithManagerAndOptions(mgr ctrl.Manager, opts OCIRepositoryReconcilerOptions) error {
+	r.maxFailureBackoff = opts.MaxFailureBackoff
 	r.patchOptions = getPatchOptions(ociRepositoryReadyCondition.Owned, r.ControllerName)
 
 	r.requeueDependency = opts.DependencyRequeueInterval
@@ -170,7 +186,7 @@ func (r *OCIRepositoryReconciler) SetupWithManagerAndOptions(mgr ctrl.Manager, o
 		WithOptions(controller.Options{
 			RateLimiter: opts.RateLimiter,
 		}).
-		Complete(r)
+		Complete(opts.NamespaceLimiter.Reconciler(sourcev1.OCIRepositoryKind, opts.Backlog.Reconciler(sourcev1.OCIRepositoryKind, r)))
 }
 
 // +kubebuilder:rbac:groups=source.toolkit.fluxcd.io,resources=ocirepositories,verbs=get;list;watch;create;update;patch;delete
@@ -189,6 +205,20 @@ func (r *OCIRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Leave the object untouched while in maintenance mode.
+	if r.Storage.Maintenance.Enabled() {
+		log.V(1).Info("controller is in maintenance mode, skipping reconciliation")
+		skipReconcile(ctx)
+		return ctrl.Result{RequeueAfter: r.Storage.Maintenance.RequeueAfter()}, nil
+	}
+
+	// Wait for the storage fencing token before writing to the Storage.
+	if !r.Storage.Fence.Acquired() {
+		log.V(1).Info("storage fencing token not acquired yet, skipping reconciliation")
+		skipReconcile(ctx)
+		return ctrl.Result{RequeueAfter: storageFenceRequeueAfter}, nil
+	}
+
 	// Initialize the patch helper with the current version of the object.
 	serialPatcher := patch.NewSerialPatcher(obj, r.Client)
 
@@ -210,9 +240,10 @@ func (r *OCIRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 				summarize.RecordReconcileReq,
 			),
 			summarize.WithResultBuilder(sreconcile.AlwaysRequeueResultBuilder{
-				RequeueAfter: jitter.JitteredIntervalDuration(obj.GetRequeueAfter()),
+				RequeueAfter: intjitter.JitteredIntervalDuration(sourcev1.OCIRepositoryKind, obj.GetRequeueAfter()),
 			}),
 			summarize.WithPatchFieldOwner(r.ControllerName),
+			summarize.WithFailureBackoff(r.maxFailureBackoff),
 		}
 		result, retErr = summarizeHelper.SummarizeAndPatch(ctx, obj, summarizeOpts...)
 
@@ -223,6 +254,11 @@ func (r *OCIRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	// Examine if the object is under deletion.
 	if !obj.ObjectMeta.DeletionTimestamp.IsZero() {
 		recResult, retErr = r.reconcileDelete(ctx, obj)
+		if retErr == nil {
+			if err := r.Diagnostics.Remove(obj); err != nil {
+				log.Error(err, "failed to remove diagnostic bundle")
+			}
+		}
 		return
 	}
 
@@ -239,6 +275,9 @@ func (r *OCIRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	// Return if the object is suspended.
 	if obj.Spec.Suspend {
 		log.Info("reconciliation is suspended for this object")
+		if err := collectSuspended(ctx, r.Storage, r.EventRecorder, obj, 5*time.Second); err != nil {
+			log.Error(err, "garbage collection failed")
+		}
 		recResult, retErr = sreconcile.ResultEmpty, nil
 		return
 	}
@@ -250,6 +289,8 @@ func (r *OCIRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		r.reconcileArtifact,
 	}
 	recResult, retErr = r.reconcile(ctx, serialPatcher, obj, reconcilers)
+	reconcileSLO(ctx, r.EventRecorder, obj, time.Since(start))
+	r.Diagnostics.Record(ctx, obj, start, retErr)
 	return
 }
 
@@ -282,7 +323,7 @@ func (r *OCIRepositoryReconciler) reconcile(ctx context.Context, sp *patch.Seria
 	}
 
 	// Create temp working dir
-	tmpDir, err := util.TempDirForObj("", obj)
+	tmpDir, err := r.Workspaces.Create(obj)
 	if err != nil {
 		e := serror.NewGeneric(
 			fmt.Errorf("failed to create temporary working directory: %w", err),
@@ -292,7 +333,7 @@ func (r *OCIRepositoryReconciler) reconcile(ctx context.Context, sp *patch.Seria
 		return sreconcile.ResultEmpty, e
 	}
 	defer func() {
-		if err = os.RemoveAll(tmpDir); err != nil {
+		if err = r.Workspaces.Remove(tmpDir); err != nil {
 			ctrl.LoggerFrom(ctx).Error(err, "failed to remove temporary working directory")
 		}
 	}()
@@ -314,7 +355,7 @@ func (r *OCIRepositoryReconciler) reconcile(ctx context.Context, sp *patch.Seria
 		// If an error is received, prioritize the returned results because an
 		// error also means immediate requeue.
 		if err != nil {
-			resErr = err
+			resErr = r.Storage.Backoff(obj, err)
 			res = recResult
 			break
 		}
@@ -369,8 +410,8 @@ func (r *OCIRepositoryReconciler) reconcileSource(ctx context.Context, sp *patch
 		var opts []auth.Option
 		if obj.Spec.ServiceAccountName != "" {
 			// Check object-level workload identity feature gate.
-			if !auth.IsObjectLevelWorkloadIdentityEnabled() {
-				const gate = auth.FeatureGateObjectLevelWorkloadIdentity
+			const gate = auth.FeatureGateObjectLevelWorkloadIdentity
+			if enabled, _ := features.EnabledFor(gate, obj.GetNamespace()); !enabled {
 				const msgFmt = "to use spec.serviceAccountName for provider authentication please enable the %s feature gate in the controller"
 				err := fmt.Errorf(msgFmt, gate)
 				return sreconcile.ResultEmpty, serror.NewStalling(err, meta.FeatureGateDisabledReason)
@@ -416,7 +457,7 @@ func (r *OCIRepositoryReconciler) reconcileSource(ctx context.Context, sp *patch
 		return sreconcile.ResultEmpty, e
 	}
 
-	opts := makeRemoteOptions(ctx, transport, keychain, authenticator)
+	opts := makeRemoteOptions(ctx, r.Upstream.RoundTripper(transport), keychain, authenticator)
 
 	// Determine which artifact revision to pull
 	ref, err := r.getArtifactRef(obj, opts)
@@ -441,12 +482,33 @@ func (r *OCIRepositoryReconciler) reconcileSource(ctx context.Context, sp *patch
 	revision, err := r.getRevision(ref, opts)
 	if err != nil {
 		e := serror.NewGeneric(
-			fmt.Errorf("failed to determine artifact digest: %w", err),
+			serror.Classified(fmt.Errorf("failed to determine artifact digest: %w", err)),
 			sourcev1.OCIPullFailedReason,
 		)
 		conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
 		return sreconcile.ResultEmpty, e
 	}
+	if cert := observeUpstreamCertificate(ctx, r.Certificates, r.EventRecorder, obj,
+		obj.Spec.URL, obj.Spec.Insecure, proxyURL); cert != nil {
+		obj.Status.UpstreamCertificate = cert
+	}
+
+	// Resolve the referrer to pull instead of the artifact itself, its
+	// digest replaces the artifact digest in the revision
+	pullRef := ref
+	if obj.Spec.Referrer != nil {
+		referrerRef, err := r.getReferrerRef(ref, revision, obj.Spec.Referrer.ArtifactType, opts)
+		if err != nil {
+			e := serror.NewGeneric(
+				serror.Classified(fmt.Errorf("failed to determine referrer of artifact type '%s': %w", obj.Spec.Referrer.ArtifactType, err)),
+				sourcev1.OCIPullFailedReason,
+			)
+			conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
+			return sreconcile.ResultEmpty, e
+		}
+		pullRef = referrerRef
+		revision = strings.TrimSuffix(revision, r.digestFromRevision(revision)) + referrerRef.DigestStr()
+	}
 	metaArtifact := &sourcev1.Artifact{Revision: revision}
 	metaArtifact.DeepCopyInto(metadata)
 
@@ -469,6 +531,9 @@ func (r *OCIRepositoryReconciler) reconcileSource(ctx context.Context, sp *patch
 	// - the upstream digest differs from the one in storage (revision drift)
 	// - the OCIRepository spec has changed (generation drift)
 	// - the previous reconciliation resulted in a failed artifact verification (retry with exponential backoff)
+	// When the unverified content is quarantined, the artifact is still pulled
+	// and verifyErr is returned once it has been persisted to the directory.
+	var verifyErr error
 	if obj.Spec.Verify == nil {
 		// Remove old observations if verification was disabled
 		conditions.Delete(obj, sourcev1.SourceVerifiedCondition)
@@ -487,7 +552,10 @@ func (r *OCIRepositoryReconciler) reconcileSource(ctx context.Context, sp *patch
 				sourcev1.VerificationError,
 			)
 			conditions.MarkFalse(obj, sourcev1.SourceVerifiedCondition, e.Reason, "%s", e)
-			return sreconcile.ResultEmpty, e
+			if !r.Storage.QuarantineUnverified {
+				return sreconcile.ResultEmpty, e
+			}
+			verifyErr = e
 		}
 
 		if result == soci.VerificationResultSuccess {
@@ -499,14 +567,39 @@ func (r *OCIRepositoryReconciler) reconcileSource(ctx context.Context, sp *patch
 	// not changed.
 	if obj.GetArtifact().HasRevision(revision) && !ociContentConfigChanged(obj) {
 		conditions.Delete(obj, sourcev1.FetchFailedCondition)
+		if verifyErr != nil {
+			return sreconcile.ResultEmpty, verifyErr
+		}
 		return sreconcile.ResultSuccess, nil
 	}
 
+	// Reuse the Artifact stored by another object for the same content
+	// instead of pulling it again
+	if verifyErr == nil {
+		digest := r.digestFromRevision(revision)
+		if shared := r.Share.Get(r.Storage, ociShareKey(obj, pullRef.Context().Name(), digest)); shared != nil {
+			// A digest reference is not resolved against the registry, check
+			// that the object can access the content with its own credentials
+			// before serving it the content pulled by another object
+			_, err := remote.Head(pullRef.Context().Digest(digest), opts...)
+			if err == nil {
+				err = r.copySharedArtifact(obj, shared, metadata, dir)
+			}
+			if err == nil {
+				r.eventLogf(ctx, obj, eventv1.EventTypeTrace, "ArtifactShared",
+					"reusing artifact '%s' stored for revision '%s'", shared.Path, shared.Revision)
+				conditions.Delete(obj, sourcev1.FetchFailedCondition)
+				return sreconcile.ResultSuccess, nil
+			}
+			ctrl.LoggerFrom(ctx).V(1).Info("failed to reuse shared artifact, pulling it", "error", err.Error())
+		}
+	}
+
 	// Pull artifact from the remote container registry
-	img, err := remote.Image(ref, opts...)
+	img, err := remote.Image(pullRef, opts...)
 	if err != nil {
 		e := serror.NewGeneric(
-			fmt.Errorf("failed to pull artifact from '%s': %w", obj.Spec.URL, err),
+			serror.Classified(fmt.Errorf("failed to pull artifact from '%s': %w", obj.Spec.URL, err)),
 			sourcev1.OCIPullFailedReason,
 		)
 		conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
@@ -525,6 +618,21 @@ func (r *OCIRepositoryReconciler) reconcileSource(ctx context.Context, sp *patch
 	}
 	metadata.Metadata = manifest.Annotations
 
+	// Persist the referrer layers to storage as files
+	if obj.Spec.Referrer != nil {
+		if err := r.writeReferrerLayers(obj, img, manifest, dir); err != nil {
+			e := serror.NewGeneric(err, sourcev1.OCILayerOperationFailedReason)
+			conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
+			return sreconcile.ResultEmpty, e
+		}
+		conditions.Delete(obj, sourcev1.FetchFailedCondition)
+		if verifyErr != nil {
+			r.quarantineUnverified(ctx, obj, revision, dir)
+			return sreconcile.ResultEmpty, verifyErr
+		}
+		return sreconcile.ResultSuccess, nil
+	}
+
 	// Extract the compressed content from the selected layer
 	blob, err := r.selectLayer(obj, img)
 	if err != nil {
@@ -576,9 +684,20 @@ func (r *OCIRepositoryReconciler) reconcileSource(ctx context.Context, sp *patch
 	}
 
 	conditions.Delete(obj, sourcev1.FetchFailedCondition)
+	if verifyErr != nil {
+		r.quarantineUnverified(ctx, obj, revision, dir)
+		return sreconcile.ResultEmpty, verifyErr
+	}
 	return sreconcile.ResultSuccess, nil
 }
 
+// quarantineUnverified stores the content of the given revision, which
+// failed verification, in the quarantine area of the Storage.
+func (r *OCIRepositoryReconciler) quarantineUnverified(ctx context.Context, obj *sourcev1.OCIRepository, revision, dir string) {
+	quarantineUnverified(ctx, r.Storage, r.EventRecorder, obj.Kind, obj, revision,
+		fmt.Sprintf("%s.tar.gz", r.digestFromRevision(revision)), dir, obj.Spec.Ignore)
+}
+
 // selectLayer finds the matching layer and returns its compressed contents.
 // If no layer selector was provided, we pick the first layer from the OCI artifact.
 func (r *OCIRepositoryReconciler) selectLayer(obj *sourcev1.OCIRepository, image gcrv1.Image) (io.ReadCloser, error) {
@@ -621,6 +740,95 @@ func (r *OCIRepositoryReconciler) selectLayer(obj *sourcev1.OCIRepository, image
 	return blob, nil
 }
 
+// getReferrerRef returns the reference to the referrer of the given artifact
+// type for the digest of the revision. When multiple referrers match, the most
+// recently created one is returned.
+func (r *OCIRepositoryReconciler) getReferrerRef(ref name.Reference, revision, artifactType string,
+	options []remote.Option) (name.Digest, error) {
+	subject := ref.Context().Digest(r.digestFromRevision(revision))
+
+	// The filter is a hint, registries are not required to apply it
+	index, err := remote.Referrers(subject, append(options, remote.WithFilter("artifactType", artifactType))...)
+	if err != nil {
+		return name.Digest{}, err
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	var selected *gcrv1.Descriptor
+	var selectedCreated time.Time
+	for i, desc := range manifest.Manifests {
+		if desc.ArtifactType != artifactType {
+			continue
+		}
+		created, _ := time.Parse(time.RFC3339, desc.Annotations[ocispec.AnnotationCreated])
+		if selected == nil || created.After(selectedCreated) {
+			selected, selectedCreated = &manifest.Manifests[i], created
+		}
+	}
+	if selected == nil {
+		return name.Digest{}, fmt.Errorf("no referrer found for '%s'", subject)
+	}
+	return ref.Context().Digest(selected.Digest.String()), nil
+}
+
+// writeReferrerLayers writes the compressed contents of the layers of the
+// referrer image to files in dir. When a layer selector media type is
+// specified, only the matching layers are written. Layers with the same file
+// name are rejected, as they would overwrite each other.
+func (r *OCIRepositoryReconciler) writeReferrerLayers(obj *sourcev1.OCIRepository, image gcrv1.Image,
+	manifest *gcrv1.Manifest, dir string) error {
+	written := make(map[string]int)
+	for i, desc := range manifest.Layers {
+		if mt := obj.GetLayerMediaType(); mt != "" && string(desc.MediaType) != mt {
+			continue
+		}
+
+		fileName := filepath.Base(desc.Annotations[ocispec.AnnotationTitle])
+		if fileName == "" || fileName == "." || fileName == "/" || fileName == ".." {
+			fileName = desc.Digest.Hex
+		}
+		if j, ok := written[fileName]; ok {
+			return fmt.Errorf("layer[%v] and layer[%v] from referrer have the same file name '%s'", j, i, fileName)
+		}
+
+		layer, err := image.LayerByDigest(desc.Digest)
+		if err != nil {
+			return fmt.Errorf("failed to get layer[%v] from referrer: %w", i, err)
+		}
+		blob, err := layer.Compressed()
+		if err != nil {
+			return fmt.Errorf("failed to read layer[%v] from referrer: %w", i, err)
+		}
+		err = func() error {
+			defer blob.Close()
+			file, err := os.Create(filepath.Join(dir, fileName))
+			if err != nil {
+				return err
+			}
+			if _, err = io.Copy(file, blob); err != nil {
+				file.Close()
+				return err
+			}
+			return file.Close()
+		}()
+		if err != nil {
+			return fmt.Errorf("failed to write layer[%v] from referrer: %w", i, err)
+		}
+		written[fileName] = i
+	}
+
+	if len(written) == 0 {
+		if mt := obj.GetLayerMediaType(); mt != "" {
+			return fmt.Errorf("failed to find layer with media type '%s' in referrer", mt)
+		}
+		return fmt.Errorf("no layers found in referrer")
+	}
+	return nil
+}
+
 // getRevision fetches the upstream digest, returning the revision in the
 // format '<tag>@<digest>'.
 func (r *OCIRepositoryReconciler) getRevision(ref name.Reference, options []remote.Option) (string, error) {
@@ -1023,7 +1231,8 @@ func (r *OCIRepositoryReconciler) getTLSConfig(ctx context.Context, obj *sourcev
 }
 
 // getProxyURL gets the proxy configuration for the transport based on the
-// specified proxy secret reference in the OCIRepository object.
+// specified proxy secret reference in the OCIRepository object. It returns
+// nil if the repository URL is excluded from the proxy.
 func (r *OCIRepositoryReconciler) getProxyURL(ctx context.Context, obj *sourcev1.OCIRepository) (*url.URL, error) {
 	if obj.Spec.ProxySecretRef == nil || obj.Spec.ProxySecretRef.Name == "" {
 		return nil, nil
@@ -1038,22 +1247,7 @@ func (r *OCIRepositoryReconciler) getProxyURL(ctx context.Context, obj *sourcev1
 		return nil, err
 	}
 
-	proxyData := proxySecret.Data
-	address, ok := proxyData["address"]
-	if !ok {
-		return nil, fmt.Errorf("invalid proxy secret '%s/%s': key 'address' is missing",
-			obj.Namespace, obj.Spec.ProxySecretRef.Name)
-	}
-	proxyURL, err := url.Parse(string(address))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse proxy address '%s': %w", address, err)
-	}
-	user, hasUser := proxyData["username"]
-	password, hasPassword := proxyData["password"]
-	if hasUser || hasPassword {
-		proxyURL.User = url.UserPassword(string(user), string(password))
-	}
-	return proxyURL, nil
+	return proxy.URLFromSecret(&proxySecret, obj.Spec.URL)
 }
 
 // reconcileStorage ensures the current state of the storage matches the
@@ -1153,6 +1347,13 @@ func (r *OCIRepositoryReconciler) reconcileArtifact(ctx context.Context, sp *pat
 		return sreconcile.ResultSuccess, nil
 	}
 
+	// Refuse to archive a working directory exceeding the workspace quota
+	if err := r.Workspaces.CheckQuota(dir); err != nil {
+		e := serror.NewGeneric(err, sourcev1.WorkspaceQuotaExceededReason)
+		conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, e.Reason, "%s", e)
+		return sreconcile.ResultEmpty, e
+	}
+
 	// Ensure target path exists and is a directory
 	if f, err := os.Stat(dir); err != nil {
 		e := serror.NewGeneric(
@@ -1192,7 +1393,7 @@ func (r *OCIRepositoryReconciler) reconcileArtifact(ctx context.Context, sp *pat
 	case sourcev1.OCILayerCopy:
 		if err = r.Storage.CopyFromPath(&artifact, filepath.Join(dir, metadata.Path)); err != nil {
 			e := serror.NewGeneric(
-				fmt.Errorf("unable to copy artifact to storage: %w", err),
+				serror.Classified(fmt.Errorf("unable to copy artifact to storage: %w", err)),
 				sourcev1.ArchiveOperationFailedReason,
 			)
 			conditions.MarkTrue(obj, sourcev1.StorageOperationFailedCondition, e.Reason, "%s", e)
@@ -1214,7 +1415,7 @@ func (r *OCIRepositoryReconciler) reconcileArtifact(ctx context.Context, sp *pat
 
 		if err := r.Storage.Archive(&artifact, dir, SourceIgnoreFilter(ps, ignoreDomain)); err != nil {
 			e := serror.NewGeneric(
-				fmt.Errorf("unable to archive artifact to storage: %s", err),
+				serror.Classified(fmt.Errorf("unable to archive artifact to storage: %w", err)),
 				sourcev1.ArchiveOperationFailedReason,
 			)
 			conditions.MarkTrue(obj, sourcev1.StorageOperationFailedCondition, e.Reason, "%s", e)
@@ -1222,11 +1423,20 @@ func (r *OCIRepositoryReconciler) reconcileArtifact(ctx context.Context, sp *pat
 		}
 	}
 
+	if err := r.Storage.VerifyReadBack(ctx, artifact); err != nil {
+		e := serror.NewGeneric(err, sourcev1.ArtifactReadBackFailedReason)
+		conditions.MarkTrue(obj, sourcev1.StorageOperationFailedCondition, e.Reason, "%s", e)
+		return sreconcile.ResultEmpty, e
+	}
+
 	// Record the observations on the object.
 	obj.Status.Artifact = artifact.DeepCopy()
 	obj.Status.Artifact.Metadata = metadata.Metadata
 	obj.Status.ObservedIgnore = obj.Spec.Ignore
 	obj.Status.ObservedLayerSelector = obj.Spec.LayerSelector
+	if repo, err := r.parseRepository(obj); err == nil {
+		r.Share.Put(ociShareKey(obj, repo.Name(), r.digestFromRevision(artifact.Revision)), *obj.Status.Artifact)
+	}
 
 	// Update symlink on a "best effort" basis
 	url, err := r.Storage.Symlink(artifact, "latest.tar.gz")
@@ -1251,6 +1461,8 @@ func (r *OCIRepositoryReconciler) reconcileDelete(ctx context.Context, obj *sour
 		return sreconcile.ResultEmpty, err
 	}
 
+	forgetUpstreamCertificates(r.Certificates, obj)
+
 	// Remove our finalizer from the list
 	controllerutil.RemoveFinalizer(obj, sourcev1.SourceFinalizer)
 
@@ -1279,10 +1491,15 @@ func (r *OCIRepositoryReconciler) garbageCollect(ctx context.Context, obj *sourc
 				"garbage collected artifacts for deleted resource")
 		}
 		obj.Status.Artifact = nil
+		obj.Status.PinnedArtifacts = nil
 		return nil
 	}
 	if obj.GetArtifact() != nil {
-		delFiles, err := r.Storage.GarbageCollect(ctx, *obj.GetArtifact(), time.Second*5)
+		obj.Status.PinnedArtifacts = r.Storage.PinArtifacts(obj.GetAnnotations(), obj.GetArtifact(), obj.Status.PinnedArtifacts)
+		if r.Storage.DeferGarbageCollection {
+			return nil
+		}
+		delFiles, err := r.Storage.retentionFor(obj).GarbageCollect(ctx, *obj.GetArtifact(), time.Second*5, obj.Status.PinnedArtifacts...)
 		if err != nil {
 			return serror.NewGeneric(
 				fmt.Errorf("garbage collection of artifacts failed: %w", err),
@@ -1319,10 +1536,7 @@ func (r *OCIRepositoryReconciler) notify(ctx context.Context, oldObj, newObj *so
 	// Notify successful reconciliation for new artifact and recovery from any
 	// failure.
 	if resErr == nil && res == sreconcile.ResultSuccess && newObj.Status.Artifact != nil {
-		annotations := map[string]string{
-			fmt.Sprintf("%s/%s", sourcev1.GroupVersion.Group, eventv1.MetaRevisionKey): newObj.Status.Artifact.Revision,
-			fmt.Sprintf("%s/%s", sourcev1.GroupVersion.Group, eventv1.MetaDigestKey):   newObj.Status.Artifact.Digest,
-		}
+		annotations := artifactEventMetadata(newObj.Status.Artifact, r.Storage.Backend())
 
 		message := fmt.Sprintf("stored artifact with revision '%s' from '%s'", newObj.Status.Artifact.Revision, newObj.Spec.URL)
 
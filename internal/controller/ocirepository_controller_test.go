@@ -33,9 +33,13 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
 	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	gcrtypes "github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/notaryproject/notation-core-go/signature/cose"
 	"github.com/notaryproject/notation-core-go/testhelper"
 	"github.com/notaryproject/notation-go"
@@ -833,6 +837,105 @@ func TestOCIRepository_reconcileSource_authStrategy(t *testing.T) {
 	}
 }
 
+func TestOCIRepository_reconcileSource_share(t *testing.T) {
+	g := NewWithT(t)
+
+	server, err := setupRegistryServer(ctx, t.TempDir(), registryOptions{withBasicAuth: true})
+	g.Expect(err).ToNot(HaveOccurred())
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	img, err := createPodinfoImageFromTar("podinfo-6.1.6.tar", "6.1.6", server.registryHost,
+		crane.WithAuth(&authn.Basic{
+			Username: testRegistryUsername,
+			Password: testRegistryPassword,
+		}),
+		crane.Insecure,
+	)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "registry-auth",
+			Namespace: "team-a",
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			".dockerconfigjson": []byte(fmt.Sprintf(`{"auths": {%q: {"username": %q, "password": %q}}}`,
+				server.registryHost, testRegistryUsername, testRegistryPassword)),
+		},
+	}
+
+	newObj := func(namespace string, secretRef *meta.LocalObjectReference) *sourcev1.OCIRepository {
+		return &sourcev1.OCIRepository{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "podinfo",
+				Namespace:  namespace,
+				Generation: 1,
+			},
+			Spec: sourcev1.OCIRepositorySpec{
+				URL:       img.url,
+				Reference: &sourcev1.OCIRepositoryRef{Digest: img.digest.String()},
+				SecretRef: secretRef,
+				Insecure:  true,
+				Interval:  metav1.Duration{Duration: interval},
+				Timeout:   &metav1.Duration{Duration: timeout},
+			},
+		}
+	}
+
+	// Share the content stored for an object with credentials.
+	owner := newObj("team-a", &meta.LocalObjectReference{Name: secret.Name})
+	content := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(content, "shared"), []byte("private"), 0o600)).To(Succeed())
+	artifact := storage.NewArtifactFor(sourcev1.OCIRepositoryKind, owner, img.digest.String(), "shared.tar.gz")
+	g.Expect(storage.MkdirAll(artifact)).To(Succeed())
+	g.Expect(storage.Archive(&artifact, content, nil)).To(Succeed())
+
+	repo, err := name.NewRepository(strings.TrimPrefix(img.url, sourcev1.OCIRepositoryPrefix), name.Insecure)
+	g.Expect(err).ToNot(HaveOccurred())
+	share := NewArtifactShare(time.Minute)
+	share.Put(ociShareKey(owner, repo.Name(), img.digest.String()), artifact)
+
+	r := &OCIRepositoryReconciler{
+		Client: fakeclient.NewClientBuilder().
+			WithScheme(testEnv.GetScheme()).
+			WithStatusSubresource(&sourcev1.OCIRepository{}).
+			WithObjects(secret).
+			Build(),
+		EventRecorder: record.NewFakeRecorder(32),
+		Storage:       storage,
+		Share:         share,
+		patchOptions:  getPatchOptions(ociRepositoryReadyCondition.Owned, "sc"),
+	}
+
+	// An object without credentials for the repository must not be served
+	// the shared content, even though its digest reference is not resolved
+	// against the registry.
+	intruder := newObj("team-b", nil)
+	g.Expect(r.Client.Create(ctx, intruder)).To(Succeed())
+	dir := t.TempDir()
+	got, err := r.reconcileSource(ctx, patch.NewSerialPatcher(intruder, r.Client), intruder, &sourcev1.Artifact{}, dir)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(got).To(Equal(sreconcile.ResultEmpty))
+	g.Expect(filepath.Join(dir, "shared")).ToNot(BeAnExistingFile())
+
+	// An object with credentials for the repository is served the shared
+	// content.
+	tenant := newObj("team-a", &meta.LocalObjectReference{Name: secret.Name})
+	tenant.Name = "podinfo-copy"
+	g.Expect(r.Client.Create(ctx, tenant)).To(Succeed())
+	dir = t.TempDir()
+	got, err = r.reconcileSource(ctx, patch.NewSerialPatcher(tenant, r.Client), tenant, &sourcev1.Artifact{}, dir)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(Equal(sreconcile.ResultSuccess))
+	g.Expect(filepath.Join(dir, "shared")).To(BeAnExistingFile())
+}
+
 func makeTransport(insecure bool) http.RoundTripper {
 	transport := remote.DefaultTransport.(*http.Transport).Clone()
 	if insecure {
@@ -2603,6 +2706,148 @@ func TestOCIRepository_reconcileSource_noop(t *testing.T) {
 	}
 }
 
+func TestOCIRepository_reconcileSource_referrer(t *testing.T) {
+	g := NewWithT(t)
+
+	tmpDir := t.TempDir()
+	server, err := setupRegistryServer(ctx, tmpDir, registryOptions{})
+	g.Expect(err).ToNot(HaveOccurred())
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	podinfoVersions, err := pushMultiplePodinfoImages(server.registryHost, true, "6.1.5")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Push an SBOM referring to the podinfo image
+	subjectRef, err := name.ParseReference(fmt.Sprintf("%s/podinfo@%s", server.registryHost,
+		podinfoVersions["6.1.5"].digest.String()), name.Insecure)
+	g.Expect(err).ToNot(HaveOccurred())
+	subject, err := remote.Head(subjectRef)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	sbom := []byte(`{"spdxVersion":"SPDX-2.3"}`)
+	sbomImg, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       static.NewLayer(sbom, "application/spdx+json"),
+		Annotations: map[string]string{ocispec.AnnotationTitle: "sbom.spdx.json"},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	sbomImg = mutate.MediaType(sbomImg, gcrtypes.OCIManifestSchema1)
+	sbomImg = mutate.ConfigMediaType(sbomImg, "application/spdx+json")
+	sbomImg = mutate.Subject(sbomImg, *subject).(gcrv1.Image)
+	sbomDigest, err := sbomImg.Digest()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(remote.Write(subjectRef.Context().Digest(sbomDigest.String()), sbomImg)).To(Succeed())
+
+	// Push an attestation with two layers of the same title
+	attestationImg, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       static.NewLayer([]byte(`{"predicate":"a"}`), "application/vnd.in-toto+json"),
+		Annotations: map[string]string{ocispec.AnnotationTitle: "attestation.json"},
+	}, mutate.Addendum{
+		Layer:       static.NewLayer([]byte(`{"predicate":"b"}`), "application/vnd.in-toto+json"),
+		Annotations: map[string]string{ocispec.AnnotationTitle: "attestation.json"},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	attestationImg = mutate.MediaType(attestationImg, gcrtypes.OCIManifestSchema1)
+	attestationImg = mutate.ConfigMediaType(attestationImg, "application/vnd.in-toto+json")
+	attestationImg = mutate.Subject(attestationImg, *subject).(gcrv1.Image)
+	attestationDigest, err := attestationImg.Digest()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(remote.Write(subjectRef.Context().Digest(attestationDigest.String()), attestationImg)).To(Succeed())
+
+	tests := []struct {
+		name         string
+		artifactType string
+		mediaType    string
+		wantErr      string
+	}{
+		{
+			name:         "fetches referrer of artifact type",
+			artifactType: "application/spdx+json",
+		},
+		{
+			name:         "fetches referrer layer of media type",
+			artifactType: "application/spdx+json",
+			mediaType:    "application/spdx+json",
+		},
+		{
+			name:         "fails without referrer of artifact type",
+			artifactType: "application/vnd.cyclonedx+json",
+			wantErr:      "no referrer found",
+		},
+		{
+			name:         "fails with referrer layers of the same title",
+			artifactType: "application/vnd.in-toto+json",
+			wantErr:      "have the same file name 'attestation.json'",
+		},
+		{
+			name:         "fails without referrer layer of media type",
+			artifactType: "application/spdx+json",
+			mediaType:    "application/vnd.cyclonedx+json",
+			wantErr:      "failed to find layer with media type",
+		},
+	}
+
+	clientBuilder := fakeclient.NewClientBuilder().
+		WithScheme(testEnv.GetScheme()).
+		WithStatusSubresource(&sourcev1.OCIRepository{})
+
+	r := &OCIRepositoryReconciler{
+		Client:        clientBuilder.Build(),
+		EventRecorder: record.NewFakeRecorder(32),
+		Storage:       testStorage,
+		patchOptions:  getPatchOptions(ociRepositoryReadyCondition.Owned, "sc"),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := &sourcev1.OCIRepository{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "referrer-",
+					Generation:   1,
+				},
+				Spec: sourcev1.OCIRepositorySpec{
+					URL:       fmt.Sprintf("oci://%s/podinfo", server.registryHost),
+					Reference: &sourcev1.OCIRepositoryRef{Tag: "6.1.5"},
+					Referrer:  &sourcev1.OCIReferrerSelector{ArtifactType: tt.artifactType},
+					Interval:  metav1.Duration{Duration: interval},
+					Timeout:   &metav1.Duration{Duration: timeout},
+					Insecure:  true,
+				},
+			}
+			if tt.mediaType != "" {
+				obj.Spec.LayerSelector = &sourcev1.OCILayerSelector{MediaType: tt.mediaType}
+			}
+
+			g.Expect(r.Client.Create(ctx, obj)).ToNot(HaveOccurred())
+			defer func() {
+				g.Expect(r.Client.Delete(ctx, obj)).ToNot(HaveOccurred())
+			}()
+
+			sp := patch.NewSerialPatcher(obj, r.Client)
+
+			artifact := &sourcev1.Artifact{}
+			dir := t.TempDir()
+			got, err := r.reconcileSource(ctx, sp, obj, artifact, dir)
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				g.Expect(got).To(Equal(sreconcile.ResultEmpty))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(sreconcile.ResultSuccess))
+			g.Expect(artifact.Revision).To(Equal("6.1.5@" + sbomDigest.String()))
+
+			b, err := os.ReadFile(filepath.Join(dir, "sbom.spdx.json"))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(b).To(Equal(sbom))
+		})
+	}
+}
+
 func TestOCIRepository_reconcileArtifact(t *testing.T) {
 	tests := []struct {
 		name             string
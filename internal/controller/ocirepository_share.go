@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/fluxcd/pkg/tar"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+// ociShareKey returns the ArtifactShare key of the content with the given
+// digest in the given repository, as stored for the given object. The
+// repository must be the normalized name of the repository the content was
+// pulled from, so that content is never shared across repositories.
+func ociShareKey(obj *sourcev1.OCIRepository, repository, digest string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%t\x00", repository, digest, obj.GetLayerOperation(), obj.Spec.Referrer != nil)
+	if ls := obj.Spec.LayerSelector; ls != nil {
+		fmt.Fprint(h, ls.MediaType)
+	}
+	fmt.Fprint(h, "\x00")
+	if obj.Spec.Ignore != nil {
+		fmt.Fprint(h, *obj.Spec.Ignore)
+	}
+	return sourcev1.OCIRepositoryKind + "/" + hex.EncodeToString(h.Sum(nil))
+}
+
+// copySharedArtifact writes the content of the given shared Artifact to the
+// given directory, as pulling it from the registry would, and copies its
+// metadata to the given metadata Artifact.
+func (r *OCIRepositoryReconciler) copySharedArtifact(obj *sourcev1.OCIRepository, shared, metadata *sourcev1.Artifact, dir string) error {
+	f, err := os.Open(r.Storage.LocalPath(*shared))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	switch obj.GetLayerOperation() {
+	case sourcev1.OCILayerCopy:
+		path := fmt.Sprintf("%s.tgz", r.digestFromRevision(metadata.Revision))
+		file, err := os.Create(filepath.Join(dir, path))
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(file, f); err != nil {
+			file.Close()
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+		metadata.Path = path
+	default:
+		if err := tar.Untar(f, dir, tar.WithMaxUntarSize(-1), tar.WithSkipSymlinks()); err != nil {
+			return err
+		}
+	}
+	metadata.Metadata = shared.Metadata
+	return nil
+}
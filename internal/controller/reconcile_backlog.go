@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+// backlogGraceRatio is the ratio of its interval by which an object must be
+// overdue to be reported by the ReconcileBacklog.
+const backlogGraceRatio = 0.5
+
+// reconcileSkippedKey is the context key of the flag marking a
+// reconciliation as skipped.
+type reconcileSkippedKey struct{}
+
+// skipReconcile marks the reconciliation of the given context as skipped
+// without reconciling the object, so that it is not recorded by the
+// ReconcileBacklog.
+func skipReconcile(ctx context.Context) {
+	if skipped, ok := ctx.Value(reconcileSkippedKey{}).(*atomic.Bool); ok {
+		skipped.Store(true)
+	}
+}
+
+// ReconcileBacklog records the time at which each Source object was last
+// reconciled successfully, and periodically reports the number of objects
+// which have not been reconciled within their interval, and the age of the
+// most overdue of them. A growing backlog means the controller can not keep
+// up with the configured intervals.
+//
+// The reconciliations skipped without reconciling the object, e.g. in
+// maintenance mode, are not recorded. An object is only reported once it is
+// overdue by more than backlogGraceRatio of its interval, which absorbs the
+// interval jitter and the duration of the reconciliations.
+//
+// Objects which have not been reconciled since the process started are
+// considered reconciled at its start.
+//
+// The depth of the work queue of each kind is already reported by the
+// workqueue_depth metric of controller-runtime.
+type ReconcileBacklog struct {
+	client.Reader
+
+	// Interval is the interval at which the metrics are updated.
+	Interval time.Duration
+
+	started time.Time
+
+	mu   sync.Mutex
+	last map[string]time.Time
+
+	staleGauge   *prometheus.GaugeVec
+	overdueGauge *prometheus.GaugeVec
+}
+
+// NewReconcileBacklog returns a new ReconcileBacklog listing the objects
+// with the given client.Reader at the given interval. The configured label
+// is: kind.
+func NewReconcileBacklog(reader client.Reader, interval time.Duration) *ReconcileBacklog {
+	return &ReconcileBacklog{
+		Reader:   reader,
+		Interval: interval,
+		started:  time.Now(),
+		last:     make(map[string]time.Time),
+		staleGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gotk_reconcile_backlog_stale_objects",
+				Help: "Number of objects which have not been reconciled successfully within their interval.",
+			},
+			[]string{"kind"},
+		),
+		overdueGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gotk_reconcile_backlog_oldest_overdue_seconds",
+				Help: "Time since the most overdue object should have been reconciled according to its interval.",
+			},
+			[]string{"kind"},
+		),
+	}
+}
+
+// Collectors returns the metrics.Collector objects for the ReconcileBacklog.
+func (b *ReconcileBacklog) Collectors() []prometheus.Collector {
+	if b == nil {
+		return nil
+	}
+	return []prometheus.Collector{
+		b.staleGauge,
+		b.overdueGauge,
+	}
+}
+
+// Reconciler returns a reconcile.Reconciler which records the successful
+// reconciliations of the objects of the given kind by the given
+// reconcile.Reconciler, unless they are marked as skipped with
+// skipReconcile.
+func (b *ReconcileBacklog) Reconciler(kind string, next reconcile.Reconciler) reconcile.Reconciler {
+	if b == nil {
+		return next
+	}
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		skipped := &atomic.Bool{}
+		result, err := next.Reconcile(context.WithValue(ctx, reconcileSkippedKey{}, skipped), req)
+		if err == nil && !skipped.Load() {
+			b.mu.Lock()
+			b.last[backlogKey(kind, req.Namespace, req.Name)] = time.Now()
+			b.mu.Unlock()
+		}
+		return result, err
+	})
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, as only the
+// leader reconciles the objects.
+func (b *ReconcileBacklog) NeedLeaderElection() bool {
+	return true
+}
+
+// Start updates the metrics at the configured interval until the given
+// context is canceled.
+func (b *ReconcileBacklog) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("reconcile-backlog")
+	ticker := time.NewTicker(b.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := b.Update(ctx); err != nil {
+				log.Error(err, "failed to update reconcile backlog metrics")
+			}
+		}
+	}
+}
+
+// Update lists the Source objects and updates the metrics. Suspended
+// objects are ignored, and the records of the objects which no longer exist
+// are dropped.
+func (b *ReconcileBacklog) Update(ctx context.Context) error {
+	now := time.Now()
+	stale := make(map[string]int)
+	overdue := make(map[string]time.Duration)
+	for _, kind := range sourceKinds {
+		stale[kind] = 0
+		overdue[kind] = 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	seen := make(map[string]struct{}, len(b.last))
+	err := forEachArtifactSource(ctx, b.Reader, func(obj artifactSource) error {
+		kind := sourceKind(obj)
+		key := backlogKey(kind, obj.GetNamespace(), obj.GetName())
+		seen[key] = struct{}{}
+
+		src, ok := obj.(sourcev1.Source)
+		if !ok || isSuspended(obj) || !obj.GetDeletionTimestamp().IsZero() {
+			return nil
+		}
+		last, ok := b.last[key]
+		if !ok {
+			last = b.started
+		}
+		interval := src.GetRequeueAfter()
+		grace := time.Duration(float64(interval) * backlogGraceRatio)
+		if d := now.Sub(last.Add(interval)); d > grace {
+			stale[kind]++
+			if d > overdue[kind] {
+				overdue[kind] = d
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// Keep the previous values rather than reporting a partial backlog.
+		return err
+	}
+
+	for key := range b.last {
+		if _, ok := seen[key]; !ok {
+			delete(b.last, key)
+		}
+	}
+	for kind, count := range stale {
+		b.staleGauge.WithLabelValues(kind).Set(float64(count))
+		b.overdueGauge.WithLabelValues(kind).Set(overdue[kind].Seconds())
+	}
+	return nil
+}
+
+// backlogKey returns the key of the object of the given kind, namespace and
+// name in the records of the ReconcileBacklog.
+func backlogKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
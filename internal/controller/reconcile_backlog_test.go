@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestReconcileBacklog_Update(t *testing.T) {
+	g := NewWithT(t)
+
+	newRepo := func(name string, suspend bool) *sourcev1.GitRepository {
+		return &sourcev1.GitRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "backlog"},
+			Spec: sourcev1.GitRepositorySpec{
+				Interval: metav1.Duration{Duration: time.Minute},
+				Suspend:  suspend,
+			},
+		}
+	}
+	c := fakeclient.NewClientBuilder().
+		WithScheme(testEnv.GetScheme()).
+		WithObjects(newRepo("fresh", false), newRepo("stale", false), newRepo("failing", false), newRepo("skipped", false),
+			newRepo("suspended", true)).
+		Build()
+
+	b := NewReconcileBacklog(c, time.Minute)
+	b.started = time.Now().Add(-3 * time.Minute)
+
+	r := b.Reconciler(sourcev1.GitRepositoryKind, reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		switch req.Name {
+		case "failing":
+			return reconcile.Result{}, errors.New("failure")
+		case "skipped":
+			skipReconcile(ctx)
+			return reconcile.Result{RequeueAfter: time.Second}, nil
+		}
+		return reconcile.Result{}, nil
+	}))
+	for _, name := range []string{"fresh", "failing", "skipped", "deleted"} {
+		_, _ = r.Reconcile(context.TODO(), reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: "backlog", Name: name},
+		})
+	}
+
+	g.Expect(b.Update(context.TODO())).To(Succeed())
+	g.Expect(testutil.ToFloat64(b.staleGauge.WithLabelValues(sourcev1.GitRepositoryKind))).To(Equal(float64(3)))
+	g.Expect(testutil.ToFloat64(b.overdueGauge.WithLabelValues(sourcev1.GitRepositoryKind))).To(BeNumerically("~", 120, 5))
+	g.Expect(testutil.ToFloat64(b.staleGauge.WithLabelValues(sourcev1.BucketKind))).To(BeZero())
+
+	// The records of the objects which no longer exist are dropped.
+	g.Expect(b.last).To(HaveLen(1))
+	g.Expect(b.last).To(HaveKey(backlogKey(sourcev1.GitRepositoryKind, "backlog", "fresh")))
+
+	// Objects overdue by less than the grace period are not reported.
+	b.started = time.Now().Add(-80 * time.Second)
+	g.Expect(b.Update(context.TODO())).To(Succeed())
+	g.Expect(testutil.ToFloat64(b.staleGauge.WithLabelValues(sourcev1.GitRepositoryKind))).To(BeZero())
+}
+
+func TestReconcileBacklog_Nil(t *testing.T) {
+	g := NewWithT(t)
+
+	var b *ReconcileBacklog
+	g.Expect(b.Collectors()).To(BeEmpty())
+	next := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, nil
+	})
+	g.Expect(b.Reconciler(sourcev1.GitRepositoryKind, next)).ToNot(BeNil())
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fluxcd/pkg/runtime/conditions"
+)
+
+// DiagnosticBundle is the state of a Source object at the end of a failed
+// reconciliation.
+type DiagnosticBundle struct {
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Generation int64  `json:"generation"`
+	// Spec is the spec of the object, with the passwords of the URLs it
+	// contains redacted.
+	Spec map[string]interface{} `json:"spec,omitempty"`
+	// Conditions are the conditions of the object as computed by the
+	// failed reconciliation.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Revision is the revision of the current Artifact of the object.
+	Revision  string    `json:"revision,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+	Duration  string    `json:"duration"`
+	// Errors is the chain of the reconciliation error, from the outermost
+	// to the innermost error.
+	Errors []string `json:"errors"`
+}
+
+// diagnosticObject is a Source object of which a DiagnosticBundle can be
+// recorded.
+type diagnosticObject interface {
+	client.Object
+	conditions.Getter
+}
+
+// ReconcileDiagnostics writes a DiagnosticBundle for every failed
+// reconciliation of a Source object to a file at
+// <Path>/<kind>/<namespace>/<name>.json, replacing the bundle of the previous
+// failure. The Path must not be served by the file server.
+//
+// All methods are safe to call on a nil ReconcileDiagnostics, in which case
+// nothing is recorded.
+type ReconcileDiagnostics struct {
+	Path string
+}
+
+// Record writes the DiagnosticBundle of the given object if the
+// reconciliation which started at the given time failed with the given
+// error. Failures to write the bundle are logged.
+func (d *ReconcileDiagnostics) Record(ctx context.Context, obj diagnosticObject, start time.Time, reconcileErr error) {
+	if d == nil || reconcileErr == nil {
+		return
+	}
+	kind := sourceKind(obj)
+	bundle := DiagnosticBundle{
+		Kind:       kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Generation: obj.GetGeneration(),
+		Conditions: obj.GetConditions(),
+		StartedAt:  start.UTC(),
+		Duration:   time.Since(start).Round(time.Millisecond).String(),
+		Errors:     errorChain(reconcileErr),
+	}
+	if src, ok := obj.(artifactSource); ok && src.GetArtifact() != nil {
+		bundle.Revision = src.GetArtifact().Revision
+	}
+	if u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err == nil {
+		if spec, ok := u["spec"].(map[string]interface{}); ok {
+			bundle.Spec = redactURLs(spec).(map[string]interface{})
+		}
+	}
+
+	if err := d.write(d.file(kind, bundle.Namespace, bundle.Name), bundle); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to record diagnostic bundle")
+	}
+}
+
+// Remove deletes the DiagnosticBundle of the given object, if any.
+func (d *ReconcileDiagnostics) Remove(obj client.Object) error {
+	if d == nil {
+		return nil
+	}
+	err := os.Remove(d.file(sourceKind(obj), obj.GetNamespace(), obj.GetName()))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// ServeHTTP serves the GET /diagnostics/{kind}/{namespace}/{name} endpoint
+// of the admin API, which returns the DiagnosticBundle of the last failed
+// reconciliation of a Source object as JSON.
+func (d *ReconcileDiagnostics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	obj, ok := newArtifactSource(r.PathValue("kind"))
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown source kind '%s'", r.PathValue("kind")), http.StatusNotFound)
+		return
+	}
+	namespace, name := r.PathValue("namespace"), r.PathValue("name")
+	if len(validation.IsDNS1123Label(namespace)) > 0 || len(validation.IsDNS1123Subdomain(name)) > 0 {
+		http.Error(w, fmt.Sprintf("invalid object reference '%s/%s'", namespace, name), http.StatusBadRequest)
+		return
+	}
+
+	b, err := os.ReadFile(d.file(sourceKind(obj), namespace, name))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, os.ErrNotExist) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// file returns the path of the DiagnosticBundle file of the object with the
+// given kind, namespace and name.
+func (d *ReconcileDiagnostics) file(kind, namespace, name string) string {
+	return filepath.Join(d.Path, strings.ToLower(kind), namespace, name+".json")
+}
+
+// write atomically writes the given bundle as JSON to the given file.
+func (d *ReconcileDiagnostics) write(file string, bundle DiagnosticBundle) error {
+	b, err := json.Marshal(bundle)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".bundle-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// errorChain returns the messages of the given error and of the errors it
+// wraps, from the outermost to the innermost. A message equal to the one of
+// the wrapping error, as for the serror types, is not repeated.
+func errorChain(err error) []string {
+	var chain []string
+	for ; err != nil; err = errors.Unwrap(err) {
+		if msg := err.Error(); len(chain) == 0 || chain[len(chain)-1] != msg {
+			chain = append(chain, msg)
+		}
+	}
+	return chain
+}
+
+// redactURLs returns the given unstructured value with the passwords of the
+// URLs in its string values redacted.
+func redactURLs(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			t[k] = redactURLs(e)
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = redactURLs(e)
+		}
+	case string:
+		if u, err := url.Parse(t); err == nil && u.User != nil {
+			return u.Redacted()
+		}
+	}
+	return v
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kuberecorder "k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fluxcd/pkg/runtime/conditions"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+// sloObject is a Source object on which the sourcev1.SLOViolatedCondition
+// can be set.
+type sloObject interface {
+	client.Object
+	conditions.Setter
+}
+
+// reconcileSLO sets the sourcev1.SLOViolatedCondition on the given object
+// when it has failed to fetch or build for longer than the
+// sourcev1.SLOMaxStalenessAnnotation, or when the reconciliation which took
+// the given duration exceeded the sourcev1.SLOMaxDurationAnnotation. The
+// condition is removed otherwise. A Warning event is emitted when the SLO
+// becomes violated, or is violated for another reason than before.
+func reconcileSLO(ctx context.Context, recorder kuberecorder.EventRecorder, obj sloObject, duration time.Duration) {
+	maxDuration := sloAnnotationDuration(ctx, obj, sourcev1.SLOMaxDurationAnnotation)
+	maxStaleness := sloAnnotationDuration(ctx, obj, sourcev1.SLOMaxStalenessAnnotation)
+
+	var reason, msg string
+	if since := failingSince(obj); maxStaleness > 0 && !since.IsZero() {
+		if staleness := time.Since(since); staleness > maxStaleness {
+			reason = sourcev1.SLOStalenessExceededReason
+			msg = fmt.Sprintf("failing since %s, exceeding the maximum staleness of %s",
+				since.UTC().Format(time.RFC3339), maxStaleness)
+		}
+	}
+	if reason == "" && maxDuration > 0 && duration > maxDuration {
+		reason = sourcev1.SLODurationExceededReason
+		msg = fmt.Sprintf("reconciliation took %s, exceeding the maximum duration of %s",
+			duration.Round(time.Millisecond), maxDuration)
+	}
+
+	if reason == "" {
+		conditions.Delete(obj, sourcev1.SLOViolatedCondition)
+		return
+	}
+	if !conditions.IsTrue(obj, sourcev1.SLOViolatedCondition) ||
+		conditions.GetReason(obj, sourcev1.SLOViolatedCondition) != reason {
+		recorder.Eventf(obj, corev1.EventTypeWarning, reason, "SLO violated: %s", msg)
+	}
+	conditions.MarkTrue(obj, sourcev1.SLOViolatedCondition, reason, "%s", msg)
+}
+
+// failingSince returns the time from which the given object has been
+// failing to fetch or build, or a zero time if it is not failing.
+func failingSince(obj conditions.Getter) time.Time {
+	var since time.Time
+	for _, t := range []string{sourcev1.FetchFailedCondition, sourcev1.BuildFailedCondition} {
+		if c := conditions.Get(obj, t); c != nil && c.Status == metav1.ConditionTrue {
+			if since.IsZero() || c.LastTransitionTime.Time.Before(since) {
+				since = c.LastTransitionTime.Time
+			}
+		}
+	}
+	return since
+}
+
+// sloAnnotationDuration returns the duration of the given annotation of the
+// object, or zero if it is not set or invalid.
+func sloAnnotationDuration(ctx context.Context, obj client.Object, annotation string) time.Duration {
+	v, ok := obj.GetAnnotations()[annotation]
+	if !ok {
+		return 0
+	}
+	d, err := time.ParseDuration(v)
+	if err == nil && d < 0 {
+		err = fmt.Errorf("duration must not be negative")
+	}
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "ignoring invalid SLO annotation", "annotation", annotation, "value", v)
+		return 0
+	}
+	return d
+}
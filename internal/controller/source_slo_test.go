@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/fluxcd/pkg/runtime/conditions"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func Test_reconcileSLO(t *testing.T) {
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		duration      time.Duration
+		failingFor    time.Duration
+		alreadyReason string
+		wantReason    string
+		wantEvent     bool
+	}{
+		{
+			name:     "no SLO",
+			duration: time.Hour,
+		},
+		{
+			name:        "within SLO",
+			annotations: map[string]string{sourcev1.SLOMaxDurationAnnotation: "1m", sourcev1.SLOMaxStalenessAnnotation: "1h"},
+			duration:    time.Second,
+			failingFor:  time.Minute,
+		},
+		{
+			name:        "duration exceeded",
+			annotations: map[string]string{sourcev1.SLOMaxDurationAnnotation: "1m"},
+			duration:    2 * time.Minute,
+			wantReason:  sourcev1.SLODurationExceededReason,
+			wantEvent:   true,
+		},
+		{
+			name:        "staleness exceeded",
+			annotations: map[string]string{sourcev1.SLOMaxDurationAnnotation: "1m", sourcev1.SLOMaxStalenessAnnotation: "1h"},
+			duration:    2 * time.Minute,
+			failingFor:  2 * time.Hour,
+			wantReason:  sourcev1.SLOStalenessExceededReason,
+			wantEvent:   true,
+		},
+		{
+			name:          "still violated",
+			annotations:   map[string]string{sourcev1.SLOMaxStalenessAnnotation: "1h"},
+			failingFor:    2 * time.Hour,
+			alreadyReason: sourcev1.SLOStalenessExceededReason,
+			wantReason:    sourcev1.SLOStalenessExceededReason,
+		},
+		{
+			name:          "recovered",
+			annotations:   map[string]string{sourcev1.SLOMaxStalenessAnnotation: "1h"},
+			alreadyReason: sourcev1.SLOStalenessExceededReason,
+		},
+		{
+			name:        "invalid annotation",
+			annotations: map[string]string{sourcev1.SLOMaxDurationAnnotation: "fast"},
+			duration:    time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := &sourcev1.GitRepository{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Namespace:   "default",
+					Annotations: tt.annotations,
+				},
+			}
+			if tt.failingFor > 0 {
+				obj.Status.Conditions = append(obj.Status.Conditions, metav1.Condition{
+					Type:               sourcev1.FetchFailedCondition,
+					Status:             metav1.ConditionTrue,
+					Reason:             sourcev1.AuthenticationFailedReason,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-tt.failingFor)),
+				})
+			}
+			if tt.alreadyReason != "" {
+				conditions.MarkTrue(obj, sourcev1.SLOViolatedCondition, tt.alreadyReason, "violated")
+			}
+			recorder := record.NewFakeRecorder(10)
+
+			reconcileSLO(context.TODO(), recorder, obj, tt.duration)
+
+			if tt.wantReason == "" {
+				g.Expect(conditions.Has(obj, sourcev1.SLOViolatedCondition)).To(BeFalse())
+			} else {
+				g.Expect(conditions.IsTrue(obj, sourcev1.SLOViolatedCondition)).To(BeTrue())
+				g.Expect(conditions.GetReason(obj, sourcev1.SLOViolatedCondition)).To(Equal(tt.wantReason))
+			}
+			if tt.wantEvent {
+				g.Expect(recorder.Events).To(Receive(ContainSubstring("Warning " + tt.wantReason + " SLO violated")))
+			} else {
+				g.Expect(recorder.Events).ToNot(Receive())
+			}
+		})
+	}
+}
@@ -0,0 +1,462 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	kuberecorder "k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/runtime/conditions"
+	helper "github.com/fluxcd/pkg/runtime/controller"
+	"github.com/fluxcd/pkg/runtime/patch"
+	"github.com/fluxcd/pkg/runtime/predicates"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	intjitter "github.com/fluxcd/source-controller/internal/jitter"
+)
+
+// sourceSetOwnedConditions are the conditions owned by the
+// SourceSetReconciler.
+var sourceSetOwnedConditions = []string{
+	meta.ReadyCondition,
+	meta.ReconcilingCondition,
+}
+
+// +kubebuilder:rbac:groups=source.toolkit.fluxcd.io,resources=sourcesets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=source.toolkit.fluxcd.io,resources=sourcesets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=source.toolkit.fluxcd.io,resources=sourcesets/finalizers,verbs=get;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// SourceSetReconciler reconciles a v1.SourceSet object, generating a
+// GitRepository or OCIRepository for each of its input sets.
+type SourceSetReconciler struct {
+	client.Client
+	kuberecorder.EventRecorder
+	helper.Metrics
+
+	// APIReader reads the input ConfigMaps and the generated sources, it
+	// should not be backed by a cache. Only the metadata of the ConfigMaps
+	// is watched, and the generated sources do not carry the labels of the
+	// SourceSet, and may thereby be filtered out of the cache by the watch
+	// label selector.
+	APIReader      client.Reader
+	ControllerName string
+
+	patchOptions []patch.Option
+}
+
+type SourceSetReconcilerOptions struct {
+	RateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+}
+
+func (r *SourceSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return r.SetupWithManagerAndOptions(mgr, SourceSetReconcilerOptions{})
+}
+
+func (r *SourceSetReconciler) SetupWithManagerAndOptions(mgr ctrl.Manager, opts SourceSetReconcilerOptions) error {
+	r.patchOptions = getPatchOptions(sourceSetOwnedConditions, r.ControllerName)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&sourcev1.SourceSet{}, builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicates.ReconcileRequestedPredicate{}),
+		)).
+		Owns(&sourcev1.GitRepository{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&sourcev1.OCIRepository{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForConfigMapChange),
+			builder.OnlyMetadata,
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		WithOptions(controller.Options{
+			RateLimiter: opts.RateLimiter,
+		}).
+		Complete(r)
+}
+
+func (r *SourceSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) {
+	start := time.Now()
+	log := ctrl.LoggerFrom(ctx)
+
+	// Fetch the SourceSet
+	obj := &sourcev1.SourceSet{}
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// The generated sources are garbage collected by Kubernetes through
+	// their owner reference.
+	if !obj.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Initialize the patch helper with the current version of the object.
+	serialPatcher := patch.NewSerialPatcher(obj, r.Client)
+
+	// Always attempt to patch the object and status after each
+	// reconciliation.
+	defer func() {
+		patchOpts := r.patchOptions
+		if retErr == nil {
+			patchOpts = append(patchOpts, patch.WithStatusObservedGeneration{})
+		}
+		if err := serialPatcher.Patch(ctx, obj, patchOpts...); err != nil {
+			retErr = kerrors.NewAggregate([]error{retErr, err})
+		}
+
+		// Always record duration metrics.
+		r.Metrics.RecordDuration(ctx, obj, start)
+	}()
+
+	if v, ok := meta.ReconcileAnnotationValue(obj.GetAnnotations()); ok {
+		obj.Status.SetLastHandledReconcileRequest(v)
+	}
+
+	// Return if the object is suspended.
+	if obj.Spec.Suspend {
+		log.Info("reconciliation is suspended for this object")
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.reconcile(ctx, obj); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{
+		RequeueAfter: intjitter.JitteredIntervalDuration(sourcev1.SourceSetKind, obj.GetRequeueAfter()),
+	}, nil
+}
+
+// requestsForConfigMapChange returns the requests for the SourceSets in the
+// namespace of the given ConfigMap whose inputs selector matches it.
+func (r *SourceSetReconciler) requestsForConfigMapChange(ctx context.Context, o client.Object) []reconcile.Request {
+	var list sourcev1.SourceSetList
+	if err := r.List(ctx, &list, client.InNamespace(o.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to list SourceSets for ConfigMap change")
+		return nil
+	}
+
+	var reqs []reconcile.Request
+	for i, v := range list.Items {
+		if v.Spec.InputsFrom == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(v.Spec.InputsFrom)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(o.GetLabels())) {
+			reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+		}
+	}
+	return reqs
+}
+
+// reconcile renders the template of the given SourceSet for each of its
+// input sets, applies the resulting sources, and deletes the sources it
+// generated previously which are no longer part of the set.
+func (r *SourceSetReconciler) reconcile(ctx context.Context, obj *sourcev1.SourceSet) error {
+	conditions.MarkReconciling(obj, meta.ProgressingReason, "reconciliation in progress")
+
+	inputs, err := r.inputs(ctx, obj)
+	if err != nil {
+		return r.fail(obj, sourcev1.InputsFailedReason, err)
+	}
+
+	sources := make([]client.Object, 0, len(inputs))
+	names := make(map[string]struct{}, len(inputs))
+	for _, input := range inputs {
+		src, err := buildSourceSetSource(obj, input)
+		if err != nil {
+			return r.fail(obj, sourcev1.TemplateFailedReason, err)
+		}
+		if _, ok := names[src.GetName()]; ok {
+			err := fmt.Errorf("template renders the duplicate name '%s'", src.GetName())
+			return r.fail(obj, sourcev1.TemplateFailedReason, err)
+		}
+		names[src.GetName()] = struct{}{}
+		sources = append(sources, src)
+	}
+
+	inventory := make([]sourcev1.SourceSetInventoryEntry, 0, len(sources))
+	for _, src := range sources {
+		if err := r.apply(ctx, obj, src); err != nil {
+			return r.fail(obj, sourcev1.ApplyFailedReason, err)
+		}
+		inventory = append(inventory, sourcev1.SourceSetInventoryEntry{
+			Kind: obj.GetTemplateKind(),
+			Name: src.GetName(),
+		})
+	}
+	obj.Status.Inventory = inventory
+
+	if err := r.garbageCollect(ctx, obj, names); err != nil {
+		return r.fail(obj, sourcev1.ApplyFailedReason, err)
+	}
+
+	conditions.Delete(obj, meta.ReconcilingCondition)
+	conditions.MarkTrue(obj, meta.ReadyCondition, meta.SucceededReason,
+		"generated %d %s object(s)", len(sources), obj.GetTemplateKind())
+	return nil
+}
+
+// fail marks the given SourceSet as not ready with the given reason and
+// error, records a warning event, and returns the error.
+func (r *SourceSetReconciler) fail(obj *sourcev1.SourceSet, reason string, err error) error {
+	conditions.MarkFalse(obj, meta.ReadyCondition, reason, "%s", err)
+	r.Event(obj, corev1.EventTypeWarning, reason, err.Error())
+	return err
+}
+
+// inputs returns the input sets of the given SourceSet: the inputs from its
+// spec, followed by the data of the selected ConfigMaps ordered by name.
+func (r *SourceSetReconciler) inputs(ctx context.Context, obj *sourcev1.SourceSet) ([]sourcev1.SourceSetInput, error) {
+	inputs := append([]sourcev1.SourceSetInput{}, obj.Spec.Inputs...)
+	if obj.Spec.InputsFrom == nil {
+		return inputs, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(obj.Spec.InputsFrom)
+	if err != nil {
+		return nil, fmt.Errorf("invalid inputs selector: %w", err)
+	}
+	var cms corev1.ConfigMapList
+	if err := r.APIReader.List(ctx, &cms, client.InNamespace(obj.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list input ConfigMaps: %w", err)
+	}
+	sort.Slice(cms.Items, func(i, j int) bool {
+		return cms.Items[i].Name < cms.Items[j].Name
+	})
+	for _, cm := range cms.Items {
+		inputs = append(inputs, cm.Data)
+	}
+	return inputs, nil
+}
+
+// apply creates or updates the given source, refusing to take over a source
+// which is not controlled by the SourceSet.
+func (r *SourceSetReconciler) apply(ctx context.Context, obj *sourcev1.SourceSet, src client.Object) error {
+	existing := src.DeepCopyObject().(client.Object)
+	c := apiReaderClient{Client: r.Client, reader: r.APIReader}
+	_, err := controllerutil.CreateOrUpdate(ctx, c, existing, func() error {
+		if existing.GetResourceVersion() != "" && !metav1.IsControlledBy(existing, obj) {
+			return fmt.Errorf("%s '%s' already exists and is not managed by the SourceSet",
+				obj.GetTemplateKind(), existing.GetName())
+		}
+		existing.SetLabels(mergeStringMaps(existing.GetLabels(), src.GetLabels()))
+		existing.SetAnnotations(mergeStringMaps(existing.GetAnnotations(), src.GetAnnotations()))
+		switch e := existing.(type) {
+		case *sourcev1.GitRepository:
+			e.Spec = src.(*sourcev1.GitRepository).Spec
+		case *sourcev1.OCIRepository:
+			e.Spec = src.(*sourcev1.OCIRepository).Spec
+		}
+		return controllerutil.SetControllerReference(obj, existing, r.Scheme())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply %s '%s': %w", obj.GetTemplateKind(), src.GetName(), err)
+	}
+	return nil
+}
+
+// garbageCollect deletes the sources controlled by the given SourceSet which
+// are not in the given set of names, or of another kind than its template.
+func (r *SourceSetReconciler) garbageCollect(ctx context.Context, obj *sourcev1.SourceSet, names map[string]struct{}) error {
+	opts := []client.ListOption{
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingLabels{sourcev1.SourceSetNameLabel: obj.GetName()},
+	}
+
+	var stale []client.Object
+	var gitRepos sourcev1.GitRepositoryList
+	if err := r.APIReader.List(ctx, &gitRepos, opts...); err != nil {
+		return fmt.Errorf("failed to list generated sources: %w", err)
+	}
+	for i := range gitRepos.Items {
+		stale = append(stale, &gitRepos.Items[i])
+	}
+	var ociRepos sourcev1.OCIRepositoryList
+	if err := r.APIReader.List(ctx, &ociRepos, opts...); err != nil {
+		return fmt.Errorf("failed to list generated sources: %w", err)
+	}
+	for i := range ociRepos.Items {
+		stale = append(stale, &ociRepos.Items[i])
+	}
+
+	var errs []error
+	for _, src := range stale {
+		if !metav1.IsControlledBy(src, obj) {
+			continue
+		}
+		kind := sourcev1.GitRepositoryKind
+		if _, ok := src.(*sourcev1.OCIRepository); ok {
+			kind = sourcev1.OCIRepositoryKind
+		}
+		if _, ok := names[src.GetName()]; ok && kind == obj.GetTemplateKind() {
+			continue
+		}
+		if err := r.Delete(ctx, src); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s '%s': %w", kind, src.GetName(), err))
+			continue
+		}
+		ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("deleted %s '%s'", kind, src.GetName()))
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// apiReaderClient is a client.Client which reads objects with the given
+// reader instead of the embedded client.Client.
+type apiReaderClient struct {
+	client.Client
+	reader client.Reader
+}
+
+func (c apiReaderClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+func (c apiReaderClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+// buildSourceSetSource returns the source generated by the template of the
+// given SourceSet for the given input set.
+func buildSourceSetSource(obj *sourcev1.SourceSet, input sourcev1.SourceSetInput) (client.Object, error) {
+	tmpl, err := renderSourceSetTemplate(obj.Spec.Template, input)
+	if err != nil {
+		return nil, err
+	}
+
+	objMeta := metav1.ObjectMeta{
+		Name:        tmpl.Metadata.Name,
+		Namespace:   obj.GetNamespace(),
+		Labels:      mergeStringMaps(tmpl.Metadata.Labels, map[string]string{sourcev1.SourceSetNameLabel: obj.GetName()}),
+		Annotations: tmpl.Metadata.Annotations,
+	}
+	switch {
+	case tmpl.GitRepository != nil:
+		return &sourcev1.GitRepository{ObjectMeta: objMeta, Spec: *tmpl.GitRepository}, nil
+	case tmpl.OCIRepository != nil:
+		return &sourcev1.OCIRepository{ObjectMeta: objMeta, Spec: *tmpl.OCIRepository}, nil
+	default:
+		return nil, fmt.Errorf("template has no GitRepository or OCIRepository spec")
+	}
+}
+
+// renderSourceSetTemplate replaces the placeholders in all the string values
+// of the given template with the values of the given input set. Referring to
+// a key which is not in the input set is an error.
+func renderSourceSetTemplate(tmpl sourcev1.SourceSetTemplate, input sourcev1.SourceSetInput) (*sourcev1.SourceSetTemplate, error) {
+	b, err := json.Marshal(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	if v, err = renderTemplateValue(v, input); err != nil {
+		return nil, err
+	}
+	if b, err = json.Marshal(v); err != nil {
+		return nil, err
+	}
+	var out sourcev1.SourceSetTemplate
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("invalid rendered template: %w", err)
+	}
+	return &out, nil
+}
+
+// renderTemplateValue recursively renders the string values of the given
+// decoded JSON value.
+func renderTemplateValue(v any, input sourcev1.SourceSetInput) (any, error) {
+	switch t := v.(type) {
+	case string:
+		if !strings.Contains(t, "{{") {
+			return t, nil
+		}
+		tpl, err := template.New("").Option("missingkey=error").Parse(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template '%s': %w", t, err)
+		}
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, map[string]string(input)); err != nil {
+			return nil, fmt.Errorf("failed to render template '%s': %w", t, err)
+		}
+		return buf.String(), nil
+	case map[string]any:
+		for k, e := range t {
+			r, err := renderTemplateValue(e, input)
+			if err != nil {
+				return nil, err
+			}
+			t[k] = r
+		}
+		return t, nil
+	case []any:
+		for i, e := range t {
+			r, err := renderTemplateValue(e, input)
+			if err != nil {
+				return nil, err
+			}
+			t[i] = r
+		}
+		return t, nil
+	default:
+		return v, nil
+	}
+}
+
+// mergeStringMaps returns a new map with the entries of a, overwritten by
+// the entries of b.
+func mergeStringMaps(a, b map[string]string) map[string]string {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(a)+len(b))
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, v := range b {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/runtime/conditions"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestSourceSetReconciler_Reconcile(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := &sourcev1.SourceSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "apps",
+			Namespace:  "default",
+			UID:        types.UID("apps-uid"),
+			Generation: 1,
+		},
+		Spec: sourcev1.SourceSetSpec{
+			Interval: metav1.Duration{Duration: time.Minute},
+			Inputs: []sourcev1.SourceSetInput{
+				{"name": "frontend", "tag": "v1.0.0"},
+			},
+			InputsFrom: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"sourceset": "apps"},
+			},
+			Template: sourcev1.SourceSetTemplate{
+				Metadata: sourcev1.SourceSetTemplateMetadata{
+					Name:   "app-{{ .name }}",
+					Labels: map[string]string{"app": "{{ .name }}"},
+				},
+				OCIRepository: &sourcev1.OCIRepositorySpec{
+					URL:       "oci://ghcr.io/org/{{ .name }}",
+					Reference: &sourcev1.OCIRepositoryRef{Tag: "{{ .tag }}"},
+					Interval:  metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+		},
+	}
+	input := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backend",
+			Namespace: "default",
+			Labels:    map[string]string{"sourceset": "apps"},
+		},
+		Data: map[string]string{"name": "backend", "tag": "v2.0.0"},
+	}
+	stale := &sourcev1.OCIRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-removed",
+			Namespace: "default",
+			Labels:    map[string]string{sourcev1.SourceSetNameLabel: "apps"},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: sourcev1.GroupVersion.String(),
+				Kind:       sourcev1.SourceSetKind,
+				Name:       "apps",
+				UID:        obj.UID,
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+	unmanaged := &sourcev1.OCIRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-unmanaged",
+			Namespace: "default",
+			Labels:    map[string]string{sourcev1.SourceSetNameLabel: "apps"},
+		},
+	}
+
+	c := fakeclient.NewClientBuilder().
+		WithScheme(testEnv.GetScheme()).
+		WithObjects(obj, input, stale, unmanaged).
+		WithStatusSubresource(&sourcev1.SourceSet{}).
+		Build()
+	r := &SourceSetReconciler{
+		Client:        c,
+		EventRecorder: record.NewFakeRecorder(32),
+		Metrics:       testMetricsH,
+		APIReader:     c,
+		patchOptions:  getPatchOptions(sourceSetOwnedConditions, "sc"),
+	}
+
+	got, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got.RequeueAfter).To(BeNumerically(">", 0))
+
+	for name, tag := range map[string]string{"frontend": "v1.0.0", "backend": "v2.0.0"} {
+		repo := &sourcev1.OCIRepository{}
+		g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "app-" + name}, repo)).To(Succeed())
+		g.Expect(repo.Spec.URL).To(Equal("oci://ghcr.io/org/" + name))
+		g.Expect(repo.Spec.Reference.Tag).To(Equal(tag))
+		g.Expect(repo.Labels).To(HaveKeyWithValue("app", name))
+		g.Expect(repo.Labels).To(HaveKeyWithValue(sourcev1.SourceSetNameLabel, "apps"))
+		g.Expect(metav1.IsControlledBy(repo, obj)).To(BeTrue())
+	}
+
+	err = c.Get(context.TODO(), client.ObjectKeyFromObject(stale), &sourcev1.OCIRepository{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(unmanaged), &sourcev1.OCIRepository{})).To(Succeed())
+
+	result := &sourcev1.SourceSet{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(obj), result)).To(Succeed())
+	g.Expect(conditions.IsReady(result)).To(BeTrue())
+	g.Expect(conditions.Has(result, meta.ReconcilingCondition)).To(BeFalse())
+	g.Expect(result.Status.ObservedGeneration).To(Equal(int64(1)))
+	g.Expect(result.Status.Inventory).To(ConsistOf(
+		sourcev1.SourceSetInventoryEntry{Kind: sourcev1.OCIRepositoryKind, Name: "app-frontend"},
+		sourcev1.SourceSetInventoryEntry{Kind: sourcev1.OCIRepositoryKind, Name: "app-backend"},
+	))
+}
+
+func TestSourceSetReconciler_ReconcileUnmanagedConflict(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := &sourcev1.SourceSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "default", UID: types.UID("apps-uid")},
+		Spec: sourcev1.SourceSetSpec{
+			Interval: metav1.Duration{Duration: time.Minute},
+			Inputs:   []sourcev1.SourceSetInput{{"name": "frontend"}},
+			Template: sourcev1.SourceSetTemplate{
+				Metadata: sourcev1.SourceSetTemplateMetadata{Name: "{{ .name }}"},
+				GitRepository: &sourcev1.GitRepositorySpec{
+					URL:      "https://github.com/org/{{ .name }}",
+					Interval: metav1.Duration{Duration: time.Minute},
+				},
+			},
+		},
+	}
+	existing := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "default"},
+		Spec:       sourcev1.GitRepositorySpec{URL: "https://github.com/other/frontend"},
+	}
+
+	c := fakeclient.NewClientBuilder().
+		WithScheme(testEnv.GetScheme()).
+		WithObjects(obj, existing).
+		WithStatusSubresource(&sourcev1.SourceSet{}).
+		Build()
+	r := &SourceSetReconciler{
+		Client:        c,
+		EventRecorder: record.NewFakeRecorder(32),
+		Metrics:       testMetricsH,
+		APIReader:     c,
+		patchOptions:  getPatchOptions(sourceSetOwnedConditions, "sc"),
+	}
+
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("not managed by the SourceSet"))
+
+	repo := &sourcev1.GitRepository{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(existing), repo)).To(Succeed())
+	g.Expect(repo.Spec.URL).To(Equal("https://github.com/other/frontend"))
+
+	result := &sourcev1.SourceSet{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(obj), result)).To(Succeed())
+	g.Expect(conditions.IsReady(result)).To(BeFalse())
+	g.Expect(conditions.GetReason(result, meta.ReadyCondition)).To(Equal(sourcev1.ApplyFailedReason))
+}
+
+func TestSourceSetReconciler_ReconcileUncached(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := &sourcev1.SourceSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "default", UID: types.UID("apps-uid")},
+		Spec: sourcev1.SourceSetSpec{
+			Interval: metav1.Duration{Duration: time.Minute},
+			Inputs:   []sourcev1.SourceSetInput{{"name": "frontend"}},
+			Template: sourcev1.SourceSetTemplate{
+				Metadata: sourcev1.SourceSetTemplateMetadata{Name: "{{ .name }}"},
+				GitRepository: &sourcev1.GitRepositorySpec{
+					URL:      "https://github.com/org/{{ .name }}",
+					Interval: metav1.Duration{Duration: time.Minute},
+				},
+			},
+		},
+	}
+	stale := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "removed",
+			Namespace: "default",
+			Labels:    map[string]string{sourcev1.SourceSetNameLabel: "apps"},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: sourcev1.GroupVersion.String(),
+				Kind:       sourcev1.SourceSetKind,
+				Name:       "apps",
+				UID:        obj.UID,
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+
+	c := fakeclient.NewClientBuilder().
+		WithScheme(testEnv.GetScheme()).
+		WithObjects(obj, stale).
+		WithStatusSubresource(&sourcev1.SourceSet{}).
+		Build()
+	// The generated sources are filtered out of the cache by the watch
+	// label selector.
+	cached := interceptor.NewClient(c, interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*sourcev1.GitRepository); ok {
+				return apierrors.NewNotFound(sourcev1.GroupVersion.WithResource("gitrepositories").GroupResource(), key.Name)
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*sourcev1.GitRepositoryList); ok {
+				return nil
+			}
+			return c.List(ctx, list, opts...)
+		},
+	})
+	r := &SourceSetReconciler{
+		Client:        cached,
+		EventRecorder: record.NewFakeRecorder(32),
+		Metrics:       testMetricsH,
+		APIReader:     c,
+		patchOptions:  getPatchOptions(sourceSetOwnedConditions, "sc"),
+	}
+
+	for range 2 {
+		_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "frontend"}, &sourcev1.GitRepository{})).To(Succeed())
+	err := c.Get(context.TODO(), client.ObjectKeyFromObject(stale), &sourcev1.GitRepository{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestSourceSetReconciler_requestsForConfigMapChange(t *testing.T) {
+	g := NewWithT(t)
+
+	selecting := &sourcev1.SourceSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "default"},
+		Spec: sourcev1.SourceSetSpec{
+			InputsFrom: &metav1.LabelSelector{MatchLabels: map[string]string{"sourceset": "apps"}},
+		},
+	}
+	other := &sourcev1.SourceSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+		Spec: sourcev1.SourceSetSpec{
+			InputsFrom: &metav1.LabelSelector{MatchLabels: map[string]string{"sourceset": "other"}},
+		},
+	}
+	static := &sourcev1.SourceSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "static", Namespace: "default"},
+	}
+	otherNamespace := &sourcev1.SourceSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "other"},
+		Spec: sourcev1.SourceSetSpec{
+			InputsFrom: &metav1.LabelSelector{MatchLabels: map[string]string{"sourceset": "apps"}},
+		},
+	}
+
+	c := fakeclient.NewClientBuilder().
+		WithScheme(testEnv.GetScheme()).
+		WithObjects(selecting, other, static, otherNamespace).
+		Build()
+	r := &SourceSetReconciler{Client: c}
+
+	cm := &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backend",
+			Namespace: "default",
+			Labels:    map[string]string{"sourceset": "apps"},
+		},
+	}
+	g.Expect(r.requestsForConfigMapChange(context.TODO(), cm)).To(ConsistOf(
+		ctrl.Request{NamespacedName: client.ObjectKeyFromObject(selecting)},
+	))
+}
+
+func TestRenderSourceSetTemplate(t *testing.T) {
+	tmpl := sourcev1.SourceSetTemplate{
+		Metadata: sourcev1.SourceSetTemplateMetadata{
+			Name:        "{{ .name }}",
+			Annotations: map[string]string{"team": "{{ .team }}"},
+		},
+		GitRepository: &sourcev1.GitRepositorySpec{
+			URL:       "https://github.com/org/{{ .name }}",
+			Reference: &sourcev1.GitRepositoryRef{Branch: "main"},
+			Interval:  metav1.Duration{Duration: time.Minute},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		input   sourcev1.SourceSetInput
+		wantErr string
+	}{
+		{
+			name:  "renders all string values",
+			input: sourcev1.SourceSetInput{"name": "podinfo", "team": "dev"},
+		},
+		{
+			name:    "fails on missing key",
+			input:   sourcev1.SourceSetInput{"name": "podinfo"},
+			wantErr: "map has no entry for key \"team\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := renderSourceSetTemplate(tmpl, tt.input)
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.Metadata.Name).To(Equal("podinfo"))
+			g.Expect(got.Metadata.Annotations).To(HaveKeyWithValue("team", "dev"))
+			g.Expect(got.GitRepository.URL).To(Equal("https://github.com/org/podinfo"))
+			g.Expect(got.GitRepository.Reference.Branch).To(Equal("main"))
+			g.Expect(got.GitRepository.Interval.Duration).To(Equal(time.Minute))
+			// The template itself is left untouched.
+			g.Expect(tmpl.GitRepository.URL).To(Equal("https://github.com/org/{{ .name }}"))
+		})
+	}
+}
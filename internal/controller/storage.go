@@ -28,6 +28,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	securejoin "github.com/cyphar/filepath-securejoin"
@@ -36,17 +37,24 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
+	"github.com/fluxcd/pkg/apis/meta"
 	"github.com/fluxcd/pkg/lockedfile"
 	"github.com/fluxcd/pkg/sourceignore"
 	pkgtar "github.com/fluxcd/pkg/tar"
 
 	v1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/fluxcd/source-controller/internal/cdn"
 	intdigest "github.com/fluxcd/source-controller/internal/digest"
+	serror "github.com/fluxcd/source-controller/internal/error"
 	sourcefs "github.com/fluxcd/source-controller/internal/fs"
 )
 
 const GarbageCountLimit = 1000
 
+// FilesystemBackend is the type of the storage backend which persists the
+// artifacts on the local file system.
+const FilesystemBackend = "filesystem"
+
 const (
 	// defaultFileMode is the permission mode applied to files inside an artifact archive.
 	defaultFileMode int64 = 0o600
@@ -71,6 +79,106 @@ type Storage struct {
 	// ArtifactRetentionRecords is the maximum number of artifacts to be kept in
 	// storage after a garbage collection.
 	ArtifactRetentionRecords int `json:"artifactRetentionRecords"`
+
+	// ArtifactRetentionMaxTTL is the maximum ArtifactRetentionTTL a Source
+	// object can set in its spec.artifact.retention. A value of 0 disables
+	// the limit.
+	ArtifactRetentionMaxTTL time.Duration `json:"artifactRetentionMaxTTL,omitempty"`
+
+	// ArtifactRetentionMaxRecords is the maximum ArtifactRetentionRecords a
+	// Source object can set in its spec.artifact.retention. A value of 0
+	// disables the limit.
+	ArtifactRetentionMaxRecords int `json:"artifactRetentionMaxRecords,omitempty"`
+
+	// ArtifactRetentionMaxSize is the maximum total size in bytes of the
+	// artifacts kept in storage for an object after a garbage collection.
+	// The oldest artifacts are removed until the size fits, the current and
+	// pinned artifacts are always kept. A value of 0 disables the limit.
+	ArtifactRetentionMaxSize int64 `json:"artifactRetentionMaxSize,omitempty"`
+
+	// DeferGarbageCollection leaves the garbage collection of the artifacts
+	// of the objects which are not being deleted to the StorageJanitor,
+	// instead of running it at the end of every reconciliation.
+	DeferGarbageCollection bool `json:"deferGarbageCollection,omitempty"`
+
+	// VirtualHosts maps namespaces to the file server host names used to
+	// compose the URIs of their artifacts instead of Hostname.
+	VirtualHosts map[string]string `json:"virtualHosts,omitempty"`
+
+	// HTTPSOnly composes the artifacts URIs with the https scheme for host
+	// names without a scheme. Host names with the http scheme are refused by
+	// ValidateHTTPSOnly.
+	HTTPSOnly bool `json:"httpsOnly,omitempty"`
+
+	// CDN composes the artifacts URIs from the URL of a CDN in front of the
+	// file server instead of the host names above, when set.
+	CDN *cdn.URLRewriter `json:"-"`
+
+	// Purger invalidates the cached copies of replaced and garbage collected
+	// artifacts, when set.
+	Purger *cdn.Purger `json:"-"`
+
+	// Metrics records the duration and failures of the operations, and the
+	// bytes written and read, when set.
+	Metrics *StorageMetrics `json:"-"`
+
+	// ReadBackTimeout is the maximum duration for which a newly stored
+	// artifact is attempted to be read back from its URL before it is
+	// advertised, see VerifyReadBack. A value of 0 disables the read-back.
+	ReadBackTimeout time.Duration `json:"-"`
+
+	// Backpressure slows down the retries of objects for which a Storage
+	// operation failed while the Storage is unavailable, when set. See
+	// Backoff.
+	Backpressure *StorageBackpressure `json:"-"`
+
+	// Maintenance holds the maintenance mode of the controller, when set.
+	// While enabled, the Source objects are not reconciled, and the Storage
+	// is not garbage collected.
+	Maintenance *Maintenance `json:"-"`
+
+	// Immutable refuses to replace an artifact file with a different
+	// content, and stores the new content at a path qualified with its
+	// digest instead. This guarantees the content of an advertised URL
+	// never changes.
+	Immutable bool `json:"-"`
+
+	// CompressionLevel is the gzip compression level of the archives
+	// created by Archive, from gzip.NoCompression to gzip.BestCompression,
+	// or gzip.DefaultCompression. See ValidateCompressionLevel.
+	CompressionLevel int `json:"compressionLevel"`
+
+	// Deduplicate stores the content of the artifacts once in the
+	// ContentDir, and hard links the artifact files to it. The content no
+	// artifact links to anymore is removed by CollectContent.
+	Deduplicate bool `json:"deduplicate,omitempty"`
+
+	// QuarantineUnverified stores the content of the Sources which failed
+	// verification in the quarantine area of the Storage for inspection,
+	// see QuarantineArtifact.
+	QuarantineUnverified bool `json:"quarantineUnverified,omitempty"`
+
+	// SuspendedRetention is the retention policy of the Artifacts of the
+	// suspended Sources. The Artifacts are pruned when empty.
+	SuspendedRetention SuspendedRetention `json:"suspendedRetention,omitempty"`
+
+	// Fence refuses the write operations of a replica which does not hold
+	// the fencing token of a Storage shared by multiple replicas, when set.
+	Fence *StorageFence `json:"-"`
+
+	// Locker locks the artifacts instead of lock files, when set, e.g. with
+	// ArtifactLeases. See Lock. The writes of an artifact whose lock has been
+	// lost are refused.
+	Locker ArtifactLocker `json:"-"`
+
+	// Consumers protects the artifacts in use by the consumers of the
+	// Sources from garbage collection, when set.
+	Consumers *ArtifactConsumers `json:"-"`
+
+	// advertisedHostname overrides Hostname once set by
+	// SetAdvertisedHostname, allowing it to be updated while the Storage is
+	// in use.
+	advertisedHostname *atomic.Pointer[string]
 }
 
 // NewStorage creates the storage helper for a given path and hostname.
@@ -83,9 +191,68 @@ func NewStorage(basePath string, hostname string, artifactRetentionTTL time.Dura
 		Hostname:                 hostname,
 		ArtifactRetentionTTL:     artifactRetentionTTL,
 		ArtifactRetentionRecords: artifactRetentionRecords,
+		CompressionLevel:         gzip.DefaultCompression,
+		advertisedHostname:       &atomic.Pointer[string]{},
 	}, nil
 }
 
+// ValidateCompressionLevel returns an error if the given level is not a
+// valid Storage.CompressionLevel.
+func ValidateCompressionLevel(level int) error {
+	if level < gzip.DefaultCompression || level > gzip.BestCompression {
+		return fmt.Errorf("invalid compression level %d: must be between %d and %d, or %d for the default level",
+			level, gzip.NoCompression, gzip.BestCompression, gzip.DefaultCompression)
+	}
+	return nil
+}
+
+// SetAdvertisedHostname replaces the host name used to compose the artifacts
+// URIs, keeping the scheme of Hostname. It is safe to call while the Storage
+// is in use.
+func (s Storage) SetAdvertisedHostname(hostname string) {
+	if s.advertisedHostname == nil {
+		return
+	}
+	for _, scheme := range []string{"http://", "https://"} {
+		if strings.HasPrefix(s.Hostname, scheme) {
+			hostname = scheme + hostname
+			break
+		}
+	}
+	s.advertisedHostname.Store(&hostname)
+}
+
+// AdvertisedHostname returns the host name used to compose the artifacts
+// URIs.
+func (s Storage) AdvertisedHostname() string {
+	if s.advertisedHostname != nil {
+		if h := s.advertisedHostname.Load(); h != nil {
+			return *h
+		}
+	}
+	return s.Hostname
+}
+
+// Backend returns the type of the storage backend.
+func (s Storage) Backend() string {
+	return FilesystemBackend
+}
+
+// Healthy returns an error if a file can not be written to the base path of
+// the Storage.
+func (s Storage) Healthy() error {
+	f, err := os.CreateTemp(s.BasePath, ".healthz-")
+	if err != nil {
+		return fmt.Errorf("storage path '%s' is not writable: %w", s.BasePath, err)
+	}
+	name := f.Name()
+	if err := f.Close(); err != nil {
+		_ = os.Remove(name)
+		return fmt.Errorf("storage path '%s' is not writable: %w", s.BasePath, err)
+	}
+	return os.Remove(name)
+}
+
 // NewArtifactFor returns a new v1.Artifact.
 func (s Storage) NewArtifactFor(kind string, metadata metav1.Object, revision, fileName string) v1.Artifact {
 	path := v1.ArtifactPath(kind, metadata.GetNamespace(), metadata.GetName(), fileName)
@@ -102,48 +269,146 @@ func (s Storage) SetArtifactURL(artifact *v1.Artifact) {
 	if artifact.Path == "" {
 		return
 	}
-	format := "http://%s/%s"
-	if strings.HasPrefix(s.Hostname, "http://") || strings.HasPrefix(s.Hostname, "https://") {
-		format = "%s/%s"
+	if s.CDN != nil {
+		if u, err := s.CDN.URL(artifact.Path); err == nil {
+			artifact.URL = u
+			return
+		}
 	}
-	artifact.URL = fmt.Sprintf(format, s.Hostname, strings.TrimLeft(artifact.Path, "/"))
+	artifact.URL = fmt.Sprintf("%s/%s", s.baseURL(artifact.Path), strings.TrimLeft(artifact.Path, "/"))
 }
 
-// SetHostname sets the hostname of the given URL string to the current Storage.Hostname and returns the result.
+// SetHostname sets the scheme and hostname of the given URL string to the ones of the current Storage.Hostname,
+// or of the virtual host of the namespace of the URL path, and returns the result.
+// If a CDN is configured, the URL of the path on the CDN is returned instead.
 func (s Storage) SetHostname(URL string) string {
 	u, err := url.Parse(URL)
 	if err != nil {
 		return ""
 	}
-	u.Host = s.Hostname
+	if s.CDN != nil {
+		if cdnURL, err := s.CDN.URL(u.Path); err == nil {
+			return cdnURL
+		}
+	}
+	base, err := url.Parse(s.baseURL(u.Path))
+	if err != nil {
+		return ""
+	}
+	u.Scheme, u.Host = base.Scheme, base.Host
 	return u.String()
 }
 
+// baseURL returns the scheme and host of the file server for the given
+// artifact path. The virtual host of the artifact namespace takes precedence
+// over Hostname, and inherits the scheme of Hostname if it has none.
+func (s Storage) baseURL(artifactPath string) string {
+	hostname := s.AdvertisedHostname()
+	if vh, ok := s.VirtualHosts[artifactPathNamespace(artifactPath)]; ok {
+		hostname = vh
+	}
+	if strings.HasPrefix(hostname, "http://") || strings.HasPrefix(hostname, "https://") {
+		return hostname
+	}
+	if s.HTTPSOnly || strings.HasPrefix(s.Hostname, "https://") {
+		return "https://" + hostname
+	}
+	return "http://" + hostname
+}
+
+// ValidateHTTPSOnly returns an error if HTTPSOnly is set and Hostname, any
+// of the VirtualHosts or the CDN would compose artifact URIs with the http
+// scheme.
+func (s Storage) ValidateHTTPSOnly() error {
+	if !s.HTTPSOnly {
+		return nil
+	}
+	var errs []error
+	if strings.HasPrefix(s.Hostname, "http://") {
+		errs = append(errs, fmt.Errorf("hostname '%s' is not HTTPS", s.Hostname))
+	}
+	for namespace, vh := range s.VirtualHosts {
+		if strings.HasPrefix(vh, "http://") {
+			errs = append(errs, fmt.Errorf("virtual host '%s' of namespace '%s' is not HTTPS", vh, namespace))
+		}
+	}
+	if s.CDN != nil && s.CDN.Scheme() != "https" {
+		errs = append(errs, fmt.Errorf("CDN URL scheme '%s' is not HTTPS", s.CDN.Scheme()))
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// purge queues the URLs of the given artifact paths to be purged from the
+// CDN cache, if a Purger is configured.
+func (s Storage) purge(artifactPaths ...string) {
+	if s.Purger == nil || len(artifactPaths) == 0 {
+		return
+	}
+	urls := make([]string, 0, len(artifactPaths))
+	for _, p := range artifactPaths {
+		p = strings.TrimLeft(filepath.ToSlash(p), "/")
+		if s.CDN != nil {
+			urls = append(urls, s.CDN.UnsignedURL(p))
+			continue
+		}
+		urls = append(urls, fmt.Sprintf("%s/%s", s.baseURL(p), p))
+	}
+	s.Purger.Purge(urls...)
+}
+
+// artifactPathNamespace returns the namespace of an artifact path in the
+// format of v1.ArtifactPath, or an empty string.
+func artifactPathNamespace(artifactPath string) string {
+	parts := strings.Split(strings.TrimLeft(artifactPath, "/"), "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[1]
+}
+
 // MkdirAll calls os.MkdirAll for the given v1.Artifact base dir.
 func (s Storage) MkdirAll(artifact v1.Artifact) error {
+	if err := s.Fence.Check(); err != nil {
+		return err
+	}
 	dir := filepath.Dir(s.LocalPath(artifact))
 	return os.MkdirAll(dir, 0o700)
 }
 
 // Remove calls os.Remove for the given v1.Artifact path.
-func (s Storage) Remove(artifact v1.Artifact) error {
+func (s Storage) Remove(artifact v1.Artifact) (err error) {
+	defer s.Metrics.observe(s.Backend(), StorageOperationDelete, time.Now(), &err)
+	if err := s.Fence.Check(); err != nil {
+		return err
+	}
 	return os.Remove(s.LocalPath(artifact))
 }
 
-// RemoveAll calls os.RemoveAll for the given v1.Artifact base dir.
-func (s Storage) RemoveAll(artifact v1.Artifact) (string, error) {
+// RemoveAll calls os.RemoveAll for the given v1.Artifact base dir, and
+// removes the quarantined content of the object, if any.
+func (s Storage) RemoveAll(artifact v1.Artifact) (_ string, err error) {
+	defer s.Metrics.observe(s.Backend(), StorageOperationDelete, time.Now(), &err)
+	if err := s.Fence.Check(); err != nil {
+		return "", err
+	}
 	var deletedDir string
 	dir := filepath.Dir(s.LocalPath(artifact))
 	// Check if the dir exists.
-	_, err := os.Stat(dir)
-	if err == nil {
+	if _, err := os.Stat(dir); err == nil {
 		deletedDir = dir
 	}
+	if err := s.removeQuarantine(artifact); err != nil {
+		return "", err
+	}
 	return deletedDir, os.RemoveAll(dir)
 }
 
 // RemoveAllButCurrent removes all files for the given v1.Artifact base dir, excluding the current one.
-func (s Storage) RemoveAllButCurrent(artifact v1.Artifact) ([]string, error) {
+func (s Storage) RemoveAllButCurrent(artifact v1.Artifact) (_ []string, err error) {
+	defer s.Metrics.observe(s.Backend(), StorageOperationDelete, time.Now(), &err)
+	if err := s.Fence.Check(); err != nil {
+		return nil, err
+	}
 	deletedFiles := []string{}
 	localPath := s.LocalPath(artifact)
 	dir := filepath.Dir(localPath)
@@ -179,27 +444,29 @@ func (s Storage) RemoveAllButCurrent(artifact v1.Artifact) ([]string, error) {
 func (s Storage) getGarbageFiles(artifact v1.Artifact, totalCountLimit, maxItemsToBeRetained int, ttl time.Duration) (garbageFiles []string, _ error) {
 	localPath := s.LocalPath(artifact)
 	dir := filepath.Dir(localPath)
-	artifactFilesWithCreatedTs := make(map[time.Time]string)
-	// sortedPaths contain all files sorted according to their created ts.
-	sortedPaths := []string{}
+	// files contains all artifact files, to be sorted according to their
+	// created ts.
+	type file struct {
+		path      string
+		createdAt time.Time
+	}
+	var files []file
 	now := time.Now().UTC()
-	totalArtifactFiles := 0
 	var errors []string
-	creationTimestamps := []time.Time{}
 	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			errors = append(errors, err.Error())
 			return nil
 		}
-		if totalArtifactFiles >= totalCountLimit {
-			return fmt.Errorf("reached file walking limit, already walked over: %d", totalArtifactFiles)
+		if len(files) >= totalCountLimit {
+			return fmt.Errorf("reached file walking limit, already walked over: %d", len(files))
 		}
 		info, err := d.Info()
 		if err != nil {
 			errors = append(errors, err.Error())
 			return nil
 		}
-		createdAt := info.ModTime().UTC()
+		createdAt := s.writeTime(path, info).UTC()
 		diff := now.Sub(createdAt)
 		// Compare the time difference between now and the time at which the file was created
 		// with the provided TTL. Delete if the difference is greater than the TTL. Since the
@@ -210,9 +477,7 @@ func (s Storage) getGarbageFiles(artifact v1.Artifact, totalCountLimit, maxItems
 			if path != localPath && expired {
 				garbageFiles = append(garbageFiles, path)
 			}
-			totalArtifactFiles += 1
-			artifactFilesWithCreatedTs[createdAt] = path
-			creationTimestamps = append(creationTimestamps, createdAt)
+			files = append(files, file{path: path, createdAt: createdAt})
 		}
 		return nil
 
@@ -223,35 +488,34 @@ func (s Storage) getGarbageFiles(artifact v1.Artifact, totalCountLimit, maxItems
 
 	// We already collected enough garbage files to satisfy the no. of max
 	// items that are supposed to be retained, so exit early.
-	if totalArtifactFiles-len(garbageFiles) < maxItemsToBeRetained {
+	if len(files)-len(garbageFiles) < maxItemsToBeRetained {
 		return garbageFiles, nil
 	}
 
-	// sort all timestamps in ascending order.
-	sort.Slice(creationTimestamps, func(i, j int) bool { return creationTimestamps[i].Before(creationTimestamps[j]) })
-	for _, ts := range creationTimestamps {
-		path, ok := artifactFilesWithCreatedTs[ts]
-		if !ok {
-			return garbageFiles, fmt.Errorf("failed to fetch file for created ts: %v", ts)
-		}
-		sortedPaths = append(sortedPaths, path)
+	// Sort all files by their created ts in ascending order. Files created
+	// at the same time keep the order in which they were walked.
+	sort.SliceStable(files, func(i, j int) bool { return files[i].createdAt.Before(files[j].createdAt) })
+
+	garbage := make(map[string]struct{}, len(garbageFiles))
+	for _, path := range garbageFiles {
+		garbage[path] = struct{}{}
 	}
 
 	var collected int
 	noOfGarbageFiles := len(garbageFiles)
-	for _, path := range sortedPaths {
-		if path != localPath && filepath.Ext(path) != ".lock" && !stringInSlice(path, garbageFiles) {
+	for _, f := range files {
+		if _, ok := garbage[f.path]; f.path != localPath && !ok {
 			// If we previously collected some garbage files with an expired ttl, then take that into account
 			// when checking whether we need to remove more files to satisfy the max no. of items allowed
 			// in the filesystem, along with the no. of files already removed in this loop.
 			if noOfGarbageFiles > 0 {
-				if (len(sortedPaths) - collected - len(garbageFiles)) > maxItemsToBeRetained {
-					garbageFiles = append(garbageFiles, path)
+				if (len(files) - collected - len(garbageFiles)) > maxItemsToBeRetained {
+					garbageFiles = append(garbageFiles, f.path)
 					collected += 1
 				}
 			} else {
-				if len(sortedPaths)-collected > maxItemsToBeRetained {
-					garbageFiles = append(garbageFiles, path)
+				if len(files)-collected > maxItemsToBeRetained {
+					garbageFiles = append(garbageFiles, f.path)
 					collected += 1
 				}
 			}
@@ -261,9 +525,70 @@ func (s Storage) getGarbageFiles(artifact v1.Artifact, totalCountLimit, maxItems
 	return garbageFiles, nil
 }
 
+// getOverBudgetFiles returns the oldest files in the dir of the given artifact
+// which need to be garbage collected, in addition to the given garbage files,
+// for the total size of the remaining files to not exceed maxSize. The given
+// artifact and the pinned artifacts are never returned.
+func (s Storage) getOverBudgetFiles(artifact v1.Artifact, garbageFiles []string, pinned []v1.Artifact, maxSize int64) ([]string, error) {
+	localPath := s.LocalPath(artifact)
+	entries, err := os.ReadDir(filepath.Dir(localPath))
+	if err != nil {
+		return nil, err
+	}
+	collected := make(map[string]struct{}, len(garbageFiles))
+	for _, path := range garbageFiles {
+		collected[path] = struct{}{}
+	}
+	keep := map[string]struct{}{localPath: {}}
+	for _, a := range pinned {
+		keep[s.LocalPath(a)] = struct{}{}
+	}
+
+	type candidate struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var candidates []candidate
+	var total int64
+	for _, e := range entries {
+		path := filepath.Join(filepath.Dir(localPath), e.Name())
+		if !e.Type().IsRegular() || filepath.Ext(path) == ".lock" {
+			continue
+		}
+		if _, ok := collected[path]; ok {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		size := s.storedSize(path, info)
+		total += size
+		if _, ok := keep[path]; !ok {
+			candidates = append(candidates, candidate{path: path, size: size, modTime: s.writeTime(path, info)})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].modTime.Before(candidates[j].modTime) })
+	var overBudget []string
+	for _, c := range candidates {
+		if total <= maxSize {
+			break
+		}
+		overBudget = append(overBudget, c.path)
+		total -= c.size
+	}
+	return overBudget, nil
+}
+
 // GarbageCollect removes all garbage files in the artifact dir according to the provided
-// retention options.
-func (s Storage) GarbageCollect(ctx context.Context, artifact v1.Artifact, timeout time.Duration) ([]string, error) {
+// retention options. The files of the pinned artifacts are never removed.
+func (s Storage) GarbageCollect(ctx context.Context, artifact v1.Artifact, timeout time.Duration, pinned ...v1.Artifact) (_ []string, err error) {
+	defer s.Metrics.observe(s.Backend(), StorageOperationGC, time.Now(), &err)
+	if err := s.Fence.Check(); err != nil {
+		return nil, err
+	}
 	delFilesChan := make(chan []string)
 	errChan := make(chan error)
 	// Abort if it takes more than the provided timeout duration.
@@ -276,6 +601,21 @@ func (s Storage) GarbageCollect(ctx context.Context, artifact v1.Artifact, timeo
 			errChan <- err
 			return
 		}
+		consumed, err := s.consumedArtifacts(ctx, artifact)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		protected := append(consumed, pinned...)
+		garbageFiles = s.withoutPinned(garbageFiles, protected)
+		if s.ArtifactRetentionMaxSize > 0 {
+			overBudget, err := s.getOverBudgetFiles(artifact, garbageFiles, protected, s.ArtifactRetentionMaxSize)
+			if err != nil {
+				errChan <- err
+				return
+			}
+			garbageFiles = append(garbageFiles, overBudget...)
+		}
 		var errors []error
 		var deleted []string
 		if len(garbageFiles) > 0 {
@@ -296,6 +636,13 @@ func (s Storage) GarbageCollect(ctx context.Context, artifact v1.Artifact, timeo
 				}
 			}
 		}
+		var purged []string
+		for _, file := range deleted {
+			if rel, err := filepath.Rel(s.BasePath, file); err == nil {
+				purged = append(purged, rel)
+			}
+		}
+		s.purge(purged...)
 		if len(errors) > 0 {
 			errChan <- kerrors.NewAggregate(errors)
 			return
@@ -315,15 +662,6 @@ func (s Storage) GarbageCollect(ctx context.Context, artifact v1.Artifact, timeo
 	}
 }
 
-func stringInSlice(a string, list []string) bool {
-	for _, b := range list {
-		if b == a {
-			return true
-		}
-	}
-	return false
-}
-
 // ArtifactExist returns a boolean indicating whether the v1.Artifact exists in storage and is a regular file.
 func (s Storage) ArtifactExist(artifact v1.Artifact) bool {
 	fi, err := os.Lstat(s.LocalPath(artifact))
@@ -385,6 +723,10 @@ func SourceIgnoreFilter(ps []gitignore.Pattern, domain []string) ArchiveFileFilt
 // the user and group name) is stripped from file headers.
 // If successful, it sets the digest and last update time on the artifact.
 func (s Storage) Archive(artifact *v1.Artifact, dir string, filter ArchiveFileFilter) (err error) {
+	defer s.Metrics.observe(s.Backend(), StorageOperationStore, time.Now(), &err)
+	if err := s.Fence.Check(); err != nil {
+		return err
+	}
 	if f, err := os.Stat(dir); os.IsNotExist(err) || !f.IsDir() {
 		return fmt.Errorf("invalid dir path: %s", dir)
 	}
@@ -405,7 +747,11 @@ func (s Storage) Archive(artifact *v1.Artifact, dir string, filter ArchiveFileFi
 	sz := &writeCounter{}
 	mw := io.MultiWriter(d.Hash(), tf, sz)
 
-	gw := gzip.NewWriter(mw)
+	gw, err := gzip.NewWriterLevel(mw, s.CompressionLevel)
+	if err != nil {
+		tf.Close()
+		return err
+	}
 	tw := tar.NewWriter(gw)
 	if err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
 		if err != nil {
@@ -480,20 +826,57 @@ func (s Storage) Archive(artifact *v1.Artifact, dir string, filter ArchiveFileFi
 		return err
 	}
 
-	if err := sourcefs.RenameWithFallback(tmpName, localPath); err != nil {
+	if err := s.commit(artifact, tmpName, d.Digest()); err != nil {
 		return err
 	}
 
 	artifact.Digest = d.Digest().String()
 	artifact.LastUpdateTime = metav1.Now()
 	artifact.Size = &sz.written
+	s.Metrics.written(s.Backend(), sz.written)
 
 	return nil
 }
 
+// Store archives the given directory to the given v1.Artifact path while
+// holding the lock of the Artifact, and verifies it can be read back from
+// its URL. It implements fetch.Store. The returned errors are
+// *serror.Generic, with the reason of the failed storage operation.
+func (s Storage) Store(ctx context.Context, artifact *v1.Artifact, dir string) error {
+	if err := s.MkdirAll(*artifact); err != nil {
+		return serror.NewGeneric(
+			fmt.Errorf("failed to create artifact directory: %w", err),
+			v1.DirCreationFailedReason,
+		)
+	}
+	unlock, err := s.Lock(*artifact)
+	if err != nil {
+		return serror.NewGeneric(
+			fmt.Errorf("failed to acquire lock for artifact: %w", err),
+			meta.FailedReason,
+		)
+	}
+	defer unlock()
+
+	if err := s.Archive(artifact, dir, nil); err != nil {
+		return serror.NewGeneric(
+			serror.Classified(fmt.Errorf("unable to archive artifact to storage: %w", err)),
+			v1.ArchiveOperationFailedReason,
+		)
+	}
+	if err := s.VerifyReadBack(ctx, *artifact); err != nil {
+		return serror.NewGeneric(err, v1.ArtifactReadBackFailedReason)
+	}
+	return nil
+}
+
 // AtomicWriteFile atomically writes the io.Reader contents to the v1.Artifact path.
 // If successful, it sets the digest and last update time on the artifact.
 func (s Storage) AtomicWriteFile(artifact *v1.Artifact, reader io.Reader, mode os.FileMode) (err error) {
+	defer s.Metrics.observe(s.Backend(), StorageOperationStore, time.Now(), &err)
+	if err := s.Fence.Check(); err != nil {
+		return err
+	}
 	localPath := s.LocalPath(*artifact)
 	tf, err := os.CreateTemp(filepath.Split(localPath))
 	if err != nil {
@@ -522,13 +905,14 @@ func (s Storage) AtomicWriteFile(artifact *v1.Artifact, reader io.Reader, mode o
 		return err
 	}
 
-	if err := sourcefs.RenameWithFallback(tfName, localPath); err != nil {
+	if err := s.commit(artifact, tfName, d.Digest()); err != nil {
 		return err
 	}
 
 	artifact.Digest = d.Digest().String()
 	artifact.LastUpdateTime = metav1.Now()
 	artifact.Size = &sz.written
+	s.Metrics.written(s.Backend(), sz.written)
 
 	return nil
 }
@@ -536,6 +920,10 @@ func (s Storage) AtomicWriteFile(artifact *v1.Artifact, reader io.Reader, mode o
 // Copy atomically copies the io.Reader contents to the v1.Artifact path.
 // If successful, it sets the digest and last update time on the artifact.
 func (s Storage) Copy(artifact *v1.Artifact, reader io.Reader) (err error) {
+	defer s.Metrics.observe(s.Backend(), StorageOperationStore, time.Now(), &err)
+	if err := s.Fence.Check(); err != nil {
+		return err
+	}
 	localPath := s.LocalPath(*artifact)
 	tf, err := os.CreateTemp(filepath.Split(localPath))
 	if err != nil {
@@ -560,13 +948,14 @@ func (s Storage) Copy(artifact *v1.Artifact, reader io.Reader) (err error) {
 		return err
 	}
 
-	if err := sourcefs.RenameWithFallback(tfName, localPath); err != nil {
+	if err := s.commit(artifact, tfName, d.Digest()); err != nil {
 		return err
 	}
 
 	artifact.Digest = d.Digest().String()
 	artifact.LastUpdateTime = metav1.Now()
 	artifact.Size = &sz.written
+	s.Metrics.written(s.Backend(), sz.written)
 
 	return nil
 }
@@ -588,7 +977,8 @@ func (s Storage) CopyFromPath(artifact *v1.Artifact, path string) (err error) {
 }
 
 // CopyToPath copies the contents in the (sub)path of the given artifact to the given path.
-func (s Storage) CopyToPath(artifact *v1.Artifact, subPath, toPath string) error {
+func (s Storage) CopyToPath(artifact *v1.Artifact, subPath, toPath string) (err error) {
+	defer s.Metrics.observe(s.Backend(), StorageOperationRetrieve, time.Now(), &err)
 	// create a tmp directory to store artifact
 	tmp, err := os.MkdirTemp("", "flux-include-")
 	if err != nil {
@@ -606,9 +996,11 @@ func (s Storage) CopyToPath(artifact *v1.Artifact, subPath, toPath string) error
 
 	// untar the artifact
 	untarPath := filepath.Join(tmp, "unpack")
-	if err = pkgtar.Untar(f, untarPath, pkgtar.WithMaxUntarSize(-1)); err != nil {
+	sz := &writeCounter{}
+	if err = pkgtar.Untar(io.TeeReader(f, sz), untarPath, pkgtar.WithMaxUntarSize(-1)); err != nil {
 		return err
 	}
+	s.Metrics.read(s.Backend(), sz.written)
 
 	// create the destination parent dir
 	if err = os.MkdirAll(filepath.Dir(toPath), os.ModePerm); err != nil {
@@ -628,6 +1020,12 @@ func (s Storage) CopyToPath(artifact *v1.Artifact, subPath, toPath string) error
 
 // Symlink creates or updates a symbolic link for the given v1.Artifact and returns the URL for the symlink.
 func (s Storage) Symlink(artifact v1.Artifact, linkName string) (string, error) {
+	if err := s.Fence.Check(); err != nil {
+		return "", err
+	}
+	if err := s.checkLock(artifact); err != nil {
+		return "", err
+	}
 	localPath := s.LocalPath(artifact)
 	dir := filepath.Dir(localPath)
 	link := filepath.Join(dir, linkName)
@@ -644,17 +1042,107 @@ func (s Storage) Symlink(artifact v1.Artifact, linkName string) (string, error)
 	if err := os.Rename(tmpLink, link); err != nil {
 		return "", err
 	}
+	s.purge(filepath.Join(filepath.Dir(artifact.Path), linkName))
+
+	return fmt.Sprintf("%s/%s", s.baseURL(artifact.Path), filepath.Join(filepath.Dir(artifact.Path), linkName)), nil
+}
+
+// commit renames the temporary file holding the content with the given
+// digest to the path of the given v1.Artifact, unless its lock has been
+// lost. If the Storage is Immutable
+// and a file with a different digest exists at that path, the path and URL
+// of the artifact are changed to the immutablePath instead. If the Storage
+// deduplicates the content, the file is linked to the stored content.
+func (s Storage) commit(artifact *v1.Artifact, tmpName string, dgst digest.Digest) error {
+	if err := s.checkLock(*artifact); err != nil {
+		return err
+	}
+	localPath := s.LocalPath(*artifact)
+	if s.Immutable {
+		if existing, err := digestFile(localPath, dgst.Algorithm()); err == nil && existing != dgst {
+			artifact.Path = immutablePath(artifact.Path, dgst)
+			s.SetArtifactURL(artifact)
+			localPath = s.LocalPath(*artifact)
+		}
+	}
+	if err := sourcefs.RenameWithFallback(tmpName, localPath); err != nil {
+		return err
+	}
+	if s.Deduplicate {
+		return s.deduplicate(localPath, dgst)
+	}
+	return nil
+}
+
+// immutableDigestLength is the number of characters of the encoded digest
+// appended to the file name by immutablePath.
+const immutableDigestLength = 12
+
+// immutablePath returns the given artifact path with the first
+// immutableDigestLength characters of the encoded digest appended to the
+// file name, before its extension.
+func immutablePath(artifactPath string, dgst digest.Digest) string {
+	ext := artifactExt(artifactPath)
+	encoded := dgst.Encoded()
+	if len(encoded) > immutableDigestLength {
+		encoded = encoded[:immutableDigestLength]
+	}
+	return fmt.Sprintf("%s-%s%s", strings.TrimSuffix(artifactPath, ext), encoded, ext)
+}
+
+// mutablePath returns the given artifact path without the digest appended
+// to the file name by immutablePath, or the path unchanged if it has none.
+func mutablePath(artifactPath string) string {
+	ext := artifactExt(artifactPath)
+	base := strings.TrimSuffix(artifactPath, ext)
+	i := strings.LastIndex(base, "-")
+	if i < 0 || len(base)-i-1 != immutableDigestLength || strings.Trim(base[i+1:], "0123456789abcdef") != "" {
+		return artifactPath
+	}
+	return base[:i] + ext
+}
 
-	return fmt.Sprintf("http://%s/%s", s.Hostname, filepath.Join(filepath.Dir(artifact.Path), linkName)), nil
+// artifactExt returns the extension of the given artifact path, including
+// the '.tar' of '.tar.gz' files.
+func artifactExt(artifactPath string) string {
+	if strings.HasSuffix(artifactPath, ".tar.gz") {
+		return ".tar.gz"
+	}
+	return filepath.Ext(artifactPath)
 }
 
-// Lock creates a file lock for the given v1.Artifact.
+// digestFile returns the digest of the file at the given path, calculated
+// with the given algorithm.
+func digestFile(path string, algo digest.Algorithm) (digest.Digest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return algo.FromReader(f)
+}
+
+// Lock creates a file lock for the given v1.Artifact, or locks it with the
+// Locker if set.
 func (s Storage) Lock(artifact v1.Artifact) (unlock func(), err error) {
+	defer s.Metrics.observe(s.Backend(), StorageOperationLock, time.Now(), &err)
+	if s.Locker != nil {
+		return s.Locker.Lock(artifact)
+	}
 	lockFile := s.LocalPath(artifact) + ".lock"
 	mutex := lockedfile.MutexAt(lockFile)
 	return mutex.Lock()
 }
 
+// checkLock returns an error if the lock of the given v1.Artifact held with
+// the Locker has been lost.
+func (s Storage) checkLock(artifact v1.Artifact) error {
+	if s.Locker == nil {
+		return nil
+	}
+	return s.Locker.Check(artifact)
+}
+
 // LocalPath returns the secure local path of the given artifact (that is: relative to the Storage.BasePath).
 func (s Storage) LocalPath(artifact v1.Artifact) string {
 	if artifact.Path == "" {
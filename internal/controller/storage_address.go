@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups="",resources=services,verbs=get
+
+// StorageAddressResolver derives the advertised address of the Storage from
+// the Service exposing the file server, and keeps it up-to-date when the
+// Service changes.
+type StorageAddressResolver struct {
+	// Reader reads the Service, it should not be backed by a cache to avoid
+	// watching all Services.
+	Reader client.Reader
+
+	Storage *Storage
+
+	// ServiceName and ServiceNamespace identify the Service.
+	ServiceName      string
+	ServiceNamespace string
+
+	// ClusterDomain is the DNS domain of the cluster, e.g. 'cluster.local'.
+	ClusterDomain string
+
+	// TargetPortName and TargetPort are the name and number of the container
+	// port of the file server, matched against the target ports of the
+	// Service.
+	TargetPortName string
+	TargetPort     int32
+
+	// Interval at which the Service is checked for changes.
+	Interval time.Duration
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. All replicas
+// advertise artifact URLs, and must keep their address up-to-date.
+func (r *StorageAddressResolver) NeedLeaderElection() bool {
+	return false
+}
+
+// Start updates the advertised address of the Storage at the configured
+// interval until the given context is canceled.
+func (r *StorageAddressResolver) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("storage-address-resolver")
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			addr, err := r.Resolve(ctx)
+			if err != nil {
+				log.Error(err, "unable to resolve storage address")
+				continue
+			}
+			if current := r.Storage.AdvertisedHostname(); !hasHost(current, addr) {
+				log.Info("storage address changed", "address", addr)
+				r.Storage.SetAdvertisedHostname(addr)
+			}
+		}
+	}
+}
+
+// Resolve returns the DNS name of the Service, with the port of the Service
+// which targets the file server. The port is omitted if it is 80.
+func (r *StorageAddressResolver) Resolve(ctx context.Context) (string, error) {
+	svc := &corev1.Service{}
+	key := client.ObjectKey{Namespace: r.ServiceNamespace, Name: r.ServiceName}
+	if err := r.Reader.Get(ctx, key, svc); err != nil {
+		return "", fmt.Errorf("failed to get storage Service '%s': %w", key, err)
+	}
+
+	port, err := r.servicePort(svc)
+	if err != nil {
+		return "", err
+	}
+
+	host := fmt.Sprintf("%s.%s.svc.%s.", svc.Name, svc.Namespace, r.ClusterDomain)
+	if port == 80 {
+		return host, nil
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(port))), nil
+}
+
+// servicePort returns the port of the Service targeting the file server.
+func (r *StorageAddressResolver) servicePort(svc *corev1.Service) (int32, error) {
+	for _, p := range svc.Spec.Ports {
+		if p.Protocol != "" && p.Protocol != corev1.ProtocolTCP {
+			continue
+		}
+		switch target := p.TargetPort; {
+		case target.Type == intstr.String && target.StrVal == r.TargetPortName,
+			target.Type == intstr.Int && target.IntVal == r.TargetPort,
+			// The target port defaults to the port.
+			target.Type == intstr.Int && target.IntVal == 0 && p.Port == r.TargetPort:
+			return p.Port, nil
+		}
+	}
+	return 0, fmt.Errorf("storage Service '%s/%s' has no port targeting '%s' or %d",
+		svc.Namespace, svc.Name, r.TargetPortName, r.TargetPort)
+}
+
+// hasHost returns true if the given host name equals addr, ignoring any
+// scheme.
+func hasHost(hostname, addr string) bool {
+	hostname = strings.TrimPrefix(strings.TrimPrefix(hostname, "https://"), "http://")
+	return hostname == addr
+}
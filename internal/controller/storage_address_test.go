@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestStorageAddressResolver_Resolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		ports   []corev1.ServicePort
+		want    string
+		wantErr string
+	}{
+		{
+			name: "named target port",
+			ports: []corev1.ServicePort{
+				{Name: "metrics", Port: 8080, TargetPort: intstr.FromString("http-prom")},
+				{Name: "http", Port: 80, TargetPort: intstr.FromString("http")},
+			},
+			want: "source-controller.flux-system.svc.cluster.local.",
+		},
+		{
+			name: "numbered target port",
+			ports: []corev1.ServicePort{
+				{Name: "http", Port: 8081, TargetPort: intstr.FromInt32(9090)},
+			},
+			want: "source-controller.flux-system.svc.cluster.local.:8081",
+		},
+		{
+			name: "default target port",
+			ports: []corev1.ServicePort{
+				{Name: "http", Port: 9090},
+			},
+			want: "source-controller.flux-system.svc.cluster.local.:9090",
+		},
+		{
+			name: "no matching port",
+			ports: []corev1.ServicePort{
+				{Name: "metrics", Port: 8080, TargetPort: intstr.FromString("http-prom")},
+			},
+			wantErr: "has no port targeting 'http' or 9090",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "source-controller", Namespace: "flux-system"},
+				Spec:       corev1.ServiceSpec{Ports: tt.ports},
+			}
+			r := &StorageAddressResolver{
+				Reader:           fakeclient.NewClientBuilder().WithObjects(svc).Build(),
+				ServiceName:      "source-controller",
+				ServiceNamespace: "flux-system",
+				ClusterDomain:    "cluster.local",
+				TargetPortName:   "http",
+				TargetPort:       9090,
+			}
+
+			got, err := r.Resolve(context.TODO())
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestStorage_SetAdvertisedHostname(t *testing.T) {
+	g := NewWithT(t)
+
+	storage, err := NewStorage(t.TempDir(), "https://", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	storage.SetAdvertisedHostname("source-controller.flux-system.svc.cluster.local.")
+	g.Expect(storage.AdvertisedHostname()).To(Equal("https://source-controller.flux-system.svc.cluster.local."))
+
+	artifact := storage.NewArtifactFor("GitRepository", &metav1.ObjectMeta{Namespace: "default", Name: "podinfo"}, "", "latest.tar.gz")
+	g.Expect(artifact.URL).To(Equal("https://source-controller.flux-system.svc.cluster.local./gitrepository/default/podinfo/latest.tar.gz"))
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kuberecorder "k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fluxcd/pkg/runtime/conditions"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	serror "github.com/fluxcd/source-controller/internal/error"
+)
+
+// StorageDegradedReason signals that Storage operations are failing because
+// the Storage is unavailable.
+const StorageDegradedReason = "StorageDegraded"
+
+// StorageBackpressure slows down the retries of the objects for which a
+// Storage operation failed while the Storage is unavailable. Instead of each
+// object erroring at the rate of the controller rate limiter, the objects
+// are retried at the RetryInterval, and a single Warning event aggregating
+// the affected objects is emitted per RetryInterval.
+type StorageBackpressure struct {
+	kuberecorder.EventRecorder
+
+	// RetryInterval is the interval at which the affected objects are
+	// retried, and at which the aggregated events are emitted.
+	RetryInterval time.Duration
+
+	mu        sync.Mutex
+	affected  map[string]struct{}
+	lastEvent time.Time
+	now       func() time.Time
+}
+
+// NewStorageBackpressure returns a new StorageBackpressure emitting events
+// with the given recorder.
+func NewStorageBackpressure(recorder kuberecorder.EventRecorder, retryInterval time.Duration) *StorageBackpressure {
+	return &StorageBackpressure{
+		EventRecorder: recorder,
+		RetryInterval: retryInterval,
+		affected:      make(map[string]struct{}),
+		now:           time.Now,
+	}
+}
+
+// Backoff returns the given reconcile error as is, unless the object has a
+// StorageOperationFailedCondition and the Storage is unhealthy. In which
+// case a Waiting error is returned instead, which requeues the object after
+// the RetryInterval of the Backpressure without emitting an event for it.
+// It is a no-op when no Backpressure is configured.
+func (s Storage) Backoff(obj conditionsObject, err error) error {
+	b := s.Backpressure
+	if b == nil || err == nil || !conditions.IsTrue(obj, sourcev1.StorageOperationFailedCondition) {
+		return err
+	}
+	healthErr := s.Healthy()
+	if healthErr == nil {
+		return err
+	}
+
+	reason := StorageDegradedReason
+	var generic *serror.Generic
+	if errors.As(err, &generic) {
+		reason = generic.Reason
+	}
+	w := serror.NewWaiting(fmt.Errorf("storage is unavailable, retrying in %s: %w", b.RetryInterval, err), reason)
+	w.RequeueAfter = b.RetryInterval
+	w.Config.Event = serror.EventTypeNone
+	b.record(obj, healthErr)
+	return w
+}
+
+// conditionsObject is a client.Object with conditions.
+type conditionsObject interface {
+	client.Object
+	conditions.Getter
+}
+
+// record adds the given object to the affected objects, and emits the
+// aggregated Warning event on it if none was emitted during the last
+// RetryInterval.
+func (b *StorageBackpressure) record(obj client.Object, cause error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.affected[fmt.Sprintf("%s/%s/%s", sourceKind(obj), obj.GetNamespace(), obj.GetName())] = struct{}{}
+
+	now := b.now()
+	if now.Sub(b.lastEvent) < b.RetryInterval {
+		return
+	}
+	b.Eventf(obj, corev1.EventTypeWarning, StorageDegradedReason,
+		"storage is unavailable, %d source(s) are retried every %s: %s",
+		len(b.affected), b.RetryInterval, cause)
+	b.lastEvent = now
+	b.affected = make(map[string]struct{})
+}
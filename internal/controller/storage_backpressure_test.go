@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/fluxcd/pkg/runtime/conditions"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	serror "github.com/fluxcd/source-controller/internal/error"
+)
+
+func TestStorage_Backoff(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	storage, err := NewStorage(dir, "hostname", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	recorder := record.NewFakeRecorder(32)
+	now := time.Now()
+	storage.Backpressure = NewStorageBackpressure(recorder, time.Minute)
+	storage.Backpressure.now = func() time.Time { return now }
+
+	newObj := func(name string, storageFailed bool) *sourcev1.GitRepository {
+		obj := &sourcev1.GitRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		}
+		if storageFailed {
+			conditions.MarkTrue(obj, sourcev1.StorageOperationFailedCondition, sourcev1.ArchiveOperationFailedReason, "failed")
+		}
+		return obj
+	}
+	reconcileErr := serror.NewGeneric(errors.New("failed to archive"), sourcev1.ArchiveOperationFailedReason)
+
+	// A healthy Storage does not apply backpressure.
+	g.Expect(storage.Backoff(newObj("a", true), reconcileErr)).To(Equal(reconcileErr))
+
+	g.Expect(os.RemoveAll(dir)).To(Succeed())
+
+	// Failures which are not related to the Storage are returned as is.
+	g.Expect(storage.Backoff(newObj("a", false), reconcileErr)).To(Equal(reconcileErr))
+	g.Expect(storage.Backoff(newObj("a", true), nil)).To(Succeed())
+
+	err = storage.Backoff(newObj("a", true), reconcileErr)
+	var waiting *serror.Waiting
+	g.Expect(errors.As(err, &waiting)).To(BeTrue())
+	g.Expect(waiting.RequeueAfter).To(Equal(time.Minute))
+	g.Expect(waiting.Reason).To(Equal(sourcev1.ArchiveOperationFailedReason))
+	g.Expect(waiting.Config.Event).To(Equal(serror.EventTypeNone))
+	g.Expect(errors.Is(err, reconcileErr)).To(BeTrue())
+	g.Expect(recorder.Events).To(Receive(ContainSubstring("1 source(s) are retried every 1m0s")))
+
+	// Subsequent failures are aggregated in the next event.
+	g.Expect(storage.Backoff(newObj("b", true), reconcileErr)).To(BeAssignableToTypeOf(&serror.Waiting{}))
+	g.Expect(storage.Backoff(newObj("c", true), reconcileErr)).To(BeAssignableToTypeOf(&serror.Waiting{}))
+	g.Expect(recorder.Events).ToNot(Receive())
+
+	now = now.Add(time.Minute)
+	g.Expect(storage.Backoff(newObj("c", true), reconcileErr)).To(BeAssignableToTypeOf(&serror.Waiting{}))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring("2 source(s) are retried every 1m0s")))
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	sourcefs "github.com/fluxcd/source-controller/internal/fs"
+)
+
+// ContentDir is the directory of the Storage in which the content of the
+// artifacts is stored once when the Storage deduplicates it, with the layout
+// content/<algorithm>/<encoded digest>.
+const ContentDir = "content"
+
+// contentTimesDir is the directory in the ContentDir holding an empty file
+// per deduplicated artifact, at the same relative path as the artifact,
+// whose modification time is the time the artifact was written. The
+// modification time of the artifact itself is the one of the content it
+// links to, which is shared with all the other artifacts linking to it.
+const contentTimesDir = "times"
+
+// contentPath returns the local path of the content with the given digest.
+func (s Storage) contentPath(dgst digest.Digest) string {
+	return filepath.Join(s.BasePath, ContentDir, dgst.Algorithm().String(), dgst.Encoded())
+}
+
+// deduplicate replaces the file at the given local path, holding the content
+// with the given digest, with a hard link to the stored content with that
+// digest. The file becomes the stored content if there is none yet.
+func (s Storage) deduplicate(localPath string, dgst digest.Digest) error {
+	content := s.contentPath(dgst)
+	if err := os.MkdirAll(filepath.Dir(content), 0o700); err != nil {
+		return fmt.Errorf("failed to create content directory: %w", err)
+	}
+
+	tmpLink := localPath + ".link"
+	if err := os.Remove(tmpLink); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	err := os.Link(content, tmpLink)
+	if errors.Is(err, fs.ErrNotExist) {
+		// No artifact has stored this content yet.
+		if err := os.Link(localPath, content); err != nil && !errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("failed to store content '%s': %w", dgst, err)
+		}
+		return s.recordWriteTime(localPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to link content '%s': %w", dgst, err)
+	}
+	if err := os.Rename(tmpLink, localPath); err != nil {
+		os.Remove(tmpLink)
+		return fmt.Errorf("failed to link content '%s': %w", dgst, err)
+	}
+	return s.recordWriteTime(localPath)
+}
+
+// writeTimePath returns the local path of the file recording the write time
+// of the artifact at the given local path.
+func (s Storage) writeTimePath(localPath string) (string, bool) {
+	rel, err := filepath.Rel(s.BasePath, localPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.Join(s.BasePath, ContentDir, contentTimesDir, rel), true
+}
+
+// recordWriteTime records the current time as the write time of the
+// deduplicated artifact at the given local path.
+func (s Storage) recordWriteTime(localPath string) error {
+	p, ok := s.writeTimePath(localPath)
+	if !ok {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return fmt.Errorf("failed to record write time: %w", err)
+	}
+	f, err := os.Create(p)
+	if err != nil {
+		return fmt.Errorf("failed to record write time: %w", err)
+	}
+	f.Close()
+	now := time.Now()
+	if err := os.Chtimes(p, now, now); err != nil {
+		return fmt.Errorf("failed to record write time: %w", err)
+	}
+	return nil
+}
+
+// writeTime returns the time the file at the given local path, with the
+// given info, was written. For a deduplicated artifact, this is the
+// recorded write time rather than the modification time of the content it
+// links to. As an artifact which is written again without being
+// deduplicated gets a newer modification time, the latest of both is used.
+func (s Storage) writeTime(localPath string, info fs.FileInfo) time.Time {
+	t := info.ModTime()
+	if p, ok := s.writeTimePath(localPath); ok {
+		if fi, err := os.Stat(p); err == nil && fi.ModTime().After(t) {
+			t = fi.ModTime()
+		}
+	}
+	return t
+}
+
+// storedSize returns the share of the file at the given local path, with
+// the given info, in the size of the Storage. The content of a deduplicated
+// artifact is shared by all the artifacts linking to it, and is only freed
+// once all of them are removed.
+func (s Storage) storedSize(localPath string, info fs.FileInfo) int64 {
+	if !s.Deduplicate {
+		return info.Size()
+	}
+	links, err := sourcefs.LinkCount(localPath)
+	// One of the links is the stored content.
+	if err != nil || links <= 2 {
+		return info.Size()
+	}
+	return info.Size() / int64(links-1)
+}
+
+// CollectContent removes the stored content no artifact links to anymore,
+// and returns the paths of the removed files relative to the base path.
+func (s Storage) CollectContent() ([]string, error) {
+	if err := s.Fence.Check(); err != nil {
+		return nil, err
+	}
+	root := filepath.Join(s.BasePath, ContentDir)
+	times := filepath.Join(root, contentTimesDir)
+	var removed []string
+	var errs []error
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() && path == times {
+			return filepath.SkipDir
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		links, err := sourcefs.LinkCount(path)
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		if links > 1 {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		if rel, err := filepath.Rel(s.BasePath, path); err == nil {
+			removed = append(removed, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	// Remove the write times of the artifacts which no longer exist.
+	err = filepath.WalkDir(times, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(times, path)
+		if err != nil {
+			return nil
+		}
+		if _, err := os.Lstat(filepath.Join(s.BasePath, rel)); errors.Is(err, fs.ErrNotExist) {
+			if err := os.Remove(path); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return removed, kerrors.NewAggregate(errs)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestStorage_Deduplicate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hard link counts are not supported on windows")
+	}
+	g := NewWithT(t)
+
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	storage.Deduplicate = true
+
+	var artifacts []sourcev1.Artifact
+	for _, ns := range []string{"team-a", "team-b"} {
+		artifact := storage.NewArtifactFor(sourcev1.GitRepositoryKind, &metav1.ObjectMeta{Namespace: ns, Name: "monorepo"}, "main@sha1:abc", "abc.tar.gz")
+		g.Expect(storage.MkdirAll(artifact)).To(Succeed())
+		g.Expect(storage.AtomicWriteFile(&artifact, strings.NewReader("content"), 0o600)).To(Succeed())
+		artifacts = append(artifacts, artifact)
+	}
+	g.Expect(artifacts[0].Digest).To(Equal(artifacts[1].Digest))
+
+	content := storage.contentPath(digest.Digest(artifacts[0].Digest))
+	contentInfo, err := os.Stat(content)
+	g.Expect(err).ToNot(HaveOccurred())
+	for _, artifact := range artifacts {
+		fi, err := os.Stat(storage.LocalPath(artifact))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(os.SameFile(fi, contentInfo)).To(BeTrue())
+		g.Expect(storage.VerifyArtifact(artifact)).To(Succeed())
+	}
+
+	// The content is kept as long as an artifact links to it.
+	g.Expect(storage.Remove(artifacts[0])).To(Succeed())
+	removed, err := storage.CollectContent()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(removed).To(BeEmpty())
+	g.Expect(storage.ArtifactExist(artifacts[1])).To(BeTrue())
+
+	g.Expect(storage.Remove(artifacts[1])).To(Succeed())
+	removed, err = storage.CollectContent()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(removed).To(HaveLen(1))
+	g.Expect(content).ToNot(BeAnExistingFile())
+}
+
+func TestStorage_DeduplicateWriteTime(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hard link counts are not supported on windows")
+	}
+	g := NewWithT(t)
+
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	storage.Deduplicate = true
+
+	write := func(ns string) sourcev1.Artifact {
+		artifact := storage.NewArtifactFor(sourcev1.GitRepositoryKind, &metav1.ObjectMeta{Namespace: ns, Name: "monorepo"}, "main@sha1:abc", "abc.tar.gz")
+		g.Expect(storage.MkdirAll(artifact)).To(Succeed())
+		g.Expect(storage.AtomicWriteFile(&artifact, strings.NewReader("content"), 0o600)).To(Succeed())
+		return artifact
+	}
+	writeTime := func(artifact sourcev1.Artifact) time.Time {
+		info, err := os.Stat(storage.LocalPath(artifact))
+		g.Expect(err).ToNot(HaveOccurred())
+		return storage.writeTime(storage.LocalPath(artifact), info)
+	}
+
+	old := write("team-a")
+	past := time.Now().Add(-time.Hour)
+	marker, ok := storage.writeTimePath(storage.LocalPath(old))
+	g.Expect(ok).To(BeTrue())
+	g.Expect(os.Chtimes(marker, past, past)).To(Succeed())
+	g.Expect(os.Chtimes(storage.LocalPath(old), past, past)).To(Succeed())
+
+	// Linking a new artifact to the content does not make the existing one
+	// look recently written.
+	recent := write("team-b")
+	g.Expect(writeTime(old)).To(BeTemporally("~", past, time.Second))
+	g.Expect(writeTime(recent)).To(BeTemporally("~", time.Now(), 10*time.Second))
+
+	// The shared content is counted once across the artifacts linking to it.
+	info, err := os.Stat(storage.LocalPath(old))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(storage.storedSize(storage.LocalPath(old), info)).To(Equal(info.Size() / 2))
+
+	// The write times of removed artifacts are removed with their content.
+	g.Expect(storage.Remove(old)).To(Succeed())
+	_, err = storage.CollectContent()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(marker).ToNot(BeAnExistingFile())
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/fluxcd/pkg/lockedfile"
+)
+
+// storageFenceRequeueAfter is the duration after which the reconcilers retry
+// an object skipped while the fencing token is not acquired.
+const storageFenceRequeueAfter = time.Second
+
+// ErrStorageFenced is returned by the Storage write operations of a replica
+// which does not hold the fencing token.
+var ErrStorageFenced = errors.New("storage is fenced")
+
+// StorageFence fences the writes to a Storage shared by multiple replicas,
+// e.g. on a ReadWriteMany PersistentVolume. Once elected, a replica takes
+// over the fencing token file by incrementing its epoch, and its writes are
+// refused as soon as another replica took it over. This prevents a replica
+// which lost its leadership but is still running, e.g. during a network
+// partition, from overwriting the Artifacts written by the new leader.
+//
+// All replicas serve the Artifacts from the shared Storage, regardless of
+// which replica wrote them.
+type StorageFence struct {
+	// Path of the fencing token file. It must be on the shared volume, but
+	// outside the Storage base path to not be served with the Artifacts.
+	Path string
+
+	// Identity of the replica recorded in the token, e.g. the Pod name.
+	Identity string
+
+	epoch atomic.Int64
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, ensuring
+// only the elected replica takes over the fencing token.
+func (f *StorageFence) NeedLeaderElection() bool {
+	return true
+}
+
+// Start takes over the fencing token, and blocks until the given context is
+// canceled.
+func (f *StorageFence) Start(ctx context.Context) error {
+	if err := f.Acquire(); err != nil {
+		return err
+	}
+	ctrl.LoggerFrom(ctx).WithName("storage-fence").Info("acquired storage fencing token",
+		"identity", f.Identity, "epoch", f.epoch.Load())
+	<-ctx.Done()
+	return nil
+}
+
+// Acquire takes over the fencing token by writing the next epoch and the
+// Identity to the token file.
+func (f *StorageFence) Acquire() error {
+	unlock, err := lockedfile.MutexAt(f.Path + ".lock").Lock()
+	if err != nil {
+		return fmt.Errorf("failed to lock storage fencing token: %w", err)
+	}
+	defer unlock()
+
+	epoch, _, err := f.read()
+	if err != nil {
+		return err
+	}
+	epoch++
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".tmp-")
+	if err != nil {
+		return fmt.Errorf("failed to write storage fencing token: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+	if _, err := fmt.Fprintf(tmp, "%d %s\n", epoch, f.Identity); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write storage fencing token: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write storage fencing token: %w", err)
+	}
+	if err := os.Rename(tmpName, f.Path); err != nil {
+		return fmt.Errorf("failed to write storage fencing token: %w", err)
+	}
+	f.epoch.Store(epoch)
+	return nil
+}
+
+// Acquired returns true if the replica acquired the fencing token, and the
+// reconcilers can write to the Storage. It always returns true on a nil
+// StorageFence.
+func (f *StorageFence) Acquired() bool {
+	return f == nil || f.epoch.Load() != 0
+}
+
+// Check returns an ErrStorageFenced error if the replica does not hold the
+// fencing token. It always returns nil on a nil StorageFence.
+func (f *StorageFence) Check() error {
+	if f == nil {
+		return nil
+	}
+	own := f.epoch.Load()
+	if own == 0 {
+		return fmt.Errorf("%w: fencing token not acquired", ErrStorageFenced)
+	}
+	epoch, identity, err := f.read()
+	if err != nil {
+		return err
+	}
+	if epoch != own {
+		return fmt.Errorf("%w: fencing token taken over by '%s' (epoch %d)", ErrStorageFenced, identity, epoch)
+	}
+	return nil
+}
+
+// read returns the epoch and identity of the fencing token file, or a zero
+// epoch if the file does not exist.
+func (f *StorageFence) read() (int64, string, error) {
+	b, err := os.ReadFile(f.Path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, "", nil
+		}
+		return 0, "", fmt.Errorf("failed to read storage fencing token: %w", err)
+	}
+	v, identity, _ := strings.Cut(strings.TrimSpace(string(b)), " ")
+	epoch, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid storage fencing token '%s': %w", f.Path, err)
+	}
+	return epoch, identity, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestStorageFence(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fence", "leader")
+	g.Expect(os.MkdirAll(filepath.Dir(path), 0o700)).To(Succeed())
+	a := &StorageFence{Path: path, Identity: "replica-a"}
+	b := &StorageFence{Path: path, Identity: "replica-b"}
+
+	var nilFence *StorageFence
+	g.Expect(nilFence.Check()).To(Succeed())
+	g.Expect(nilFence.Acquired()).To(BeTrue())
+
+	err := a.Check()
+	g.Expect(errors.Is(err, ErrStorageFenced)).To(BeTrue())
+	g.Expect(a.Acquired()).To(BeFalse())
+
+	g.Expect(a.Acquire()).To(Succeed())
+	g.Expect(a.Check()).To(Succeed())
+	g.Expect(a.Acquired()).To(BeTrue())
+	content, err := os.ReadFile(path)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(content)).To(Equal("1 replica-a\n"))
+
+	g.Expect(b.Acquire()).To(Succeed())
+	g.Expect(b.Check()).To(Succeed())
+	err = a.Check()
+	g.Expect(errors.Is(err, ErrStorageFenced)).To(BeTrue())
+	g.Expect(err.Error()).To(ContainSubstring("taken over by 'replica-b' (epoch 2)"))
+
+	// The fenced replica refuses to write to the Storage.
+	storage, err := NewStorage(filepath.Join(dir, "storage"), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	artifact := sourcev1.Artifact{Path: "gitrepository/default/podinfo/a.txt"}
+	storage.Fence = a
+	err = storage.MkdirAll(artifact)
+	g.Expect(errors.Is(err, ErrStorageFenced)).To(BeTrue())
+
+	storage.Fence = b
+	g.Expect(storage.MkdirAll(artifact)).To(Succeed())
+	g.Expect(storage.AtomicWriteFile(&artifact, strings.NewReader("content"), 0o600)).To(Succeed())
+}
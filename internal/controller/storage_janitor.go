@@ -0,0 +1,394 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	kuberecorder "k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+// ArtifactInventoryRecorder is a recorder for the number and size of the
+// Artifacts in the Storage.
+type ArtifactInventoryRecorder struct {
+	countGauge     *prometheus.GaugeVec
+	bytesGauge     *prometheus.GaugeVec
+	deletedCounter *prometheus.CounterVec
+}
+
+// NewArtifactInventoryRecorder returns a new ArtifactInventoryRecorder.
+// The configured labels are: backend, kind.
+func NewArtifactInventoryRecorder() *ArtifactInventoryRecorder {
+	return &ArtifactInventoryRecorder{
+		countGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gotk_storage_artifacts",
+				Help: "The number of artifacts stored in the storage.",
+			},
+			[]string{"backend", "kind"},
+		),
+		bytesGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gotk_storage_artifacts_bytes",
+				Help: "The total size in bytes of the artifacts stored in the storage.",
+			},
+			[]string{"backend", "kind"},
+		),
+		deletedCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "gotk_storage_gc_deleted_artifacts_total",
+				Help: "The number of artifacts deleted by the storage garbage collection sweep.",
+			},
+			[]string{"backend", "kind"},
+		),
+	}
+}
+
+// Collectors returns the metrics.Collector objects for the
+// ArtifactInventoryRecorder.
+func (r *ArtifactInventoryRecorder) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		r.countGauge,
+		r.bytesGauge,
+		r.deletedCounter,
+	}
+}
+
+// RecordInventory records the number and total size of the artifacts of the
+// given kind in the given backend.
+func (r *ArtifactInventoryRecorder) RecordInventory(backend, kind string, count, bytes int64) {
+	r.countGauge.WithLabelValues(backend, kind).Set(float64(count))
+	r.bytesGauge.WithLabelValues(backend, kind).Set(float64(bytes))
+}
+
+// RecordGarbageCollected records the number of artifacts of the given kind
+// deleted from the given backend by a sweep.
+func (r *ArtifactInventoryRecorder) RecordGarbageCollected(backend, kind string, count int) {
+	r.deletedCounter.WithLabelValues(backend, kind).Add(float64(count))
+}
+
+// MustMakeArtifactInventoryMetrics creates a new ArtifactInventoryRecorder,
+// and registers the metrics collectors in the controller-runtime metrics
+// registry.
+func MustMakeArtifactInventoryMetrics() *ArtifactInventoryRecorder {
+	r := NewArtifactInventoryRecorder()
+	metrics.Registry.MustRegister(r.Collectors()...)
+	return r
+}
+
+// StorageJanitor periodically garbage collects the Storage for all the
+// Source objects, independently of their reconciliation. This prunes the
+// Artifacts of suspended objects, of objects stuck in deletion, and of
+// objects which no longer exist. After each sweep, the inventory of the
+// Storage is recorded.
+type StorageJanitor struct {
+	client.Client
+
+	Storage  *Storage
+	Interval time.Duration
+	// RateLimit is the maximum number of objects and orphaned directories
+	// processed per second. A value of 0 disables the rate limiting.
+	RateLimit float64
+	// Concurrency is the maximum number of objects garbage collected at the
+	// same time. A value below 1 collects one object at a time.
+	Concurrency int
+	// Timeout is the timeout of the garbage collection of a single object.
+	Timeout time.Duration
+	// Recorder records the inventory of the Storage after each sweep, and
+	// the number of Artifacts it deleted, when set.
+	Recorder *ArtifactInventoryRecorder
+	// EventRecorder records the garbage collection of the Artifacts of the
+	// objects, when set.
+	EventRecorder kuberecorder.EventRecorder
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, ensuring
+// only the replica writing to the Storage deletes from it.
+func (j *StorageJanitor) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs a sweep at the configured interval until the given context is
+// canceled.
+func (j *StorageJanitor) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("storage-janitor")
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if j.Storage.Maintenance.Enabled() {
+				log.V(1).Info("controller is in maintenance mode, skipping storage garbage collection")
+				continue
+			}
+			if !j.Storage.Fence.Acquired() {
+				log.V(1).Info("storage fencing token not acquired yet, skipping storage garbage collection")
+				continue
+			}
+			if err := j.Sweep(ctx); err != nil {
+				log.Error(err, "storage garbage collection failed")
+			}
+		}
+	}
+}
+
+// Sweep garbage collects the Artifacts of all the Source objects, and
+// removes the directories of the Storage which do not belong to any object
+// and have not been modified for at least the interval. When the Storage
+// deduplicates the content of the Artifacts, the content no Artifact links
+// to anymore is removed as well.
+func (j *StorageJanitor) Sweep(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("storage-janitor")
+	wait := j.limiter()
+
+	owned := make(map[string]struct{})
+	var (
+		errs   []error
+		errsMu sync.Mutex
+		wg     sync.WaitGroup
+	)
+	workers := make(chan struct{}, max(j.Concurrency, 1))
+	err := forEachArtifactSource(ctx, j.Client, func(obj artifactSource) error {
+		owned[j.artifactDir(obj)] = struct{}{}
+		artifact := obj.GetArtifact()
+		if artifact == nil {
+			return nil
+		}
+		if err := wait(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case workers <- struct{}{}:
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			if err := j.collect(ctx, obj, *artifact); err != nil {
+				errsMu.Lock()
+				errs = append(errs, err)
+				errsMu.Unlock()
+			}
+		}()
+		return nil
+	})
+	// Wait for the running collectors before returning, also when the
+	// listing was interrupted, so that none outlives the sweep.
+	wg.Wait()
+	if err != nil {
+		// The set of owned directories may be incomplete, do not remove
+		// anything which may belong to an object.
+		return kerrors.NewAggregate(append(errs, err))
+	}
+
+	orphans, err := j.orphanedDirs(owned)
+	if err != nil {
+		return kerrors.NewAggregate(append(errs, err))
+	}
+	for _, dir := range orphans {
+		if err := wait(ctx); err != nil {
+			return kerrors.NewAggregate(append(errs, err))
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		log.Info("removed orphaned artifacts", "path", dir)
+	}
+
+	if j.Storage.Deduplicate {
+		removed, err := j.Storage.CollectContent()
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if len(removed) > 0 {
+			log.Info("removed unreferenced content", "count", len(removed))
+		}
+	}
+
+	if j.Recorder != nil {
+		if err := j.recordInventory(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// recordInventory records the number and total size of the Artifacts in the
+// Storage for each Source kind.
+func (j *StorageJanitor) recordInventory() error {
+	for _, kind := range sourceKinds {
+		var count, bytes int64
+		root := filepath.Join(j.Storage.BasePath, strings.ToLower(kind))
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if !d.Type().IsRegular() || filepath.Ext(path) == ".lock" {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			count++
+			bytes += info.Size()
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record the inventory of %s artifacts: %w", kind, err)
+		}
+		j.Recorder.RecordInventory(j.Storage.Backend(), kind, count, bytes)
+	}
+	return nil
+}
+
+// collect removes all the Artifacts of the given object if it is being
+// deleted, the garbage Artifacts according to the SuspendedRetention policy
+// if it is suspended, or according to the retention options otherwise.
+func (j *StorageJanitor) collect(ctx context.Context, obj artifactSource, artifact sourcev1.Artifact) error {
+	if !obj.GetDeletionTimestamp().IsZero() {
+		if _, err := j.Storage.RemoveAll(artifact); err != nil {
+			return fmt.Errorf("failed to remove artifacts of deleted '%s/%s': %w", obj.GetNamespace(), obj.GetName(), err)
+		}
+		return nil
+	}
+	if isSuspended(obj) {
+		return collectSuspended(ctx, j.Storage, j.EventRecorder, obj, j.Timeout)
+	}
+	deleted, err := j.Storage.retentionFor(obj).GarbageCollect(ctx, artifact, j.Timeout, obj.GetPinnedArtifacts()...)
+	if err != nil {
+		return fmt.Errorf("failed to garbage collect artifacts of '%s/%s': %w", obj.GetNamespace(), obj.GetName(), err)
+	}
+	if len(deleted) > 0 {
+		ctrl.LoggerFrom(ctx).WithName("storage-janitor").V(1).Info(
+			fmt.Sprintf("garbage collected %d artifacts", len(deleted)),
+			"namespace", obj.GetNamespace(), "name", obj.GetName())
+		if j.Recorder != nil {
+			j.Recorder.RecordGarbageCollected(j.Storage.Backend(), sourceKind(obj), len(deleted))
+		}
+		if j.EventRecorder != nil {
+			j.EventRecorder.Eventf(obj, eventv1.EventTypeTrace, "GarbageCollectionSucceeded",
+				"garbage collected %d artifacts", len(deleted))
+		}
+	}
+	return nil
+}
+
+// artifactDir returns the directory of the Storage for the Artifacts of the
+// given object.
+func (j *StorageJanitor) artifactDir(obj client.Object) string {
+	return filepath.Join(j.Storage.BasePath, sourcev1.ArtifactDir(sourceKind(obj), obj.GetNamespace(), obj.GetName()))
+}
+
+// orphanedDirs returns the object directories of the Storage which are not
+// in the given set, and have not been modified for at least the interval.
+func (j *StorageJanitor) orphanedDirs(owned map[string]struct{}) ([]string, error) {
+	var orphans []string
+	for _, kind := range sourceKinds {
+		dirs, err := filepath.Glob(filepath.Join(j.Storage.BasePath, strings.ToLower(kind), "*", "*"))
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range dirs {
+			if _, ok := owned[dir]; ok {
+				continue
+			}
+			fi, err := os.Lstat(dir)
+			if err != nil || !fi.IsDir() || time.Since(fi.ModTime()) < j.Interval {
+				continue
+			}
+			orphans = append(orphans, dir)
+		}
+	}
+	return orphans, nil
+}
+
+// limiter returns a function which blocks until the next operation is
+// allowed by the rate limit.
+func (j *StorageJanitor) limiter() func(ctx context.Context) error {
+	if j.RateLimit <= 0 {
+		return func(ctx context.Context) error { return ctx.Err() }
+	}
+	interval := time.Duration(float64(time.Second) / j.RateLimit)
+	var last time.Time
+	return func(ctx context.Context) error {
+		if d := interval - time.Since(last); !last.IsZero() && d > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(d):
+			}
+		}
+		last = time.Now()
+		return nil
+	}
+}
+
+// sourceKinds are the kinds of the Source objects with Artifacts in the
+// Storage.
+var sourceKinds = []string{
+	sourcev1.GitRepositoryKind,
+	sourcev1.HelmRepositoryKind,
+	sourcev1.HelmChartKind,
+	sourcev1.BucketKind,
+	sourcev1.OCIRepositoryKind,
+}
+
+// sourceKind returns the kind of the given Source object.
+func sourceKind(obj client.Object) string {
+	switch obj.(type) {
+	case *sourcev1.GitRepository:
+		return sourcev1.GitRepositoryKind
+	case *sourcev1.HelmRepository:
+		return sourcev1.HelmRepositoryKind
+	case *sourcev1.HelmChart:
+		return sourcev1.HelmChartKind
+	case *sourcev1.Bucket:
+		return sourcev1.BucketKind
+	case *sourcev1.OCIRepository:
+		return sourcev1.OCIRepositoryKind
+	default:
+		return obj.GetObjectKind().GroupVersionKind().Kind
+	}
+}
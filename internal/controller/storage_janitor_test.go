@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestStorageJanitor_Sweep(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	storage, err := NewStorage(dir, "hostname", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	now := time.Now()
+	writeFile := func(path string, age time.Duration) {
+		t.Helper()
+		p := filepath.Join(dir, path)
+		g.Expect(os.MkdirAll(filepath.Dir(p), 0o700)).To(Succeed())
+		g.Expect(os.WriteFile(p, []byte(path), 0o600)).To(Succeed())
+		g.Expect(os.Chtimes(p, now.Add(-age), now.Add(-age))).To(Succeed())
+	}
+	ageDir := func(path string, age time.Duration) {
+		t.Helper()
+		g.Expect(os.Chtimes(filepath.Join(dir, path), now.Add(-age), now.Add(-age))).To(Succeed())
+	}
+
+	// A suspended object with expired artifacts.
+	suspended := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "suspended", Namespace: "default"},
+		Spec:       sourcev1.GitRepositorySpec{Suspend: true},
+		Status: sourcev1.GitRepositoryStatus{
+			Artifact: &sourcev1.Artifact{Path: "gitrepository/default/suspended/c.tar.gz"},
+		},
+	}
+	writeFile("gitrepository/default/suspended/a.tar.gz", 3*time.Hour)
+	writeFile("gitrepository/default/suspended/b.tar.gz", 2*time.Hour)
+	writeFile("gitrepository/default/suspended/c.tar.gz", 0)
+
+	// An object stuck in deletion.
+	deleted := &sourcev1.OCIRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "deleted",
+			Namespace:         "default",
+			Finalizers:        []string{sourcev1.SourceFinalizer},
+			DeletionTimestamp: &metav1.Time{Time: now},
+		},
+		Status: sourcev1.OCIRepositoryStatus{
+			Artifact: &sourcev1.Artifact{Path: "ocirepository/default/deleted/a.tar.gz"},
+		},
+	}
+	writeFile("ocirepository/default/deleted/a.tar.gz", 0)
+
+	// An object without an artifact.
+	noArtifact := &sourcev1.HelmRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "no-artifact", Namespace: "default"},
+	}
+	writeFile("helmrepository/default/no-artifact/index.yaml", time.Hour)
+	ageDir("helmrepository/default/no-artifact", time.Hour)
+
+	// Directories of objects which no longer exist.
+	writeFile("bucket/default/gone/a.tar.gz", time.Hour)
+	ageDir("bucket/default/gone", time.Hour)
+	writeFile("helmchart/default/recent/a.tgz", 0)
+
+	c := fakeclient.NewClientBuilder().
+		WithScheme(testEnv.GetScheme()).
+		WithObjects(suspended, deleted, noArtifact).
+		Build()
+	j := &StorageJanitor{
+		Client:    c,
+		Storage:   storage,
+		Interval:  10 * time.Minute,
+		RateLimit: 1000,
+		Timeout:   5 * time.Second,
+		Recorder:  NewArtifactInventoryRecorder(),
+	}
+	g.Expect(j.Sweep(context.TODO())).To(Succeed())
+
+	g.Expect(filepath.Join(dir, "gitrepository/default/suspended/a.tar.gz")).ToNot(BeAnExistingFile())
+	g.Expect(filepath.Join(dir, "gitrepository/default/suspended/b.tar.gz")).ToNot(BeAnExistingFile())
+	g.Expect(filepath.Join(dir, "gitrepository/default/suspended/c.tar.gz")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(dir, "ocirepository/default/deleted")).ToNot(BeADirectory())
+	g.Expect(filepath.Join(dir, "helmrepository/default/no-artifact/index.yaml")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(dir, "bucket/default/gone")).ToNot(BeADirectory())
+	g.Expect(filepath.Join(dir, "helmchart/default/recent/a.tgz")).To(BeAnExistingFile())
+
+	for kind, want := range map[string][]float64{
+		sourcev1.GitRepositoryKind:  {1, float64(len("gitrepository/default/suspended/c.tar.gz"))},
+		sourcev1.HelmRepositoryKind: {1, float64(len("helmrepository/default/no-artifact/index.yaml"))},
+		sourcev1.HelmChartKind:      {1, float64(len("helmchart/default/recent/a.tgz"))},
+		sourcev1.BucketKind:         {0, 0},
+		sourcev1.OCIRepositoryKind:  {0, 0},
+	} {
+		g.Expect(testutil.ToFloat64(j.Recorder.countGauge.WithLabelValues(FilesystemBackend, kind))).To(Equal(want[0]), kind)
+		g.Expect(testutil.ToFloat64(j.Recorder.bytesGauge.WithLabelValues(FilesystemBackend, kind))).To(Equal(want[1]), kind)
+	}
+}
+
+func TestStorageJanitor_SweepConcurrency(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	storage, err := NewStorage(dir, "hostname", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	builder := fakeclient.NewClientBuilder().WithScheme(testEnv.GetScheme())
+	var expired []string
+	for i := range 5 {
+		obj := &sourcev1.GitRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("repo-%d", i), Namespace: "default"},
+			Status: sourcev1.GitRepositoryStatus{
+				Artifact: &sourcev1.Artifact{Path: fmt.Sprintf("gitrepository/default/repo-%d/b.tar.gz", i)},
+			},
+		}
+		builder = builder.WithObjects(obj)
+		for name, age := range map[string]time.Duration{"a.tar.gz": 2 * time.Hour, "b.tar.gz": 0} {
+			p := filepath.Join(dir, "gitrepository", "default", obj.Name, name)
+			g.Expect(os.MkdirAll(filepath.Dir(p), 0o700)).To(Succeed())
+			g.Expect(os.WriteFile(p, []byte(name), 0o600)).To(Succeed())
+			mtime := time.Now().Add(-age)
+			g.Expect(os.Chtimes(p, mtime, mtime)).To(Succeed())
+			if age > 0 {
+				expired = append(expired, p)
+			}
+		}
+	}
+
+	eventRecorder := record.NewFakeRecorder(10)
+	j := &StorageJanitor{
+		Client:        builder.Build(),
+		Storage:       storage,
+		Interval:      10 * time.Minute,
+		Concurrency:   4,
+		Timeout:       5 * time.Second,
+		Recorder:      NewArtifactInventoryRecorder(),
+		EventRecorder: eventRecorder,
+	}
+	g.Expect(j.Sweep(context.TODO())).To(Succeed())
+
+	for _, p := range expired {
+		g.Expect(p).ToNot(BeAnExistingFile())
+	}
+	g.Expect(testutil.ToFloat64(j.Recorder.deletedCounter.WithLabelValues(FilesystemBackend, sourcev1.GitRepositoryKind))).To(Equal(float64(5)))
+	g.Expect(testutil.ToFloat64(j.Recorder.countGauge.WithLabelValues(FilesystemBackend, sourcev1.GitRepositoryKind))).To(Equal(float64(5)))
+	g.Expect(eventRecorder.Events).To(HaveLen(5))
+	g.Expect(<-eventRecorder.Events).To(Equal("Trace GarbageCollectionSucceeded garbage collected 1 artifacts"))
+}
+
+func TestStorageJanitor_limiter(t *testing.T) {
+	g := NewWithT(t)
+
+	j := &StorageJanitor{RateLimit: 20}
+	wait := j.limiter()
+	start := time.Now()
+	for range 3 {
+		g.Expect(wait(context.TODO())).To(Succeed())
+	}
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 100*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	g.Expect(wait(ctx)).To(MatchError(context.Canceled))
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+// ArtifactLocker locks the artifacts of the Storage across the replicas
+// writing to it, see Storage.Locker.
+type ArtifactLocker interface {
+	// Lock locks the given v1.Artifact, and returns the function unlocking
+	// it.
+	Lock(artifact v1.Artifact) (unlock func(), err error)
+	// Check returns an error if the lock of the given v1.Artifact, held by
+	// this replica, has been lost since it was acquired.
+	Check(artifact v1.Artifact) error
+}
+
+// ErrArtifactLeaseLost is returned by the write operations of the Storage on
+// an artifact whose Lease has been lost while it was locked.
+var ErrArtifactLeaseLost = errors.New("artifact lease lost")
+
+// ArtifactLeasePathAnnotation is the annotation of an artifact Lease with
+// the path of the locked artifact.
+const ArtifactLeasePathAnnotation = "source.toolkit.fluxcd.io/artifact-path"
+
+// ArtifactLeases locks the artifacts of the Storage with Kubernetes Lease
+// objects in the namespace of the controller, instead of lock files. Unlike
+// lock files, which depend on the file locking of the volume the Storage is
+// on, the Leases are honored by all the replicas with access to the cluster.
+//
+// A Lease is renewed while the lock is held, and deleted once released. The
+// Lease of a replica which stopped renewing it is taken over after its
+// duration. A replica which lost a Lease while holding it can no longer
+// write the artifact, see Check.
+type ArtifactLeases struct {
+	// Client is used to manage the Leases. It should not read from a cache.
+	Client client.Client
+
+	// Namespace is the namespace of the Leases, i.e. the runtime namespace
+	// of the controller, in which it manages its leader election Leases.
+	Namespace string
+
+	// Identity of the replica recorded as the holder of the Leases, e.g. the
+	// Pod name.
+	Identity string
+
+	// LeaseDuration is the duration after which a Lease which has not been
+	// renewed can be taken over by another replica.
+	LeaseDuration time.Duration
+
+	// RetryPeriod is the interval at which a Lease held by another replica
+	// is attempted to be acquired.
+	RetryPeriod time.Duration
+
+	// Timeout is the maximum duration to wait for a Lease.
+	Timeout time.Duration
+
+	mu sync.Mutex
+	// held maps the path of the artifacts locked by this replica to the
+	// context cancelled once their Lease is lost.
+	held map[string]context.Context
+}
+
+// Lock acquires the Lease of the given v1.Artifact, waiting for at most the
+// Timeout if it is held by another holder, and returns the function
+// releasing it.
+func (l *ArtifactLeases) Lock(artifact v1.Artifact) (unlock func(), err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), l.Timeout)
+	defer cancel()
+
+	if artifactPathNamespace(artifact.Path) == "" {
+		return nil, fmt.Errorf("invalid artifact path '%s'", artifact.Path)
+	}
+	key := types.NamespacedName{
+		Namespace: l.Namespace,
+		Name:      artifactLeaseName(artifact.Path),
+	}
+	for {
+		lease, err := l.tryAcquire(ctx, key, artifact.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire lease '%s' for artifact: %w", key, err)
+		}
+		if lease != nil {
+			return l.hold(lease, artifact.Path), nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for lease '%s' for artifact '%s'", key, artifact.Path)
+		case <-time.After(l.RetryPeriod):
+		}
+	}
+}
+
+// tryAcquire creates the Lease with the given key, or takes it over if it is
+// not held. It returns a nil Lease if it is held by another holder.
+func (l *ArtifactLeases) tryAcquire(ctx context.Context, key types.NamespacedName, artifactPath string) (*coordinationv1.Lease, error) {
+	now := metav1.NewMicroTime(time.Now())
+	duration := int32(l.LeaseDuration.Seconds())
+	spec := coordinationv1.LeaseSpec{
+		HolderIdentity:       &l.Identity,
+		LeaseDurationSeconds: &duration,
+		AcquireTime:          &now,
+		RenewTime:            &now,
+	}
+
+	lease := &coordinationv1.Lease{}
+	if err := l.Client.Get(ctx, key, lease); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        key.Name,
+				Namespace:   key.Namespace,
+				Annotations: map[string]string{ArtifactLeasePathAnnotation: artifactPath},
+			},
+			Spec: spec,
+		}
+		if err := l.Client.Create(ctx, lease); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return lease, nil
+	}
+
+	// The Lease is held until it expires, including by this replica, in
+	// which case another goroutine holds it.
+	if lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != "" &&
+		lease.Spec.RenewTime != nil && lease.Spec.LeaseDurationSeconds != nil &&
+		lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds)*time.Second).After(now.Time) {
+		return nil, nil
+	}
+	lease.Spec = spec
+	if err := l.Client.Update(ctx, lease); err != nil {
+		if apierrors.IsConflict(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return lease, nil
+}
+
+// Check returns an error wrapping ErrArtifactLeaseLost if the Lease of the
+// given v1.Artifact, locked by this replica, has been taken over or could not
+// be renewed within its duration.
+func (l *ArtifactLeases) Check(artifact v1.Artifact) error {
+	l.mu.Lock()
+	lost, ok := l.held[artifact.Path]
+	l.mu.Unlock()
+	if ok && lost.Err() != nil {
+		return context.Cause(lost)
+	}
+	return nil
+}
+
+// hold renews the given Lease of the artifact at the given path until the
+// returned function is called, which deletes it. If the Lease is lost in the
+// meantime, Check reports it for the artifact.
+func (l *ArtifactLeases) hold(lease *coordinationv1.Lease, artifactPath string) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	lost, markLost := context.WithCancelCause(context.Background())
+	l.mu.Lock()
+	if l.held == nil {
+		l.held = make(map[string]context.Context)
+	}
+	l.held[artifactPath] = lost
+	l.mu.Unlock()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(l.LeaseDuration / 3)
+		defer ticker.Stop()
+		renewed := time.Now()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				now := metav1.NewMicroTime(time.Now())
+				lease.Spec.RenewTime = &now
+				err := l.Client.Update(ctx, lease)
+				switch {
+				case err == nil:
+					renewed = now.Time
+				case ctx.Err() != nil:
+					return
+				case apierrors.IsConflict(err):
+					markLost(fmt.Errorf("%w: lease '%s/%s' has been taken over", ErrArtifactLeaseLost, lease.Namespace, lease.Name))
+					return
+				case time.Since(renewed) >= l.LeaseDuration:
+					markLost(fmt.Errorf("%w: failed to renew lease '%s/%s': %w", ErrArtifactLeaseLost, lease.Namespace, lease.Name, err))
+					return
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			cancel()
+			wg.Wait()
+			l.mu.Lock()
+			delete(l.held, artifactPath)
+			l.mu.Unlock()
+			markLost(nil)
+			deleteCtx, deleteCancel := context.WithTimeout(context.Background(), l.Timeout)
+			defer deleteCancel()
+			// Only delete the Lease if it has not been taken over, otherwise it
+			// expires.
+			_ = l.Client.Delete(deleteCtx, lease, client.Preconditions{
+				UID:             &lease.UID,
+				ResourceVersion: &lease.ResourceVersion,
+			})
+		})
+	}
+}
+
+// artifactLeaseName returns the name of the Lease of the artifact at the
+// given path.
+func artifactLeaseName(artifactPath string) string {
+	return fmt.Sprintf("artifact-%x", sha256.Sum256([]byte(artifactPath)))
+}
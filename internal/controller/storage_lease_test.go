@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestArtifactLeases_Lock(t *testing.T) {
+	g := NewWithT(t)
+
+	c := fakeclient.NewClientBuilder().WithScheme(testEnv.GetScheme()).Build()
+	newLeases := func(identity string) *ArtifactLeases {
+		return &ArtifactLeases{
+			Client:        c,
+			Namespace:     "flux-system",
+			Identity:      identity,
+			LeaseDuration: time.Minute,
+			RetryPeriod:   10 * time.Millisecond,
+			Timeout:       100 * time.Millisecond,
+		}
+	}
+	a, b := newLeases("a"), newLeases("b")
+
+	artifact := sourcev1.Artifact{Path: sourcev1.ArtifactPath(sourcev1.GitRepositoryKind, "default", "podinfo", "a.tar.gz")}
+	key := types.NamespacedName{Namespace: "flux-system", Name: artifactLeaseName(artifact.Path)}
+
+	unlock, err := a.Lock(artifact)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	lease := &coordinationv1.Lease{}
+	g.Expect(c.Get(context.TODO(), key, lease)).To(Succeed())
+	g.Expect(*lease.Spec.HolderIdentity).To(Equal("a"))
+	g.Expect(lease.Annotations).To(HaveKeyWithValue(ArtifactLeasePathAnnotation, artifact.Path))
+
+	// The Lease is held by another replica, and by another goroutine of the
+	// same replica.
+	_, err = b.Lock(artifact)
+	g.Expect(err).To(MatchError(ContainSubstring("timed out waiting for lease")))
+	_, err = a.Lock(artifact)
+	g.Expect(err).To(HaveOccurred())
+
+	// The Lease is deleted once released.
+	unlock()
+	g.Expect(apierrors.IsNotFound(c.Get(context.TODO(), key, lease))).To(BeTrue())
+
+	unlock, err = b.Lock(artifact)
+	g.Expect(err).ToNot(HaveOccurred())
+	unlock()
+}
+
+func TestArtifactLeases_LockExpired(t *testing.T) {
+	g := NewWithT(t)
+
+	artifact := sourcev1.Artifact{Path: sourcev1.ArtifactPath(sourcev1.GitRepositoryKind, "default", "podinfo", "a.tar.gz")}
+	holder := "crashed"
+	duration := int32(60)
+	renewed := metav1.NewMicroTime(time.Now().Add(-2 * time.Minute))
+	expired := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: artifactLeaseName(artifact.Path)},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &duration,
+			RenewTime:            &renewed,
+		},
+	}
+	c := fakeclient.NewClientBuilder().WithScheme(testEnv.GetScheme()).WithObjects(expired).Build()
+
+	leases := &ArtifactLeases{
+		Client:        c,
+		Namespace:     "flux-system",
+		Identity:      "a",
+		LeaseDuration: time.Minute,
+		RetryPeriod:   10 * time.Millisecond,
+		Timeout:       100 * time.Millisecond,
+	}
+	unlock, err := leases.Lock(artifact)
+	g.Expect(err).ToNot(HaveOccurred())
+	defer unlock()
+
+	lease := &coordinationv1.Lease{}
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: "flux-system", Name: expired.Name}, lease)).To(Succeed())
+	g.Expect(*lease.Spec.HolderIdentity).To(Equal("a"))
+}
+
+func TestArtifactLeases_Lost(t *testing.T) {
+	g := NewWithT(t)
+
+	c := fakeclient.NewClientBuilder().WithScheme(testEnv.GetScheme()).Build()
+	leases := &ArtifactLeases{
+		Client:        c,
+		Namespace:     "flux-system",
+		Identity:      "a",
+		LeaseDuration: 30 * time.Millisecond,
+		RetryPeriod:   10 * time.Millisecond,
+		Timeout:       100 * time.Millisecond,
+	}
+
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	storage.Locker = leases
+
+	artifact := storage.NewArtifactFor(sourcev1.GitRepositoryKind, &metav1.ObjectMeta{Namespace: "default", Name: "podinfo"}, "", "a.txt")
+	g.Expect(storage.MkdirAll(artifact)).To(Succeed())
+
+	unlock, err := storage.Lock(artifact)
+	g.Expect(err).ToNot(HaveOccurred())
+	defer unlock()
+	g.Expect(leases.Check(artifact)).To(Succeed())
+	g.Expect(storage.AtomicWriteFile(&artifact, strings.NewReader("a"), 0o600)).To(Succeed())
+
+	// Another replica takes the Lease over.
+	lease := &coordinationv1.Lease{}
+	key := types.NamespacedName{Namespace: "flux-system", Name: artifactLeaseName(artifact.Path)}
+	g.Expect(c.Get(context.TODO(), key, lease)).To(Succeed())
+	holder := "b"
+	lease.Spec.HolderIdentity = &holder
+	g.Expect(c.Update(context.TODO(), lease)).To(Succeed())
+
+	g.Eventually(func() error {
+		return leases.Check(artifact)
+	}).WithTimeout(time.Second).Should(MatchError(ErrArtifactLeaseLost))
+	g.Expect(storage.AtomicWriteFile(&artifact, strings.NewReader("b"), 0o600)).To(MatchError(ErrArtifactLeaseLost))
+
+	// The Lease of another replica is not deleted once released.
+	unlock()
+	g.Expect(c.Get(context.TODO(), key, lease)).To(Succeed())
+	g.Expect(*lease.Spec.HolderIdentity).To(Equal("b"))
+	g.Expect(leases.Check(artifact)).To(Succeed())
+}
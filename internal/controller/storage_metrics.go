@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// The operations of the Storage recorded by the StorageMetrics.
+const (
+	StorageOperationStore    = "store"
+	StorageOperationRetrieve = "retrieve"
+	StorageOperationDelete   = "delete"
+	StorageOperationGC       = "gc"
+	StorageOperationLock     = "lock"
+)
+
+// StorageMetrics records the duration and the failures of the Storage
+// operations, and the number of bytes written to and read from the Storage.
+//
+// All methods are safe to call on a nil StorageMetrics, in which case
+// nothing is recorded.
+type StorageMetrics struct {
+	durationHistogram *prometheus.HistogramVec
+	errorsCounter     *prometheus.CounterVec
+	bytesCounter      *prometheus.CounterVec
+}
+
+// NewStorageMetrics returns a new StorageMetrics. The configured labels are:
+// backend, operation for the duration and errors, and backend, direction for
+// the bytes.
+func NewStorageMetrics() *StorageMetrics {
+	return &StorageMetrics{
+		durationHistogram: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "gotk_storage_operation_duration_seconds",
+				Help:    "The duration in seconds of the storage operations.",
+				Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+			},
+			[]string{"backend", "operation"},
+		),
+		errorsCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "gotk_storage_operation_errors_total",
+				Help: "Total number of failed storage operations.",
+			},
+			[]string{"backend", "operation"},
+		),
+		bytesCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "gotk_storage_bytes_total",
+				Help: "Total number of bytes written to (direction=write) and read from (direction=read) the storage.",
+			},
+			[]string{"backend", "direction"},
+		),
+	}
+}
+
+// Collectors returns the metrics.Collector objects for the StorageMetrics.
+func (m *StorageMetrics) Collectors() []prometheus.Collector {
+	if m == nil {
+		return nil
+	}
+	return []prometheus.Collector{
+		m.durationHistogram,
+		m.errorsCounter,
+		m.bytesCounter,
+	}
+}
+
+// MustMakeStorageMetrics creates a new StorageMetrics, and registers the
+// metrics collectors in the controller-runtime metrics registry.
+func MustMakeStorageMetrics() *StorageMetrics {
+	m := NewStorageMetrics()
+	metrics.Registry.MustRegister(m.Collectors()...)
+	return m
+}
+
+// observe records the duration of the given operation of the given backend
+// since start, and its failure if the error the given pointer refers to is
+// not nil. It is meant to be deferred with a pointer to a named error
+// result.
+func (m *StorageMetrics) observe(backend, operation string, start time.Time, err *error) {
+	if m == nil {
+		return
+	}
+	m.durationHistogram.WithLabelValues(backend, operation).Observe(time.Since(start).Seconds())
+	if err != nil && *err != nil {
+		m.errorsCounter.WithLabelValues(backend, operation).Inc()
+	}
+}
+
+// written records the given number of bytes written to the given backend.
+func (m *StorageMetrics) written(backend string, n int64) {
+	if m == nil {
+		return
+	}
+	m.bytesCounter.WithLabelValues(backend, "write").Add(float64(n))
+}
+
+// read records the given number of bytes read from the given backend.
+func (m *StorageMetrics) read(backend string, n int64) {
+	if m == nil {
+		return
+	}
+	m.bytesCounter.WithLabelValues(backend, "read").Add(float64(n))
+}
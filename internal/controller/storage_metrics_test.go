@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestStorageMetrics(t *testing.T) {
+	g := NewWithT(t)
+
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	storage.Metrics = NewStorageMetrics()
+	backend := storage.Backend()
+
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "file"), []byte("content"), 0o600)).To(Succeed())
+
+	artifact := storage.NewArtifactFor(sourcev1.GitRepositoryKind, &metav1.ObjectMeta{Namespace: "default", Name: "podinfo"}, "", "a.tar.gz")
+	g.Expect(storage.MkdirAll(artifact)).To(Succeed())
+	g.Expect(storage.Archive(&artifact, dir, nil)).To(Succeed())
+	g.Expect(testutil.ToFloat64(storage.Metrics.bytesCounter.WithLabelValues(backend, "write"))).To(Equal(float64(*artifact.Size)))
+
+	g.Expect(storage.CopyToPath(&artifact, "file", filepath.Join(t.TempDir(), "file"))).To(Succeed())
+	g.Expect(testutil.ToFloat64(storage.Metrics.bytesCounter.WithLabelValues(backend, "read"))).To(BeNumerically(">", 0))
+
+	missing := storage.NewArtifactFor(sourcev1.GitRepositoryKind, &metav1.ObjectMeta{Namespace: "default", Name: "missing"}, "", "a.tar.gz")
+	g.Expect(storage.Remove(missing)).ToNot(Succeed())
+	g.Expect(testutil.ToFloat64(storage.Metrics.errorsCounter.WithLabelValues(backend, StorageOperationDelete))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(storage.Metrics.errorsCounter.WithLabelValues(backend, StorageOperationStore))).To(BeZero())
+
+	g.Expect(testutil.CollectAndCount(storage.Metrics.durationHistogram)).To(Equal(3))
+
+	// A Storage without metrics records nothing.
+	storage.Metrics = nil
+	g.Expect(storage.Archive(&artifact, dir, nil)).To(Succeed())
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/fluxcd/source-controller/internal/cache"
+)
+
+// RemoveNamespace removes all the files stored for the given namespace,
+// regardless of whether the Source objects they belong to still exist. It
+// returns the paths of the removed files relative to the Storage base path,
+// including the ones removed before an error occurred.
+func (s Storage) RemoveNamespace(namespace string) ([]string, error) {
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid namespace '%s': %s", namespace, strings.Join(errs, ", "))
+	}
+	if err := s.Fence.Check(); err != nil {
+		return nil, err
+	}
+
+	// The Storage layout is <kind>/<namespace>/<name>/<file>, see
+	// v1.ArtifactPath.
+	kinds, err := os.ReadDir(s.BasePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list storage kinds: %w", err)
+	}
+
+	var dirs []string
+	for _, kind := range kinds {
+		if !kind.IsDir() || kind.Name() == ContentDir {
+			// The stored content is removed by CollectContent once no
+			// artifact links to it.
+			continue
+		}
+		if kind.Name() == QuarantineDir {
+			// The quarantine mirrors the layout of the Storage.
+			quarantined, err := filepath.Glob(filepath.Join(s.BasePath, QuarantineDir, "*", namespace))
+			if err != nil {
+				return nil, fmt.Errorf("failed to list quarantine kinds: %w", err)
+			}
+			dirs = append(dirs, quarantined...)
+			continue
+		}
+		dirs = append(dirs, filepath.Join(s.BasePath, kind.Name(), namespace))
+	}
+
+	var removed []string
+	var errs []error
+	for _, dir := range dirs {
+		if _, err := os.Lstat(dir); err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		var files []string
+		if err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(s.BasePath, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+			return nil
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to list files in '%s': %w", dir, err))
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove '%s': %w", dir, err))
+			continue
+		}
+		removed = append(removed, files...)
+	}
+	sort.Strings(removed)
+	s.purge(removed...)
+	return removed, kerrors.NewAggregate(errs)
+}
+
+// NamespaceOffboardingReport lists what was removed for a namespace.
+type NamespaceOffboardingReport struct {
+	// Namespace which was offboarded.
+	Namespace string `json:"namespace"`
+	// Files are the removed Storage paths.
+	Files []string `json:"files"`
+	// CacheKeys are the keys of the removed Helm repository index cache
+	// entries.
+	CacheKeys []string `json:"cacheKeys"`
+	// Error is set when not everything could be removed.
+	Error string `json:"error,omitempty"`
+}
+
+// NamespaceOffboarder removes all the data of a namespace from the Storage
+// and the Helm repository index cache. It serves the
+// DELETE /namespaces/{namespace} endpoint of the admin API.
+//
+// Source objects which still exist in the namespace store a new Artifact on
+// their next reconciliation, they should be deleted (or suspended) before.
+type NamespaceOffboarder struct {
+	Storage *Storage
+	// Cache is the Helm repository index cache, it may be nil.
+	Cache *cache.Cache
+}
+
+// Offboard removes the files and cache entries of the given namespace.
+func (o *NamespaceOffboarder) Offboard(namespace string) (NamespaceOffboardingReport, error) {
+	report := NamespaceOffboardingReport{
+		Namespace: namespace,
+		Files:     []string{},
+		CacheKeys: []string{},
+	}
+	files, err := o.Storage.RemoveNamespace(namespace)
+	if files != nil {
+		report.Files = files
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+	// The index cache is keyed by the HelmRepository Artifact path.
+	if o.Cache != nil {
+		if keys := o.Cache.DeleteFunc(func(key string) bool {
+			return artifactPathNamespace(key) == namespace
+		}); keys != nil {
+			sort.Strings(keys)
+			report.CacheKeys = keys
+		}
+	}
+	return report, err
+}
+
+// ServeHTTP handles DELETE /namespaces/{namespace} requests, and writes the
+// NamespaceOffboardingReport as JSON.
+func (o *NamespaceOffboarder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		http.Error(w, fmt.Sprintf("invalid namespace '%s': %s", namespace, strings.Join(errs, ", ")), http.StatusBadRequest)
+		return
+	}
+
+	log := ctrl.Log.WithName("offboarding").WithValues("namespace", namespace)
+	report, err := o.Offboard(namespace)
+	status := http.StatusOK
+	if err != nil {
+		log.Error(err, "failed to offboard namespace")
+		status = http.StatusInternalServerError
+	} else {
+		log.Info("offboarded namespace", "files", len(report.Files), "cacheKeys", len(report.CacheKeys))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(report)
+}
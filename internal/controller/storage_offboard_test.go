@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/fluxcd/source-controller/internal/cache"
+)
+
+func TestNamespaceOffboarder(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	for _, p := range []string{
+		"gitrepository/team-a/podinfo/a.tar.gz",
+		"helmrepository/team-a/podinfo/index-abc.yaml",
+		"gitrepository/team-b/podinfo/a.tar.gz",
+	} {
+		g.Expect(os.MkdirAll(filepath.Join(dir, filepath.Dir(p)), 0o700)).To(Succeed())
+		g.Expect(os.WriteFile(filepath.Join(dir, p), []byte("content"), 0o600)).To(Succeed())
+	}
+	storage, err := NewStorage(dir, "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	c := cache.New(10, 0)
+	g.Expect(c.Set("helmrepository/team-a/podinfo/index-abc.yaml", "index", 0)).To(Succeed())
+	g.Expect(c.Set("helmrepository/team-b/podinfo/index-def.yaml", "index", 0)).To(Succeed())
+
+	mux := http.NewServeMux()
+	mux.Handle("DELETE /namespaces/{namespace}", &NamespaceOffboarder{Storage: storage, Cache: c})
+
+	req := httptest.NewRequest(http.MethodDelete, "/namespaces/team-a", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+
+	var report NamespaceOffboardingReport
+	g.Expect(json.NewDecoder(rec.Body).Decode(&report)).To(Succeed())
+	g.Expect(report).To(Equal(NamespaceOffboardingReport{
+		Namespace: "team-a",
+		Files: []string{
+			"gitrepository/team-a/podinfo/a.tar.gz",
+			"helmrepository/team-a/podinfo/index-abc.yaml",
+		},
+		CacheKeys: []string{"helmrepository/team-a/podinfo/index-abc.yaml"},
+	}))
+
+	g.Expect(filepath.Join(dir, "gitrepository", "team-a")).ToNot(BeAnExistingFile())
+	g.Expect(filepath.Join(dir, "helmrepository", "team-a")).ToNot(BeAnExistingFile())
+	g.Expect(filepath.Join(dir, "gitrepository", "team-b", "podinfo", "a.tar.gz")).To(BeARegularFile())
+	g.Expect(c.ItemCount()).To(Equal(1))
+
+	// Offboarding is idempotent.
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/namespaces/team-a", nil))
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	report = NamespaceOffboardingReport{}
+	g.Expect(json.NewDecoder(rec.Body).Decode(&report)).To(Succeed())
+	g.Expect(report.Files).To(BeEmpty())
+	g.Expect(report.CacheKeys).To(BeEmpty())
+}
+
+func TestStorage_RemoveNamespace_Invalid(t *testing.T) {
+	g := NewWithT(t)
+
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = storage.RemoveNamespace("..")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid namespace '..'")))
+}
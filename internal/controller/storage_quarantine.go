@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kuberecorder "k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fluxcd/pkg/sourceignore"
+
+	v1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+// QuarantineDir is the directory of the Storage in which the content of the
+// Sources which failed verification is stored. Its layout is
+// quarantine/<kind>/<namespace>/<name>/<file>.
+const QuarantineDir = "quarantine"
+
+// QuarantineArtifact archives the given directory to the quarantine area of
+// the Storage, excluding the files matched by the given ArchiveFileFilter, in
+// place of any content previously quarantined for the object of the given
+// v1.Artifact, and returns the quarantined v1.Artifact. Its URL
+// is served by the file server for inspection, but must not be advertised in
+// the status of the object. The quarantine of an object is removed together
+// with its artifacts by RemoveAll.
+func (s Storage) QuarantineArtifact(artifact v1.Artifact, dir string, filter ArchiveFileFilter) (*v1.Artifact, error) {
+	quarantined := quarantinedArtifact(artifact)
+	if err := s.MkdirAll(*quarantined); err != nil {
+		return nil, fmt.Errorf("failed to create quarantine directory: %w", err)
+	}
+	if err := s.Archive(quarantined, dir, filter); err != nil {
+		return nil, fmt.Errorf("failed to archive quarantined content: %w", err)
+	}
+	if _, err := s.RemoveAllButCurrent(*quarantined); err != nil {
+		return nil, fmt.Errorf("failed to remove previously quarantined content: %w", err)
+	}
+	s.SetArtifactURL(quarantined)
+	return quarantined, nil
+}
+
+// IsQuarantined returns true if the content of the given v1.Artifact is in
+// the quarantine area of the Storage.
+func (s Storage) IsQuarantined(artifact v1.Artifact) bool {
+	return s.ArtifactExist(*quarantinedArtifact(artifact))
+}
+
+// quarantinedArtifact returns a copy of the given v1.Artifact with its path
+// in the quarantine area.
+func quarantinedArtifact(artifact v1.Artifact) *v1.Artifact {
+	quarantined := artifact.DeepCopy()
+	quarantined.Path = path.Join(QuarantineDir, artifact.Path)
+	return quarantined
+}
+
+// removeQuarantine removes the quarantine directory of the object of the
+// given v1.Artifact.
+func (s Storage) removeQuarantine(artifact v1.Artifact) error {
+	if artifact.Path == "" {
+		return nil
+	}
+	localPath := s.LocalPath(v1.Artifact{Path: path.Join(QuarantineDir, artifact.Path)})
+	if localPath == "" {
+		return nil
+	}
+	return os.RemoveAll(filepath.Dir(localPath))
+}
+
+// quarantineUnverified stores the given directory with the content of the
+// given revision of the object in the quarantine area of the Storage, if
+// enabled, and records a warning event with its URL. The content is filtered
+// like the Artifact would be, with the ignore rules of the directory and the
+// given ignore rules of the object. A revision which is already quarantined
+// is not stored again. Failures are only logged, as the verification failure
+// is the error to report.
+func quarantineUnverified(ctx context.Context, storage *Storage, recorder kuberecorder.EventRecorder,
+	kind string, obj client.Object, revision, fileName, dir string, ignore *string) {
+	if storage == nil || !storage.QuarantineUnverified {
+		return
+	}
+	artifact := storage.NewArtifactFor(kind, obj, revision, fileName)
+	if storage.IsQuarantined(artifact) {
+		return
+	}
+
+	ignoreDomain := strings.Split(dir, string(filepath.Separator))
+	ps, err := sourceignore.LoadIgnorePatterns(dir, ignoreDomain)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to load source ignore patterns of unverified content", "revision", revision)
+		return
+	}
+	if ignore != nil {
+		ps = append(ps, sourceignore.ReadPatterns(strings.NewReader(*ignore), ignoreDomain)...)
+	}
+
+	quarantined, err := storage.QuarantineArtifact(artifact, dir, SourceIgnoreFilter(ps, ignoreDomain))
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to quarantine unverified content", "revision", revision)
+		return
+	}
+	recorder.Eventf(obj, corev1.EventTypeWarning, v1.ArtifactQuarantinedReason,
+		"content of revision '%s' which failed verification is quarantined for inspection at %s", revision, quarantined.URL)
+}
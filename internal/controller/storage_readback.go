@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+
+	v1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+// readBackRetryInterval is the interval between the attempts to read back an
+// artifact.
+const readBackRetryInterval = 500 * time.Millisecond
+
+// VerifyReadBack downloads the given v1.Artifact from its URL and verifies
+// its digest, guaranteeing that consumers are able to fetch the Artifact
+// before it is advertised. Failed attempts are retried until the
+// ReadBackTimeout has elapsed. It is a no-op when ReadBackTimeout is 0.
+func (s Storage) VerifyReadBack(ctx context.Context, artifact v1.Artifact) error {
+	if s.ReadBackTimeout <= 0 {
+		return nil
+	}
+
+	d, err := digest.Parse(artifact.Digest)
+	if err != nil {
+		return fmt.Errorf("failed to parse artifact digest '%s': %w", artifact.Digest, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.ReadBackTimeout)
+	defer cancel()
+	for {
+		err := readBack(ctx, artifact.URL, d)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to read back artifact from '%s': %w", artifact.URL, err)
+		case <-time.After(readBackRetryInterval):
+		}
+	}
+}
+
+// readBack downloads the file at the given URL, and verifies it matches the
+// given digest.
+func readBack(ctx context.Context, url string, d digest.Digest) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	verifier := d.Verifier()
+	if _, err := io.Copy(verifier, resp.Body); err != nil {
+		return err
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("computed digest doesn't match '%s'", d.String())
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestStorage_VerifyReadBack(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte("artifact content")
+	g := NewWithT(t)
+	g.Expect(os.MkdirAll(filepath.Join(dir, "gitrepository", "default", "podinfo"), 0o700)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "gitrepository", "default", "podinfo", "a.tar.gz"), content, 0o600)).To(Succeed())
+
+	server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		path    string
+		digest  string
+		wantErr string
+	}{
+		{
+			name:    "matching digest",
+			timeout: time.Second,
+			path:    "gitrepository/default/podinfo/a.tar.gz",
+			digest:  digest.FromBytes(content).String(),
+		},
+		{
+			name:    "mismatching digest",
+			timeout: time.Second,
+			path:    "gitrepository/default/podinfo/a.tar.gz",
+			digest:  digest.FromString("other").String(),
+			wantErr: "computed digest doesn't match",
+		},
+		{
+			name:    "not found",
+			timeout: time.Second,
+			path:    "gitrepository/default/podinfo/b.tar.gz",
+			digest:  digest.FromBytes(content).String(),
+			wantErr: "unexpected status code 404",
+		},
+		{
+			name:   "disabled",
+			path:   "gitrepository/default/podinfo/b.tar.gz",
+			digest: digest.FromString("other").String(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			storage, err := NewStorage(dir, server.URL, time.Minute, 2)
+			g.Expect(err).ToNot(HaveOccurred())
+			storage.ReadBackTimeout = tt.timeout
+
+			artifact := sourcev1.Artifact{Path: tt.path, Digest: tt.digest}
+			storage.SetArtifactURL(&artifact)
+
+			err = storage.VerifyReadBack(context.TODO(), artifact)
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
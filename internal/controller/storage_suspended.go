@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kuberecorder "k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SuspendedRetention is the retention policy of the Artifacts of suspended
+// Source objects. It is applied when a suspended object is reconciled, e.g.
+// right after it got suspended, and by the StorageJanitor sweeps.
+type SuspendedRetention string
+
+const (
+	// SuspendedRetentionRetain keeps all the Artifacts of suspended objects
+	// until they are resumed.
+	SuspendedRetentionRetain SuspendedRetention = "retain"
+	// SuspendedRetentionPrune garbage collects the Artifacts of suspended
+	// objects according to the retention TTL and records of the Storage, as
+	// for the objects being reconciled. This is the default.
+	SuspendedRetentionPrune SuspendedRetention = "prune"
+	// SuspendedRetentionAggressive removes all but the current and the
+	// pinned Artifacts of suspended objects, regardless of their age.
+	SuspendedRetentionAggressive SuspendedRetention = "aggressive"
+)
+
+// SuspendedArtifactsPrunedReason signals that Artifacts of a suspended
+// object were garbage collected according to the SuspendedRetention policy.
+const SuspendedArtifactsPrunedReason = "SuspendedArtifactsPruned"
+
+// ParseSuspendedRetention returns the SuspendedRetention policy with the
+// given name. An empty name is SuspendedRetentionPrune.
+func ParseSuspendedRetention(name string) (SuspendedRetention, error) {
+	switch p := SuspendedRetention(name); p {
+	case "":
+		return SuspendedRetentionPrune, nil
+	case SuspendedRetentionRetain, SuspendedRetentionPrune, SuspendedRetentionAggressive:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unsupported suspended artifact retention policy '%s', must be one of: %s, %s, %s",
+			name, SuspendedRetentionRetain, SuspendedRetentionPrune, SuspendedRetentionAggressive)
+	}
+}
+
+// collectSuspended garbage collects the Artifacts of the given suspended
+// object according to the SuspendedRetention policy of the Storage. The
+// removal is recorded with an event when a recorder is given.
+func collectSuspended(ctx context.Context, storage *Storage, recorder kuberecorder.EventRecorder,
+	obj artifactSource, timeout time.Duration) error {
+	artifact := obj.GetArtifact()
+	if storage == nil || artifact == nil {
+		return nil
+	}
+
+	policy, err := ParseSuspendedRetention(string(storage.SuspendedRetention))
+	if err != nil {
+		return err
+	}
+	gc := storage.retentionFor(obj)
+	switch policy {
+	case SuspendedRetentionRetain:
+		return nil
+	case SuspendedRetentionAggressive:
+		gc.ArtifactRetentionTTL = 0
+		gc.ArtifactRetentionRecords = 1
+	}
+
+	deleted, err := gc.GarbageCollect(ctx, *artifact, timeout, obj.GetPinnedArtifacts()...)
+	if err != nil {
+		return fmt.Errorf("failed to garbage collect artifacts of suspended '%s/%s': %w",
+			obj.GetNamespace(), obj.GetName(), err)
+	}
+	if len(deleted) > 0 {
+		ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("garbage collected %d artifacts of suspended object", len(deleted)),
+			"namespace", obj.GetNamespace(), "name", obj.GetName(), "policy", policy)
+		if recorder != nil {
+			recorder.Eventf(obj, corev1.EventTypeNormal, SuspendedArtifactsPrunedReason,
+				"garbage collected %d artifacts of suspended object with the '%s' retention policy",
+				len(deleted), policy)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestCollectSuspended(t *testing.T) {
+	tests := []struct {
+		policy     SuspendedRetention
+		wantFiles  []string
+		wantPruned bool
+	}{
+		{
+			policy:    SuspendedRetentionRetain,
+			wantFiles: []string{"1.tar.gz", "2.tar.gz", "3.tar.gz", "4.tar.gz"},
+		},
+		{
+			policy:     SuspendedRetentionPrune,
+			wantFiles:  []string{"2.tar.gz", "3.tar.gz", "4.tar.gz"},
+			wantPruned: true,
+		},
+		{
+			policy:     "",
+			wantFiles:  []string{"2.tar.gz", "3.tar.gz", "4.tar.gz"},
+			wantPruned: true,
+		},
+		{
+			policy:     SuspendedRetentionAggressive,
+			wantFiles:  []string{"2.tar.gz", "4.tar.gz"},
+			wantPruned: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			g := NewWithT(t)
+
+			storage, err := NewStorage(t.TempDir(), "localhost", time.Hour, 2)
+			g.Expect(err).ToNot(HaveOccurred())
+			storage.SuspendedRetention = tt.policy
+
+			obj := &sourcev1.GitRepository{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "podinfo"},
+				Spec:       sourcev1.GitRepositorySpec{Suspend: true},
+			}
+			for i := 1; i <= 4; i++ {
+				artifact := storage.NewArtifactFor(sourcev1.GitRepositoryKind, obj, "", fmt.Sprintf("%d.tar.gz", i))
+				g.Expect(storage.MkdirAll(artifact)).To(Succeed())
+				g.Expect(os.WriteFile(storage.LocalPath(artifact), []byte("content"), 0o600)).To(Succeed())
+				mtime := time.Now().Add(time.Duration(i-4) * time.Minute)
+				g.Expect(os.Chtimes(storage.LocalPath(artifact), mtime, mtime)).To(Succeed())
+				switch i {
+				case 2:
+					obj.Status.PinnedArtifacts = []sourcev1.Artifact{artifact}
+				case 4:
+					obj.Status.Artifact = &artifact
+				}
+			}
+
+			recorder := record.NewFakeRecorder(8)
+			g.Expect(collectSuspended(context.TODO(), storage, recorder, obj, time.Second)).To(Succeed())
+
+			var files []string
+			entries, err := os.ReadDir(filepath.Dir(storage.LocalPath(*obj.Status.Artifact)))
+			g.Expect(err).ToNot(HaveOccurred())
+			for _, e := range entries {
+				files = append(files, e.Name())
+			}
+			g.Expect(files).To(Equal(tt.wantFiles))
+			if tt.wantPruned {
+				g.Expect(recorder.Events).To(Receive(ContainSubstring(SuspendedArtifactsPrunedReason)))
+			} else {
+				g.Expect(recorder.Events).ToNot(Receive())
+			}
+		})
+	}
+}
+
+func TestParseSuspendedRetention(t *testing.T) {
+	g := NewWithT(t)
+
+	policy, err := ParseSuspendedRetention("")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(policy).To(Equal(SuspendedRetentionPrune))
+
+	policy, err = ParseSuspendedRetention("aggressive")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(policy).To(Equal(SuspendedRetentionAggressive))
+
+	_, err = ParseSuspendedRetention("forever")
+	g.Expect(err).To(HaveOccurred())
+}
@@ -32,8 +32,12 @@ import (
 
 	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/fluxcd/source-controller/pkg/fetch"
 )
 
 func TestStorageConstructor(t *testing.T) {
@@ -60,6 +64,137 @@ func TestStorageConstructor(t *testing.T) {
 	}
 }
 
+func TestStorage_SetArtifactURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		hostname     string
+		virtualHosts map[string]string
+		httpsOnly    bool
+		path         string
+		want         string
+	}{
+		{
+			name:     "hostname",
+			hostname: "source-controller.flux-system.svc",
+			path:     "gitrepository/default/app/latest.tar.gz",
+			want:     "http://source-controller.flux-system.svc/gitrepository/default/app/latest.tar.gz",
+		},
+		{
+			name:     "hostname with scheme",
+			hostname: "https://source-controller.flux-system.svc",
+			path:     "gitrepository/default/app/latest.tar.gz",
+			want:     "https://source-controller.flux-system.svc/gitrepository/default/app/latest.tar.gz",
+		},
+		{
+			name:         "virtual host of namespace",
+			hostname:     "source-controller.flux-system.svc",
+			virtualHosts: map[string]string{"tenant-a": "tenant-a.artifacts.svc"},
+			path:         "gitrepository/tenant-a/app/latest.tar.gz",
+			want:         "http://tenant-a.artifacts.svc/gitrepository/tenant-a/app/latest.tar.gz",
+		},
+		{
+			name:         "virtual host inherits scheme",
+			hostname:     "https://source-controller.flux-system.svc",
+			virtualHosts: map[string]string{"tenant-a": "tenant-a.artifacts.svc"},
+			path:         "gitrepository/tenant-a/app/latest.tar.gz",
+			want:         "https://tenant-a.artifacts.svc/gitrepository/tenant-a/app/latest.tar.gz",
+		},
+		{
+			name:      "https only",
+			hostname:  "source-controller.flux-system.svc",
+			httpsOnly: true,
+			path:      "gitrepository/default/app/latest.tar.gz",
+			want:      "https://source-controller.flux-system.svc/gitrepository/default/app/latest.tar.gz",
+		},
+		{
+			name:         "virtual host of other namespace",
+			hostname:     "source-controller.flux-system.svc",
+			virtualHosts: map[string]string{"tenant-a": "tenant-a.artifacts.svc"},
+			path:         "gitrepository/tenant-b/app/latest.tar.gz",
+			want:         "http://source-controller.flux-system.svc/gitrepository/tenant-b/app/latest.tar.gz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := Storage{Hostname: tt.hostname, VirtualHosts: tt.virtualHosts, HTTPSOnly: tt.httpsOnly}
+			artifact := &sourcev1.Artifact{Path: tt.path}
+			s.SetArtifactURL(artifact)
+			g.Expect(artifact.URL).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestStorage_SetHostname(t *testing.T) {
+	g := NewWithT(t)
+
+	s := Storage{
+		Hostname:     "source-controller.flux-system.svc",
+		VirtualHosts: map[string]string{"tenant-a": "https://tenant-a.artifacts.svc"},
+	}
+	g.Expect(s.SetHostname("http://old/helmrepository/default/repo/index.yaml")).
+		To(Equal("http://source-controller.flux-system.svc/helmrepository/default/repo/index.yaml"))
+	g.Expect(s.SetHostname("http://old/helmrepository/tenant-a/repo/index.yaml")).
+		To(Equal("https://tenant-a.artifacts.svc/helmrepository/tenant-a/repo/index.yaml"))
+
+	s.Hostname = "https://source-controller.flux-system.svc"
+	g.Expect(s.SetHostname("http://old/helmrepository/default/repo/index.yaml")).
+		To(Equal("https://source-controller.flux-system.svc/helmrepository/default/repo/index.yaml"))
+
+	s.Hostname = "source-controller.flux-system.svc"
+	s.HTTPSOnly = true
+	g.Expect(s.SetHostname("http://old/helmrepository/default/repo/index.yaml")).
+		To(Equal("https://source-controller.flux-system.svc/helmrepository/default/repo/index.yaml"))
+}
+
+func TestStorage_ValidateHTTPSOnly(t *testing.T) {
+	tests := []struct {
+		name         string
+		httpsOnly    bool
+		hostname     string
+		virtualHosts map[string]string
+		wantErr      string
+	}{
+		{
+			name:     "disabled",
+			hostname: "http://source-controller.flux-system.svc",
+		},
+		{
+			name:      "hostname without scheme",
+			httpsOnly: true,
+			hostname:  "source-controller.flux-system.svc",
+		},
+		{
+			name:      "hostname with http scheme",
+			httpsOnly: true,
+			hostname:  "http://source-controller.flux-system.svc",
+			wantErr:   "hostname 'http://source-controller.flux-system.svc' is not HTTPS",
+		},
+		{
+			name:         "virtual host with http scheme",
+			httpsOnly:    true,
+			hostname:     "https://source-controller.flux-system.svc",
+			virtualHosts: map[string]string{"tenant-a": "http://tenant-a.artifacts.svc"},
+			wantErr:      "virtual host 'http://tenant-a.artifacts.svc' of namespace 'tenant-a' is not HTTPS",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := Storage{Hostname: tt.hostname, VirtualHosts: tt.virtualHosts, HTTPSOnly: tt.httpsOnly}
+			err := s.ValidateHTTPSOnly()
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
 // walks a tar.gz and looks for paths with the basename. It does not match
 // symlinks properly at this time because that's painful.
 func walkTar(tarFile string, match string, dir bool) (int64, int64, bool, error) {
@@ -101,6 +236,24 @@ func walkTar(tarFile string, match string, dir bool) (int64, int64, bool, error)
 	return 0, 0, false, nil
 }
 
+func TestStorage_Healthy(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	storage, err := NewStorage(dir, "hostname", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(storage.Healthy()).To(Succeed())
+	entries, err := os.ReadDir(dir)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entries).To(BeEmpty())
+
+	g.Expect(os.RemoveAll(dir)).To(Succeed())
+	err = storage.Healthy()
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("is not writable"))
+}
+
 func TestStorage_Archive(t *testing.T) {
 	dir := t.TempDir()
 
@@ -698,6 +851,62 @@ func TestStorage_getGarbageFiles(t *testing.T) {
 	}
 }
 
+func TestStorage_getGarbageFilesSameModTime(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+
+	s, err := NewStorage(dir, "hostname", time.Hour, 2)
+	g.Expect(err).ToNot(HaveOccurred(), "failed to create new storage")
+
+	artifactFolder := filepath.Join("foo", "bar")
+	g.Expect(os.MkdirAll(filepath.Join(dir, artifactFolder), 0o750)).To(Succeed())
+	modTime := time.Now().Add(-time.Minute)
+	for i := 1; i <= 5; i++ {
+		path := filepath.Join(dir, artifactFolder, fmt.Sprintf("artifact%d.tar.gz", i))
+		g.Expect(os.WriteFile(path, nil, 0o600)).To(Succeed())
+		g.Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+	}
+
+	artifact := sourcev1.Artifact{Path: filepath.Join(artifactFolder, "artifact5.tar.gz")}
+	deletedPaths, err := s.getGarbageFiles(artifact, 10, 2, time.Hour)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(deletedPaths).To(HaveLen(3))
+	g.Expect(deletedPaths).ToNot(ContainElement(s.LocalPath(artifact)))
+}
+
+func BenchmarkStorage_getGarbageFiles(b *testing.B) {
+	dir := b.TempDir()
+	s, err := NewStorage(dir, "hostname", time.Hour, 2)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	artifactFolder := filepath.Join("foo", "bar")
+	if err := os.MkdirAll(filepath.Join(dir, artifactFolder), 0o750); err != nil {
+		b.Fatal(err)
+	}
+	const revisions = 1000
+	start := time.Now().Add(-revisions * time.Second)
+	for i := 0; i < revisions; i++ {
+		path := filepath.Join(dir, artifactFolder, fmt.Sprintf("artifact%d.tar.gz", i))
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			b.Fatal(err)
+		}
+		modTime := start.Add(time.Duration(i) * time.Second)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			b.Fatal(err)
+		}
+	}
+	artifact := sourcev1.Artifact{Path: filepath.Join(artifactFolder, fmt.Sprintf("artifact%d.tar.gz", revisions-1))}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.getGarbageFiles(artifact, revisions+1, 2, time.Hour); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func TestStorage_GarbageCollect(t *testing.T) {
 	artifactFolder := filepath.Join("foo", "bar")
 	tests := []struct {
@@ -794,6 +1003,39 @@ func TestStorage_GarbageCollect(t *testing.T) {
 	}
 }
 
+func TestStorage_GarbageCollectMaxSize(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	s, err := NewStorage(dir, "hostname", time.Hour, 10)
+	g.Expect(err).ToNot(HaveOccurred())
+	s.ArtifactRetentionMaxSize = 25
+
+	now := time.Now()
+	var artifacts []sourcev1.Artifact
+	for i := 0; i < 4; i++ {
+		artifact := sourcev1.Artifact{Path: fmt.Sprintf("gitrepository/default/podinfo/artifact%d.tar.gz", i)}
+		g.Expect(os.MkdirAll(filepath.Dir(s.LocalPath(artifact)), 0o750)).To(Succeed())
+		g.Expect(os.WriteFile(s.LocalPath(artifact), bytes.Repeat([]byte("a"), 10), 0o600)).To(Succeed())
+		modTime := now.Add(time.Duration(i-4) * time.Minute)
+		g.Expect(os.Chtimes(s.LocalPath(artifact), modTime, modTime)).To(Succeed())
+		artifacts = append(artifacts, artifact)
+	}
+
+	// The oldest artifact is pinned, and the newest is the current one.
+	deleted, err := s.GarbageCollect(context.TODO(), artifacts[3], time.Second, artifacts[0])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(deleted).To(ConsistOf(s.LocalPath(artifacts[1]), s.LocalPath(artifacts[2])))
+	g.Expect(s.LocalPath(artifacts[0])).To(BeAnExistingFile())
+	g.Expect(s.LocalPath(artifacts[3])).To(BeAnExistingFile())
+
+	// The current and pinned artifacts are kept over the budget.
+	s.ArtifactRetentionMaxSize = 1
+	deleted, err = s.GarbageCollect(context.TODO(), artifacts[3], time.Second, artifacts[0])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(deleted).To(BeEmpty())
+}
+
 func TestStorage_VerifyArtifact(t *testing.T) {
 	g := NewWithT(t)
 
@@ -851,3 +1093,139 @@ func TestStorage_VerifyArtifact(t *testing.T) {
 		g.Expect(err).ToNot(HaveOccurred())
 	})
 }
+
+func TestStorage_Store(t *testing.T) {
+	g := NewWithT(t)
+
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "file"), []byte("content"), 0o600)).To(Succeed())
+
+	var store fetch.Store = storage
+	artifact := sourcev1.Artifact{Path: "gitrepository/default/podinfo/rev.tar.gz", Revision: "rev"}
+	g.Expect(store.Store(context.TODO(), &artifact, dir)).To(Succeed())
+	g.Expect(artifact.Digest).ToNot(BeEmpty())
+	g.Expect(artifact.Size).ToNot(BeNil())
+	g.Expect(storage.ArtifactExist(artifact)).To(BeTrue())
+}
+
+func TestStorage_Immutable(t *testing.T) {
+	g := NewWithT(t)
+
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	storage.Immutable = true
+
+	artifact := storage.NewArtifactFor(sourcev1.HelmChartKind, &metav1.ObjectMeta{Namespace: "default", Name: "podinfo"}, "6.0.0", "podinfo-6.0.0.tgz")
+	g.Expect(storage.MkdirAll(artifact)).To(Succeed())
+	g.Expect(storage.Copy(&artifact, strings.NewReader("content"))).To(Succeed())
+	g.Expect(artifact.Path).To(Equal("helmchart/default/podinfo/podinfo-6.0.0.tgz"))
+
+	// The same content is stored at the same path.
+	same := artifact.DeepCopy()
+	g.Expect(storage.Copy(same, strings.NewReader("content"))).To(Succeed())
+	g.Expect(same.Path).To(Equal(artifact.Path))
+
+	// A different content is stored at a path qualified with its digest.
+	changed := artifact.DeepCopy()
+	g.Expect(storage.Copy(changed, strings.NewReader("changed content"))).To(Succeed())
+	g.Expect(changed.Path).To(MatchRegexp(`^helmchart/default/podinfo/podinfo-6\.0\.0-[0-9a-f]{12}\.tgz$`))
+	g.Expect(changed.URL).To(HaveSuffix(changed.Path))
+	g.Expect(storage.VerifyArtifact(*changed)).To(Succeed())
+
+	b, err := os.ReadFile(storage.LocalPath(artifact))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(b)).To(Equal("content"))
+}
+
+func TestStorage_CompressionLevel(t *testing.T) {
+	g := NewWithT(t)
+
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(storage.CompressionLevel).To(Equal(gzip.DefaultCompression))
+
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "file"), bytes.Repeat([]byte("content"), 1024), 0o600)).To(Succeed())
+
+	sizes := make(map[int]int64)
+	for _, level := range []int{gzip.NoCompression, gzip.BestCompression} {
+		storage.CompressionLevel = level
+		artifact := storage.NewArtifactFor(sourcev1.GitRepositoryKind, &metav1.ObjectMeta{Namespace: "default", Name: "podinfo"}, "", fmt.Sprintf("%d.tar.gz", level))
+		g.Expect(storage.MkdirAll(artifact)).To(Succeed())
+		g.Expect(storage.Archive(&artifact, dir, nil)).To(Succeed())
+		g.Expect(storage.VerifyArtifact(artifact)).To(Succeed())
+		sizes[level] = *artifact.Size
+	}
+	g.Expect(sizes[gzip.BestCompression]).To(BeNumerically("<", sizes[gzip.NoCompression]))
+
+	g.Expect(ValidateCompressionLevel(gzip.DefaultCompression)).To(Succeed())
+	g.Expect(ValidateCompressionLevel(gzip.BestCompression)).To(Succeed())
+	g.Expect(ValidateCompressionLevel(10)).ToNot(Succeed())
+	g.Expect(ValidateCompressionLevel(gzip.HuffmanOnly)).ToNot(Succeed())
+}
+
+func TestStorage_QuarantineArtifact(t *testing.T) {
+	g := NewWithT(t)
+
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	meta := &metav1.ObjectMeta{Namespace: "default", Name: "podinfo"}
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "file"), []byte("content"), 0o600)).To(Succeed())
+
+	first, err := storage.QuarantineArtifact(storage.NewArtifactFor(sourcev1.GitRepositoryKind, meta, "main@sha1:a", "a.tar.gz"), dir, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(first.Path).To(Equal("quarantine/gitrepository/default/podinfo/a.tar.gz"))
+	g.Expect(first.URL).To(Equal("http://localhost/quarantine/gitrepository/default/podinfo/a.tar.gz"))
+	g.Expect(storage.VerifyArtifact(*first)).To(Succeed())
+	g.Expect(storage.IsQuarantined(storage.NewArtifactFor(sourcev1.GitRepositoryKind, meta, "main@sha1:a", "a.tar.gz"))).To(BeTrue())
+
+	// Only the latest quarantined content of an object is kept.
+	second, err := storage.QuarantineArtifact(storage.NewArtifactFor(sourcev1.GitRepositoryKind, meta, "main@sha1:b", "b.tar.gz"), dir, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(storage.LocalPath(*second)).To(BeARegularFile())
+	g.Expect(storage.LocalPath(*first)).ToNot(BeAnExistingFile())
+	g.Expect(storage.IsQuarantined(storage.NewArtifactFor(sourcev1.GitRepositoryKind, meta, "main@sha1:a", "a.tar.gz"))).To(BeFalse())
+
+	// The quarantine is removed with the artifacts of the object.
+	_, err = storage.RemoveAll(storage.NewArtifactFor(sourcev1.GitRepositoryKind, meta, "", "*"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(filepath.Dir(storage.LocalPath(*second))).ToNot(BeAnExistingFile())
+	g.Expect(filepath.Join(storage.BasePath, QuarantineDir)).To(BeADirectory())
+}
+
+func Test_quarantineUnverified(t *testing.T) {
+	g := NewWithT(t)
+
+	storage, err := NewStorage(t.TempDir(), "localhost", time.Minute, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	storage.QuarantineUnverified = true
+
+	dir := t.TempDir()
+	g.Expect(os.MkdirAll(filepath.Join(dir, ".git"), 0o700)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "file"), []byte("content"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "ignored"), []byte("content"), 0o600)).To(Succeed())
+
+	obj := &sourcev1.GitRepository{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "podinfo"}}
+	recorder := record.NewFakeRecorder(2)
+	quarantineUnverified(context.TODO(), storage, recorder, sourcev1.GitRepositoryKind, obj, "main@sha1:a", "a.tar.gz", dir, ptr.To("ignored"))
+
+	g.Expect(recorder.Events).To(HaveLen(1))
+	artifact := storage.NewArtifactFor(sourcev1.GitRepositoryKind, obj, "main@sha1:a", "a.tar.gz")
+	g.Expect(storage.IsQuarantined(artifact)).To(BeTrue())
+	localPath := storage.LocalPath(*quarantinedArtifact(artifact))
+	for name, want := range map[string]bool{"file": true, ".git/HEAD": false, "ignored": false} {
+		_, _, found, err := walkTar(localPath, name, false)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(found).To(Equal(want), name)
+	}
+
+	// An already quarantined revision is not quarantined again.
+	quarantineUnverified(context.TODO(), storage, recorder, sourcev1.GitRepositoryKind, obj, "main@sha1:a", "a.tar.gz", dir, nil)
+	g.Expect(recorder.Events).To(HaveLen(1))
+}
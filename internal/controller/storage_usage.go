@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	kuberecorder "k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	sourcefs "github.com/fluxcd/source-controller/internal/fs"
+)
+
+// StorageUsageHighReason signals that the usage of the Storage file system
+// exceeds the configured warning threshold.
+const StorageUsageHighReason = "StorageUsageHigh"
+
+// StorageUsageRecorder is a recorder for the usage of the Storage file
+// system.
+type StorageUsageRecorder struct {
+	bytesGauge  *prometheus.GaugeVec
+	inodesGauge *prometheus.GaugeVec
+}
+
+// NewStorageUsageRecorder returns a new StorageUsageRecorder.
+// The configured label is: state, which is one of "used" or "available".
+func NewStorageUsageRecorder() *StorageUsageRecorder {
+	return &StorageUsageRecorder{
+		bytesGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gotk_storage_bytes",
+				Help: "The number of bytes used and available on the artifact storage file system.",
+			},
+			[]string{"state"},
+		),
+		inodesGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gotk_storage_inodes",
+				Help: "The number of inodes used and available on the artifact storage file system.",
+			},
+			[]string{"state"},
+		),
+	}
+}
+
+// Collectors returns the metrics.Collector objects for the
+// StorageUsageRecorder.
+func (r *StorageUsageRecorder) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		r.bytesGauge,
+		r.inodesGauge,
+	}
+}
+
+// RecordUsage records the given usage statistics.
+func (r *StorageUsageRecorder) RecordUsage(u sourcefs.DiskUsage) {
+	r.bytesGauge.WithLabelValues("used").Set(float64(u.UsedBytes))
+	r.bytesGauge.WithLabelValues("available").Set(float64(u.AvailableBytes))
+	r.inodesGauge.WithLabelValues("used").Set(float64(u.UsedInodes))
+	r.inodesGauge.WithLabelValues("available").Set(float64(u.AvailableInodes))
+}
+
+// MustMakeStorageUsageMetrics creates a new StorageUsageRecorder, and
+// registers the metrics collectors in the controller-runtime metrics
+// registry.
+func MustMakeStorageUsageMetrics() *StorageUsageRecorder {
+	r := NewStorageUsageRecorder()
+	metrics.Registry.MustRegister(r.Collectors()...)
+	return r
+}
+
+// StorageUsageMonitor periodically records the usage of the file system the
+// Storage is located on. When the percentage of used bytes or inodes crosses
+// the WarningThreshold, a Warning event is emitted for all Source objects
+// with an Artifact, allowing operators to act before the garbage collection
+// can no longer keep up.
+type StorageUsageMonitor struct {
+	client.Client
+	kuberecorder.EventRecorder
+
+	Storage  *Storage
+	Recorder *StorageUsageRecorder
+	Interval time.Duration
+	// WarningThreshold is the percentage of used bytes or inodes above
+	// which Warning events are emitted. A value of 0 disables the events.
+	WarningThreshold float64
+
+	exceeded bool
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, ensuring
+// only the replica writing to the Storage reports on it.
+func (m *StorageUsageMonitor) NeedLeaderElection() bool {
+	return true
+}
+
+// Start records the usage at the configured interval until the given
+// context is canceled.
+func (m *StorageUsageMonitor) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+	for {
+		if err := m.Check(ctx); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "failed to check storage usage")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check records the current usage of the Storage, and emits Warning events
+// if the usage crossed the WarningThreshold since the previous check.
+func (m *StorageUsageMonitor) Check(ctx context.Context) error {
+	usage, err := sourcefs.Usage(m.Storage.BasePath)
+	if err != nil {
+		return err
+	}
+	if m.Recorder != nil {
+		m.Recorder.RecordUsage(*usage)
+	}
+
+	if m.WarningThreshold <= 0 {
+		return nil
+	}
+	exceeded := usage.BytesUsedPercentage() >= m.WarningThreshold ||
+		usage.InodesUsedPercentage() >= m.WarningThreshold
+	crossed := exceeded && !m.exceeded
+	m.exceeded = exceeded
+	if !crossed {
+		return nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info("storage usage exceeds warning threshold",
+		"bytesUsed", usage.BytesUsedPercentage(), "inodesUsed", usage.InodesUsedPercentage(),
+		"threshold", m.WarningThreshold)
+	return forEachArtifactSource(ctx, m.Client, func(obj artifactSource) error {
+		if obj.GetArtifact() == nil {
+			return nil
+		}
+		m.Eventf(obj, corev1.EventTypeWarning, StorageUsageHighReason,
+			"artifact storage usage exceeds %.0f%% threshold: %.1f%% of bytes (%s available) and %.1f%% of inodes in use",
+			m.WarningThreshold, usage.BytesUsedPercentage(), units.HumanSize(float64(usage.AvailableBytes)),
+			usage.InodesUsedPercentage())
+		return nil
+	})
+}
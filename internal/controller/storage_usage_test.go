@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestStorageUsageMonitor_Check(t *testing.T) {
+	g := NewWithT(t)
+
+	withArtifact := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "with-artifact", Namespace: "usage"},
+		Status: sourcev1.GitRepositoryStatus{
+			Artifact: &sourcev1.Artifact{Path: "/gitrepository/usage/with-artifact/main.tar.gz"},
+		},
+	}
+	withoutArtifact := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "without-artifact", Namespace: "usage"},
+	}
+
+	recorder := record.NewFakeRecorder(32)
+	m := &StorageUsageMonitor{
+		Client: fakeclient.NewClientBuilder().
+			WithScheme(testEnv.GetScheme()).
+			WithObjects(withArtifact, withoutArtifact).
+			Build(),
+		EventRecorder: recorder,
+		Storage:       testStorage,
+		Recorder:      NewStorageUsageRecorder(),
+		// Any file system in use crosses this threshold.
+		WarningThreshold: 0.000001,
+	}
+
+	g.Expect(m.Check(context.TODO())).To(Succeed())
+	g.Expect(recorder.Events).To(HaveLen(1))
+	g.Expect(<-recorder.Events).To(ContainSubstring(StorageUsageHighReason))
+
+	// Events are only emitted when the threshold is crossed.
+	g.Expect(m.Check(context.TODO())).To(Succeed())
+	g.Expect(recorder.Events).To(BeEmpty())
+
+	m.WarningThreshold = 0
+	g.Expect(m.Check(context.TODO())).To(Succeed())
+	g.Expect(recorder.Events).To(BeEmpty())
+}
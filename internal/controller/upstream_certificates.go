@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kuberecorder "k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fluxcd/pkg/runtime/logger"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/fluxcd/source-controller/internal/upstream"
+)
+
+// observeUpstreamCertificate records the TLS certificate presented by the
+// upstream at the given address for the given object, and records a warning
+// event when it is about to expire, or changed since the previous fetch of
+// the object. The upstream is reached through the given proxy, or the one
+// configured in the environment if it is nil. It returns the certificate to
+// record in the status of the object, or nil if none could be observed.
+// Failures are only logged, as the fetch itself succeeded.
+func observeUpstreamCertificate(ctx context.Context, monitor *upstream.CertificateMonitor, recorder kuberecorder.EventRecorder,
+	obj client.Object, address string, insecure bool, proxyURL *url.URL) *sourcev1.UpstreamCertificate {
+	if monitor == nil {
+		return nil
+	}
+	obs, err := monitor.Observe(ctx, certificateSourceKey(obj), address, insecure, proxyURL)
+	if err != nil {
+		if !errors.Is(err, upstream.ErrNoTLS) {
+			ctrl.LoggerFrom(ctx).V(logger.DebugLevel).Info("failed to observe upstream certificate", "error", err.Error())
+		}
+		return nil
+	}
+
+	cert := obs.Certificate
+	host := upstream.Host(address)
+	if obs.PreviousFingerprint != "" {
+		recorder.Eventf(obj, corev1.EventTypeWarning, sourcev1.UpstreamCertificateChangedReason,
+			"TLS certificate of '%s' changed from SHA-256 fingerprint %s to %s (subject '%s', issuer '%s', expires %s)",
+			host, obs.PreviousFingerprint, cert.Fingerprint, cert.Subject, cert.Issuer, cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if obs.Expiring {
+		recorder.Eventf(obj, corev1.EventTypeWarning, sourcev1.UpstreamCertificateExpiringReason,
+			"TLS certificate of '%s' (subject '%s', issuer '%s', SHA-256 fingerprint %s) expires %s",
+			host, cert.Subject, cert.Issuer, cert.Fingerprint, cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return &sourcev1.UpstreamCertificate{
+		Fingerprint: cert.Fingerprint,
+		NotAfter:    metav1.NewTime(cert.NotAfter),
+	}
+}
+
+// forgetUpstreamCertificates removes the certificate observations of the
+// given deleted object.
+func forgetUpstreamCertificates(monitor *upstream.CertificateMonitor, obj client.Object) {
+	monitor.Forget(certificateSourceKey(obj))
+}
+
+// certificateSourceKey returns the key identifying the given object in the
+// CertificateMonitor.
+func certificateSourceKey(obj client.Object) string {
+	return sourceKind(obj) + "/" + client.ObjectKeyFromObject(obj).String()
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package error
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+
+	gittransport "github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/minio/minio-go/v7"
+
+	"github.com/fluxcd/source-controller/internal/upstream"
+)
+
+// The classes of the failure taxonomy shared by all the reconcilers.
+const (
+	// ClassAuthenticationFailed signals that the upstream Source refused the
+	// credentials, or that none were given while they are required.
+	ClassAuthenticationFailed = "AuthenticationFailed"
+	// ClassNotFound signals that the upstream Source, or the requested
+	// revision of it, does not exist.
+	ClassNotFound = "NotFound"
+	// ClassThrottled signals that the upstream Source, or the controller,
+	// rate limited the requests.
+	ClassThrottled = "Throttled"
+	// ClassTimeout signals that a request to the upstream Source timed out.
+	ClassTimeout = "Timeout"
+	// ClassStorageError signals that the storage of the controller can not
+	// be written to, e.g. because it is full or read-only.
+	ClassStorageError = "StorageError"
+)
+
+// ClassifiedError is an error which falls in a class of the taxonomy. The
+// class prefixes the message of the error, which leaves the reason of the
+// condition it is recorded on to the reconciler.
+type ClassifiedError struct {
+	// Class is the class of the taxonomy the error falls in.
+	Class string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements error interface.
+func (e *ClassifiedError) Error() string {
+	return e.Class + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ClassifiedError) Unwrap() error {
+	return e.Err
+}
+
+// Classify returns the class of the taxonomy for the given error, or an
+// empty string if the error does not fall in any of the classes.
+//
+// Verification failures are not classified, as the reconcilers already
+// record them with the sourcev1.VerificationError reason.
+func Classify(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var classifiedErr *ClassifiedError
+	if errors.As(err, &classifiedErr) {
+		return classifiedErr.Class
+	}
+
+	var budgetErr *upstream.BudgetExceededError
+	if errors.As(err, &budgetErr) {
+		return ClassThrottled
+	}
+
+	switch {
+	case errors.Is(err, gittransport.ErrAuthenticationRequired),
+		errors.Is(err, gittransport.ErrAuthorizationFailed),
+		errors.Is(err, gittransport.ErrInvalidAuthMethod):
+		return ClassAuthenticationFailed
+	case errors.Is(err, gittransport.ErrRepositoryNotFound),
+		errors.Is(err, gittransport.ErrEmptyRemoteRepository):
+		return ClassNotFound
+	case errors.Is(err, syscall.ENOSPC),
+		errors.Is(err, syscall.EDQUOT),
+		errors.Is(err, syscall.EROFS):
+		return ClassStorageError
+	}
+
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) {
+		if class := classifyStatusCode(transportErr.StatusCode); class != "" {
+			return class
+		}
+	}
+	var minioErr minio.ErrorResponse
+	if errors.As(err, &minioErr) {
+		if class := classifyStatusCode(minioErr.StatusCode); class != "" {
+			return class
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ClassTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ClassTimeout
+	}
+	return ""
+}
+
+// Classified returns the given error as a ClassifiedError if it falls in a
+// class of the taxonomy, or as is otherwise.
+func Classified(err error) error {
+	var classifiedErr *ClassifiedError
+	if err == nil || errors.As(err, &classifiedErr) {
+		return err
+	}
+	if class := Classify(err); class != "" {
+		return &ClassifiedError{Class: class, Err: err}
+	}
+	return err
+}
+
+// classifyStatusCode returns the class for the given HTTP response status
+// code, or an empty string.
+func classifyStatusCode(code int) string {
+	switch code {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ClassAuthenticationFailed
+	case http.StatusNotFound:
+		return ClassNotFound
+	case http.StatusTooManyRequests:
+		return ClassThrottled
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return ClassTimeout
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package error
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+
+	gittransport "github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/minio/minio-go/v7"
+	. "github.com/onsi/gomega"
+
+	"github.com/fluxcd/source-controller/internal/upstream"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "git authentication",
+			err:  fmt.Errorf("failed to checkout: %w", gittransport.ErrAuthenticationRequired),
+			want: ClassAuthenticationFailed,
+		},
+		{
+			name: "git repository not found",
+			err:  fmt.Errorf("failed to checkout: %w", gittransport.ErrRepositoryNotFound),
+			want: ClassNotFound,
+		},
+		{
+			name: "registry unauthorized",
+			err:  fmt.Errorf("failed to pull: %w", &transport.Error{StatusCode: http.StatusUnauthorized}),
+			want: ClassAuthenticationFailed,
+		},
+		{
+			name: "registry manifest unknown",
+			err:  &transport.Error{StatusCode: http.StatusNotFound},
+			want: ClassNotFound,
+		},
+		{
+			name: "bucket throttled",
+			err:  fmt.Errorf("failed to list objects: %w", minio.ErrorResponse{StatusCode: http.StatusTooManyRequests}),
+			want: ClassThrottled,
+		},
+		{
+			name: "upstream budget exceeded",
+			err:  &upstream.BudgetExceededError{Host: "example.com"},
+			want: ClassThrottled,
+		},
+		{
+			name: "deadline exceeded",
+			err:  fmt.Errorf("failed to checkout: %w", context.DeadlineExceeded),
+			want: ClassTimeout,
+		},
+		{
+			name: "disk full",
+			err:  &os.PathError{Op: "write", Path: "/data/artifact.tar.gz", Err: syscall.ENOSPC},
+			want: ClassStorageError,
+		},
+		{
+			name: "unclassified",
+			err:  errors.New("x509: certificate signed by unknown authority"),
+		},
+		{
+			name: "nil",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(Classify(tt.err)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestClassified(t *testing.T) {
+	g := NewWithT(t)
+
+	err := Classified(fmt.Errorf("failed to checkout: %w", context.DeadlineExceeded))
+	g.Expect(err).To(MatchError("Timeout: failed to checkout: context deadline exceeded"))
+	g.Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+	g.Expect(Classify(err)).To(Equal(ClassTimeout))
+
+	g.Expect(Classified(fmt.Errorf("wrapped: %w", err)).Error()).To(Equal("wrapped: Timeout: failed to checkout: context deadline exceeded"))
+
+	err = errors.New("failure")
+	g.Expect(Classified(err)).To(Equal(err))
+	g.Expect(Classified(nil)).To(BeNil())
+}
@@ -30,12 +30,24 @@ const (
 	// When enabled, it will cache both object types, resulting in increased memory usage
 	// and cluster-wide RBAC permissions (list and watch).
 	CacheSecretsAndConfigMaps = "CacheSecretsAndConfigMaps"
+
+	// GitProviderRefResolution controls whether the branch or tag of a
+	// GitRepository hosted on GitHub, GitLab or Azure DevOps is resolved with
+	// the REST API of the provider before cloning.
+	//
+	// When enabled, the repository is not cloned if the reference still
+	// points to the commit of the current artifact.
+	GitProviderRefResolution = "GitProviderRefResolution"
 )
 
 var features = map[string]bool{
 	// CacheSecretsAndConfigMaps
 	// opt-in from v0.34
 	CacheSecretsAndConfigMaps: false,
+
+	// GitProviderRefResolution
+	// opt-in
+	GitProviderRefResolution: false,
 }
 
 func init() {
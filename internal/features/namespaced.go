@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fluxcd/pkg/auth"
+)
+
+// namespacedFeatures are the feature gates which can be overridden per
+// namespace. Feature gates which configure the controller at startup, like
+// CacheSecretsAndConfigMaps, can not be overridden.
+var namespacedFeatures = map[string]bool{
+	auth.FeatureGateObjectLevelWorkloadIdentity: true,
+}
+
+var (
+	overridesMu sync.RWMutex
+	overrides   map[string]map[string]bool
+	// defaults records the process-wide state of the namespaced feature
+	// gates before any override was applied.
+	defaults map[string]bool
+)
+
+// NamespacedFeatureGates returns the names of the feature gates which can be
+// overridden per namespace.
+func NamespacedFeatureGates() []string {
+	var names []string
+	for name := range namespacedFeatures {
+		names = append(names, name)
+	}
+	return names
+}
+
+// ParseNamespaceOverrides parses the per-namespace feature gate overrides
+// from the given ConfigMap data. Each key is a namespace, and each value a
+// comma separated list of 'Feature=true|false' pairs, in the format of the
+// --feature-gates flag.
+func ParseNamespaceOverrides(data map[string]string) (map[string]map[string]bool, error) {
+	result := make(map[string]map[string]bool, len(data))
+	for namespace, value := range data {
+		gates := make(map[string]bool)
+		for _, pair := range strings.Split(value, ",") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			name, v, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid feature gate '%s' for namespace '%s': expected 'Feature=true|false'", pair, namespace)
+			}
+			name = strings.TrimSpace(name)
+			if !namespacedFeatures[name] {
+				return nil, fmt.Errorf("feature gate '%s' for namespace '%s' can not be overridden per namespace", name, namespace)
+			}
+			enabled, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for feature gate '%s' for namespace '%s': %w", name, namespace, err)
+			}
+			gates[name] = enabled
+		}
+		result[namespace] = gates
+	}
+	return result, nil
+}
+
+// SetNamespaceOverrides replaces the per-namespace feature gate overrides.
+//
+// As the object-level workload identity gate is also checked by pkg/auth for
+// the whole process, it is enabled globally as soon as it is enabled for any
+// namespace. EnabledFor remains the authority on whether an object may use
+// it.
+func SetNamespaceOverrides(o map[string]map[string]bool) {
+	overridesMu.Lock()
+	defer overridesMu.Unlock()
+	if defaults == nil {
+		defaults = map[string]bool{
+			auth.FeatureGateObjectLevelWorkloadIdentity: auth.IsObjectLevelWorkloadIdentityEnabled(),
+		}
+	}
+	overrides = o
+	for _, gates := range o {
+		if gates[auth.FeatureGateObjectLevelWorkloadIdentity] {
+			auth.EnableObjectLevelWorkloadIdentity()
+		}
+	}
+}
+
+// EnabledFor verifies whether the feature is enabled for objects in the
+// given namespace, taking the per-namespace overrides into account.
+func EnabledFor(feature, namespace string) (bool, error) {
+	if !namespacedFeatures[feature] {
+		return Enabled(feature)
+	}
+	overridesMu.RLock()
+	defer overridesMu.RUnlock()
+	if enabled, ok := overrides[namespace][feature]; ok {
+		return enabled, nil
+	}
+	if enabled, ok := defaults[feature]; ok {
+		return enabled, nil
+	}
+	// The object-level workload identity gate is configured through the
+	// environment, see main.go.
+	return auth.IsObjectLevelWorkloadIdentityEnabled(), nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/fluxcd/pkg/auth"
+)
+
+func TestEnabledFor(t *testing.T) {
+	g := NewWithT(t)
+
+	const gate = auth.FeatureGateObjectLevelWorkloadIdentity
+	t.Setenv(auth.EnvVarEnableObjectLevelWorkloadIdentity, "false")
+	t.Cleanup(func() {
+		overrides, defaults = nil, nil
+	})
+
+	enabled, err := EnabledFor(gate, "team-a")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(enabled).To(BeFalse())
+
+	SetNamespaceOverrides(map[string]map[string]bool{
+		"team-a": {gate: true},
+		"team-b": {gate: false},
+	})
+	g.Expect(auth.IsObjectLevelWorkloadIdentityEnabled()).To(BeTrue())
+
+	for namespace, want := range map[string]bool{
+		"team-a": true,
+		"team-b": false,
+		"team-c": false,
+	} {
+		enabled, err := EnabledFor(gate, namespace)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(enabled).To(Equal(want), namespace)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileserver
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// CertFileName is the name of the certificate file in a certificate
+	// directory, matching the key of a kubernetes.io/tls Secret.
+	CertFileName = "tls.crt"
+	// KeyFileName is the name of the private key file in a certificate
+	// directory, matching the key of a kubernetes.io/tls Secret.
+	KeyFileName = "tls.key"
+)
+
+// CertificateStore serves TLS certificates from a directory. The default
+// certificate is read from the root of the directory, the certificate of a
+// virtual host from a sub directory named after its host name. Certificates
+// are reloaded when their files change, to support rotation through Secret
+// volume mounts.
+type CertificateStore struct {
+	dir   string
+	mu    sync.Mutex
+	certs map[string]cachedCertificate
+}
+
+type cachedCertificate struct {
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+// NewCertificateStore returns a CertificateStore for the given directory,
+// which must contain a default certificate.
+func NewCertificateStore(dir string) (*CertificateStore, error) {
+	s := &CertificateStore{
+		dir:   dir,
+		certs: make(map[string]cachedCertificate),
+	}
+	if _, err := s.load(""); err != nil {
+		return nil, fmt.Errorf("failed to load default certificate: %w", err)
+	}
+	return s, nil
+}
+
+// GetCertificate returns the certificate for the server name of the given
+// tls.ClientHelloInfo, falling back to the default certificate. It can be
+// used as tls.Config.GetCertificate.
+func (s *CertificateStore) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if name := strings.ToLower(hello.ServerName); name != "" && !strings.ContainsAny(name, `/\`) && name != ".." {
+		cert, err := s.load(name)
+		if err == nil {
+			return cert, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return s.load("")
+}
+
+// load returns the certificate from the sub directory with the given name,
+// reloading it if the certificate file changed since it was last loaded.
+func (s *CertificateStore) load(name string) (*tls.Certificate, error) {
+	certFile := filepath.Join(s.dir, name, CertFileName)
+	keyFile := filepath.Join(s.dir, name, KeyFileName)
+
+	fi, err := os.Stat(certFile)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cached, ok := s.certs[name]; ok && cached.modTime.Equal(fi.ModTime()) {
+		return cached.cert, nil
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	s.certs[name] = cachedCertificate{cert: &cert, modTime: fi.ModTime()}
+	return &cert, nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileserver
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func writeCertificate(t *testing.T, dir, certFile, keyFile string) {
+	t.Helper()
+	g := NewWithT(t)
+
+	g.Expect(os.MkdirAll(dir, 0o700)).To(Succeed())
+	for src, dst := range map[string]string{certFile: CertFileName, keyFile: KeyFileName} {
+		b, err := os.ReadFile(filepath.Join("..", "controller", "testdata", "certs", src))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(os.WriteFile(filepath.Join(dir, dst), b, 0o600)).To(Succeed())
+	}
+}
+
+func TestCertificateStore_GetCertificate(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+
+	_, err := NewCertificateStore(dir)
+	g.Expect(err).To(HaveOccurred())
+
+	writeCertificate(t, dir, "server.pem", "server-key.pem")
+	writeCertificate(t, filepath.Join(dir, "tenant-a.example.com"), "client.pem", "client-key.pem")
+
+	store, err := NewCertificateStore(dir)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	defaultCert, err := store.GetCertificate(&tls.ClientHelloInfo{})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	vhostCert, err := store.GetCertificate(&tls.ClientHelloInfo{ServerName: "Tenant-A.example.com"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(vhostCert.Certificate[0]).ToNot(Equal(defaultCert.Certificate[0]))
+
+	unknownCert, err := store.GetCertificate(&tls.ClientHelloInfo{ServerName: "tenant-b.example.com"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(unknownCert.Certificate[0]).To(Equal(defaultCert.Certificate[0]))
+
+	traversalCert, err := store.GetCertificate(&tls.ClientHelloInfo{ServerName: ".."})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(traversalCert.Certificate[0]).To(Equal(defaultCert.Certificate[0]))
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileserver
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+const (
+	// FormatQueryParameter is the query parameter to request an artifact in
+	// a specific format.
+	FormatQueryParameter = "format"
+	// TarFormat requests a compressed tarball artifact as a plain tarball.
+	TarFormat = "tar"
+)
+
+// DecompressHandler returns an http.Handler which serves gzip compressed
+// tarball artifacts decompressed on the fly, when requested with the
+// 'format=tar' query parameter. The artifacts are otherwise served as
+// stored, whatever the Accept-Encoding header, for the clients to verify
+// them against the digest of the artifact. Other requests are passed to
+// next.
+func DecompressHandler(root http.FileSystem, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isCompressedTarball(r.URL.Path) || r.URL.Query().Get(FormatQueryParameter) != TarFormat {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		f, err := root.Open(path.Clean("/" + r.URL.Path))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				http.NotFound(w, r)
+				return
+			}
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			http.Error(w, "artifact is not gzip compressed", http.StatusUnprocessableEntity)
+			return
+		}
+		defer gr.Close()
+
+		w.Header().Set("Content-Type", "application/x-tar")
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodHead {
+			return
+		}
+		// The status has been written, a decompression error can only
+		// result in a truncated response.
+		_, _ = io.Copy(w, gr)
+	})
+}
+
+// isCompressedTarball returns true if the given path is of a gzip compressed
+// tarball artifact.
+func isCompressedTarball(p string) bool {
+	return strings.HasSuffix(p, ".tar.gz") || strings.HasSuffix(p, ".tgz")
+}
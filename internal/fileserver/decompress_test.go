@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDecompressHandler(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	content := []byte("tarball content")
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write(content)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(gw.Close()).To(Succeed())
+	g.Expect(os.MkdirAll(filepath.Join(dir, "gitrepository", "default", "podinfo"), 0o700)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "gitrepository", "default", "podinfo", "latest.tar.gz"), buf.Bytes(), 0o600)).To(Succeed())
+
+	root := http.Dir(dir)
+	handler := DecompressHandler(root, http.FileServer(root))
+
+	tests := []struct {
+		name           string
+		target         string
+		acceptEncoding string
+		wantCode       int
+		wantBody       []byte
+		wantType       string
+	}{
+		{
+			name:     "compressed by default",
+			target:   "/gitrepository/default/podinfo/latest.tar.gz",
+			wantCode: http.StatusOK,
+			wantBody: buf.Bytes(),
+		},
+		{
+			name:           "compressed when gzip is accepted",
+			target:         "/gitrepository/default/podinfo/latest.tar.gz",
+			acceptEncoding: "gzip, identity",
+			wantCode:       http.StatusOK,
+			wantBody:       buf.Bytes(),
+		},
+		{
+			name:     "decompressed with format query parameter",
+			target:   "/gitrepository/default/podinfo/latest.tar.gz?format=tar",
+			wantCode: http.StatusOK,
+			wantBody: content,
+			wantType: "application/x-tar",
+		},
+		{
+			name:           "compressed when only identity is accepted",
+			target:         "/gitrepository/default/podinfo/latest.tar.gz",
+			acceptEncoding: "identity, gzip;q=0",
+			wantCode:       http.StatusOK,
+			wantBody:       buf.Bytes(),
+		},
+		{
+			name:     "not found",
+			target:   "/gitrepository/default/podinfo/missing.tar.gz?format=tar",
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			g.Expect(rec.Code).To(Equal(tt.wantCode))
+			if tt.wantBody != nil {
+				g.Expect(rec.Body.Bytes()).To(Equal(tt.wantBody))
+			}
+			if tt.wantType != "" {
+				g.Expect(rec.Header().Get("Content-Type")).To(Equal(tt.wantType))
+			}
+		})
+	}
+}
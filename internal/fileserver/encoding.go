@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileserver
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ZstdEncoding is the zstd content coding.
+const ZstdEncoding = "zstd"
+
+// supportedEncodings are the content codings tarball artifacts can be
+// re-encoded with.
+var supportedEncodings = []string{ZstdEncoding}
+
+// ValidateEncodings returns an error if any of the given content codings is
+// not supported.
+func ValidateEncodings(encodings []string) error {
+	for _, e := range encodings {
+		if !slices.Contains(supportedEncodings, e) {
+			return fmt.Errorf("unsupported content encoding '%s', must be one of: %s",
+				e, strings.Join(supportedEncodings, ", "))
+		}
+	}
+	return nil
+}
+
+// EncodingHandler returns an http.Handler which serves gzip compressed
+// tarball artifacts re-encoded on the fly with the first of the given
+// content codings the client prefers over gzip, as negotiated through the
+// Accept-Encoding header. The response has a Content-Encoding header with the
+// negotiated coding and a Content-Type of application/x-tar, the stored
+// artifact is left unchanged. Other requests are passed to next.
+func EncodingHandler(root http.FileSystem, encodings []string, next http.Handler) http.Handler {
+	if len(encodings) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isCompressedTarball(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// Both representations are served from the same URL.
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"), encodings)
+		if encoding == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		f, err := root.Open(path.Clean("/" + r.URL.Path))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				http.NotFound(w, r)
+				return
+			}
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			http.Error(w, "artifact is not gzip compressed", http.StatusUnprocessableEntity)
+			return
+		}
+		defer gr.Close()
+
+		w.Header().Set("Content-Type", "application/x-tar")
+		w.Header().Set("Content-Encoding", encoding)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodHead {
+			return
+		}
+		// The status has been written, an encoding error can only
+		// result in a truncated response.
+		ew, err := newEncodingWriter(encoding, w)
+		if err != nil {
+			return
+		}
+		_, _ = io.Copy(ew, gr)
+		_ = ew.Close()
+	})
+}
+
+// newEncodingWriter returns an io.WriteCloser encoding the data written to
+// it with the given content coding to w.
+func newEncodingWriter(encoding string, w io.Writer) (io.WriteCloser, error) {
+	switch encoding {
+	case ZstdEncoding:
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported content encoding '%s'", encoding)
+	}
+}
+
+// negotiateEncoding returns the first of the given content codings with the
+// highest quality value in the given Accept-Encoding header, if that value
+// is greater than zero and strictly greater than the one of gzip. It returns
+// an empty string if none of the codings is preferred over gzip.
+func negotiateEncoding(header string, encodings []string) string {
+	qualities := parseAcceptEncoding(header)
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, e := range encodings {
+		if q, ok := qualities[e]; ok && q > bestQ {
+			best, bestQ = e, q
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	if q, ok := qualities["gzip"]; ok && q >= bestQ {
+		return ""
+	}
+	return best
+}
+
+// parseAcceptEncoding returns the quality values of the content codings in
+// the given Accept-Encoding header value, indexed by the lower-cased coding.
+func parseAcceptEncoding(header string) map[string]float64 {
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.ReplaceAll(strings.TrimSpace(params), " ", ""), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		qualities[coding] = q
+	}
+	return qualities
+}
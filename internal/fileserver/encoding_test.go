@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	. "github.com/onsi/gomega"
+)
+
+func TestEncodingHandler(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	content := []byte("tarball content")
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write(content)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(gw.Close()).To(Succeed())
+	g.Expect(os.MkdirAll(filepath.Join(dir, "gitrepository", "default", "podinfo"), 0o700)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "gitrepository", "default", "podinfo", "latest.tar.gz"), buf.Bytes(), 0o600)).To(Succeed())
+
+	root := http.Dir(dir)
+	handler := EncodingHandler(root, []string{ZstdEncoding}, http.FileServer(root))
+
+	tests := []struct {
+		name           string
+		target         string
+		acceptEncoding string
+		wantCode       int
+		wantEncoding   string
+	}{
+		{
+			name:     "canonical artifact by default",
+			target:   "/gitrepository/default/podinfo/latest.tar.gz",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:           "canonical artifact when only gzip is accepted",
+			target:         "/gitrepository/default/podinfo/latest.tar.gz",
+			acceptEncoding: "gzip",
+			wantCode:       http.StatusOK,
+		},
+		{
+			name:           "canonical artifact when gzip is preferred",
+			target:         "/gitrepository/default/podinfo/latest.tar.gz",
+			acceptEncoding: "gzip, zstd;q=0.5",
+			wantCode:       http.StatusOK,
+		},
+		{
+			name:           "canonical artifact when gzip and zstd are equally preferred",
+			target:         "/gitrepository/default/podinfo/latest.tar.gz",
+			acceptEncoding: "gzip, deflate, br, zstd",
+			wantCode:       http.StatusOK,
+		},
+		{
+			name:           "zstd when preferred",
+			target:         "/gitrepository/default/podinfo/latest.tar.gz",
+			acceptEncoding: "gzip;q=0.5, deflate, br, zstd",
+			wantCode:       http.StatusOK,
+			wantEncoding:   ZstdEncoding,
+		},
+		{
+			name:           "zstd when gzip is not accepted",
+			target:         "/gitrepository/default/podinfo/latest.tar.gz",
+			acceptEncoding: "zstd",
+			wantCode:       http.StatusOK,
+			wantEncoding:   ZstdEncoding,
+		},
+		{
+			name:           "canonical artifact when zstd is rejected",
+			target:         "/gitrepository/default/podinfo/latest.tar.gz",
+			acceptEncoding: "zstd;q=0",
+			wantCode:       http.StatusOK,
+		},
+		{
+			name:           "not found",
+			target:         "/gitrepository/default/podinfo/missing.tar.gz",
+			acceptEncoding: "zstd",
+			wantCode:       http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			g.Expect(rec.Code).To(Equal(tt.wantCode))
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			if isCompressedTarball(tt.target) {
+				g.Expect(rec.Header().Values("Vary")).To(ContainElement("Accept-Encoding"))
+			}
+			g.Expect(rec.Header().Get("Content-Encoding")).To(Equal(tt.wantEncoding))
+			if tt.wantEncoding == "" {
+				g.Expect(rec.Body.Bytes()).To(Equal(buf.Bytes()))
+				return
+			}
+			g.Expect(rec.Header().Get("Content-Type")).To(Equal("application/x-tar"))
+			zr, err := zstd.NewReader(rec.Body)
+			g.Expect(err).ToNot(HaveOccurred())
+			defer zr.Close()
+			var got bytes.Buffer
+			_, err = got.ReadFrom(zr)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.Bytes()).To(Equal(content))
+		})
+	}
+}
+
+func TestValidateEncodings(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(ValidateEncodings(nil)).To(Succeed())
+	g.Expect(ValidateEncodings([]string{ZstdEncoding})).To(Succeed())
+	g.Expect(ValidateEncodings([]string{"br"})).To(MatchError(ContainSubstring("unsupported content encoding 'br'")))
+}
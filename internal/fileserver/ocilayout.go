@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileserver
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// OCILayoutFormat requests a compressed tarball artifact as a tarball of
+	// an OCI image layout.
+	OCILayoutFormat = "oci"
+	// OCILayoutMediaType is the media type of a tarball of an OCI image
+	// layout, which can be requested through the Accept header.
+	OCILayoutMediaType = "application/vnd.oci.image.layout.v1+tar"
+
+	// OCILayoutRefName is the reference name of the artifact image in the
+	// index of the OCI image layout.
+	OCILayoutRefName = "latest"
+
+	// fluxConfigMediaType and fluxContentMediaType are the media types of
+	// the config and the layer of the artifacts pushed with 'flux push
+	// artifact'.
+	fluxConfigMediaType  = "application/vnd.cncf.flux.config.v1+json"
+	fluxContentMediaType = "application/vnd.cncf.flux.content.v1.tar+gzip"
+)
+
+// DigestFunc returns the digest and size recorded for the artifact with the
+// given path, if known.
+type DigestFunc func(ctx context.Context, name string) (digest.Digest, int64, bool)
+
+// OCILayoutHandler returns an http.Handler which serves gzip compressed
+// tarball artifacts as a tarball of an OCI image layout, when requested with
+// the 'format=oci' query parameter or with an Accept header which accepts
+// the OCILayoutMediaType. The image has the artifact as its single layer,
+// with the media types of the artifacts pushed with 'flux push artifact', and
+// is tagged with the OCILayoutRefName. The digest of the layer is the one
+// returned by the given DigestFunc when it matches the size of the file, and
+// is computed from the file otherwise. The stored artifact is left
+// unchanged. Other requests are passed to next.
+func OCILayoutHandler(root http.FileSystem, digests DigestFunc, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isCompressedTarball(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// Both representations are served from the same URL.
+		w.Header().Add("Vary", "Accept")
+		if !wantsOCILayout(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		f, err := root.Open(path.Clean("/" + r.URL.Path))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				http.NotFound(w, r)
+				return
+			}
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+
+		layer, err := layerDescriptor(r.Context(), f, r.URL.Path, digests)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", OCILayoutMediaType)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodHead {
+			return
+		}
+		// The status has been written, an error can only result in a
+		// truncated response.
+		_ = writeOCILayout(w, layer, f)
+	})
+}
+
+// wantsOCILayout returns true if the request asks for an OCI image layout,
+// with a quality value greater than zero when asked with the Accept header.
+func wantsOCILayout(r *http.Request) bool {
+	if r.URL.Query().Get(FormatQueryParameter) == OCILayoutFormat {
+		return true
+	}
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != OCILayoutMediaType {
+			continue
+		}
+		q, err := strconv.ParseFloat(params["q"], 64)
+		if params["q"] == "" || (err == nil && q > 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// layerDescriptor returns the descriptor of the layer with the content of
+// the given file at the given path. The digest returned by the given
+// DigestFunc is used when it matches the size of the file, otherwise the
+// file is hashed and rewound.
+func layerDescriptor(ctx context.Context, f http.File, name string, digests DigestFunc) (ocispec.Descriptor, error) {
+	layer := ocispec.Descriptor{
+		MediaType: fluxContentMediaType,
+		Annotations: map[string]string{
+			ocispec.AnnotationTitle: path.Base(name),
+		},
+	}
+	if digests != nil {
+		fi, err := f.Stat()
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if dgst, size, ok := digests(ctx, name); ok && size == fi.Size() && isOCIDigest(dgst) {
+			layer.Digest, layer.Size = dgst, size
+			return layer, nil
+		}
+	}
+
+	d := digest.Canonical.Digester()
+	size, err := io.Copy(d.Hash(), f)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	layer.Digest, layer.Size = d.Digest(), size
+	return layer, nil
+}
+
+// isOCIDigest returns true if the given digest is valid and uses one of the
+// algorithms registered by the OCI image specification.
+func isOCIDigest(dgst digest.Digest) bool {
+	if dgst.Validate() != nil {
+		return false
+	}
+	return dgst.Algorithm() == digest.SHA256 || dgst.Algorithm() == digest.SHA512
+}
+
+// writeOCILayout writes a tarball of an OCI image layout with an image which
+// has the given layer, read from the given reader, to w.
+func writeOCILayout(w io.Writer, layer ocispec.Descriptor, content io.Reader) error {
+	config := []byte("{}")
+	manifest, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config: ocispec.Descriptor{
+			MediaType: fluxConfigMediaType,
+			Digest:    digest.FromBytes(config),
+			Size:      int64(len(config)),
+		},
+		Layers: []ocispec.Descriptor{layer},
+	})
+	if err != nil {
+		return err
+	}
+	index, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Digest:    digest.FromBytes(manifest),
+			Size:      int64(len(manifest)),
+			Annotations: map[string]string{
+				ocispec.AnnotationRefName: OCILayoutRefName,
+			},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	layout, err := json.Marshal(ocispec.ImageLayout{Version: ocispec.ImageLayoutVersion})
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	dirs := []string{ocispec.ImageBlobsDir, path.Join(ocispec.ImageBlobsDir, digest.Canonical.String())}
+	if layer.Digest.Algorithm() != digest.Canonical {
+		dirs = append(dirs, path.Join(ocispec.ImageBlobsDir, layer.Digest.Algorithm().String()))
+	}
+	for _, dir := range dirs {
+		if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: dir + "/", Mode: 0o755}); err != nil {
+			return err
+		}
+	}
+	for _, file := range []struct {
+		name string
+		data []byte
+	}{
+		{ocispec.ImageLayoutFile, layout},
+		{ocispec.ImageIndexFile, index},
+		{blobPath(digest.FromBytes(config)), config},
+		{blobPath(digest.FromBytes(manifest)), manifest},
+	} {
+		if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: file.name, Mode: 0o644, Size: int64(len(file.data))}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return err
+		}
+	}
+	if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: blobPath(layer.Digest), Mode: 0o644, Size: layer.Size}); err != nil {
+		return err
+	}
+	if _, err := io.Copy(tw, content); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// blobPath returns the path of the blob with the given digest in an OCI
+// image layout.
+func blobPath(dgst digest.Digest) string {
+	return path.Join(ocispec.ImageBlobsDir, dgst.Algorithm().String(), dgst.Encoded())
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileserver
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestOCILayoutHandler(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	content := []byte("compressed tarball content")
+	g.Expect(os.MkdirAll(filepath.Join(dir, "gitrepository", "default", "podinfo"), 0o700)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "gitrepository", "default", "podinfo", "abc.tar.gz"), content, 0o600)).To(Succeed())
+
+	root := http.Dir(dir)
+	handler := OCILayoutHandler(root, nil, http.FileServer(root))
+
+	// Artifacts are served as stored by default.
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gitrepository/default/podinfo/abc.tar.gz", nil))
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(rec.Body.Bytes()).To(Equal(content))
+	g.Expect(rec.Header().Values("Vary")).To(ContainElement("Accept"))
+
+	for _, accept := range []string{OCILayoutMediaType + ";q=0", OCILayoutMediaType + ";q=0.0", OCILayoutMediaType + ";q=invalid"} {
+		req := httptest.NewRequest(http.MethodGet, "/gitrepository/default/podinfo/abc.tar.gz", nil)
+		req.Header.Set("Accept", accept)
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		g.Expect(rec.Body.Bytes()).To(Equal(content), accept)
+	}
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/gitrepository/default/podinfo/abc.tar.gz?format=oci", nil),
+		func() *http.Request {
+			req := httptest.NewRequest(http.MethodGet, "/gitrepository/default/podinfo/abc.tar.gz", nil)
+			req.Header.Set("Accept", "application/json, "+OCILayoutMediaType+";q=0.5")
+			return req
+		}(),
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		g.Expect(rec.Code).To(Equal(http.StatusOK))
+		g.Expect(rec.Header().Get("Content-Type")).To(Equal(OCILayoutMediaType))
+		g.Expect(rec.Header().Values("Vary")).To(ContainElement("Accept"))
+
+		files := make(map[string][]byte)
+		tr := tar.NewReader(rec.Body)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			b, err := io.ReadAll(tr)
+			g.Expect(err).ToNot(HaveOccurred())
+			files[hdr.Name] = b
+		}
+		g.Expect(files).To(HaveKey(ocispec.ImageLayoutFile))
+
+		var index ocispec.Index
+		g.Expect(json.Unmarshal(files[ocispec.ImageIndexFile], &index)).To(Succeed())
+		g.Expect(index.Manifests).To(HaveLen(1))
+		g.Expect(index.Manifests[0].Annotations).To(HaveKeyWithValue(ocispec.AnnotationRefName, OCILayoutRefName))
+
+		var manifest ocispec.Manifest
+		g.Expect(json.Unmarshal(files[blobPath(index.Manifests[0].Digest)], &manifest)).To(Succeed())
+		g.Expect(files).To(HaveKey(blobPath(manifest.Config.Digest)))
+		g.Expect(manifest.Layers).To(HaveLen(1))
+		g.Expect(manifest.Layers[0].Digest).To(Equal(digest.FromBytes(content)))
+		g.Expect(manifest.Layers[0].Annotations).To(HaveKeyWithValue(ocispec.AnnotationTitle, "abc.tar.gz"))
+		g.Expect(files[blobPath(manifest.Layers[0].Digest)]).To(Equal(content))
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gitrepository/default/podinfo/missing.tar.gz?format=oci", nil))
+	g.Expect(rec.Code).To(Equal(http.StatusNotFound))
+}
+
+func TestOCILayoutHandler_recordedDigest(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	content := []byte("compressed tarball content")
+	g.Expect(os.MkdirAll(filepath.Join(dir, "gitrepository", "default", "podinfo"), 0o700)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "gitrepository", "default", "podinfo", "abc.tar.gz"), content, 0o600)).To(Succeed())
+
+	recorded := digest.SHA512.FromBytes(content)
+	size := int64(len(content))
+	digests := func(_ context.Context, name string) (digest.Digest, int64, bool) {
+		g.Expect(name).To(Equal("/gitrepository/default/podinfo/abc.tar.gz"))
+		return recorded, size, true
+	}
+	root := http.Dir(dir)
+	handler := OCILayoutHandler(root, digests, http.FileServer(root))
+
+	layerDigest := func() digest.Digest {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gitrepository/default/podinfo/abc.tar.gz?format=oci", nil))
+		g.Expect(rec.Code).To(Equal(http.StatusOK))
+
+		files := make(map[string][]byte)
+		tr := tar.NewReader(rec.Body)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			b, err := io.ReadAll(tr)
+			g.Expect(err).ToNot(HaveOccurred())
+			files[hdr.Name] = b
+		}
+		var index ocispec.Index
+		g.Expect(json.Unmarshal(files[ocispec.ImageIndexFile], &index)).To(Succeed())
+		var manifest ocispec.Manifest
+		g.Expect(json.Unmarshal(files[blobPath(index.Manifests[0].Digest)], &manifest)).To(Succeed())
+		g.Expect(files[blobPath(manifest.Layers[0].Digest)]).To(Equal(content))
+		return manifest.Layers[0].Digest
+	}
+
+	// The recorded digest is used without hashing the artifact.
+	g.Expect(layerDigest()).To(Equal(recorded))
+
+	// The artifact is hashed when the recorded digest does not match its size.
+	size = 1
+	g.Expect(layerDigest()).To(Equal(digest.FromBytes(content)))
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileserver
+
+import (
+	"net/http"
+)
+
+// PriorityHandler returns an http.Handler which serves at most maxDownloads
+// artifact downloads concurrently, queueing the others until a download
+// completes or the client goes away. HEAD and OPTIONS requests are not
+// queued, so that revision probes and health checks stay fast while the
+// downloads saturate the throughput of the server. A maxDownloads of zero or
+// less disables the limit.
+func PriorityHandler(maxDownloads int, next http.Handler) http.Handler {
+	if maxDownloads <= 0 {
+		return next
+	}
+	downloads := make(chan struct{}, maxDownloads)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead || r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+		select {
+		case downloads <- struct{}{}:
+			defer func() { <-downloads }()
+		case <-r.Context().Done():
+			// The client went away while queued.
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPriorityHandler(t *testing.T) {
+	g := NewWithT(t)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var served atomic.Int32
+	handler := PriorityHandler(1, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/large.tar.gz" {
+			close(started)
+			<-release
+		}
+		served.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Occupy the only download slot.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/large.tar.gz", nil))
+	}()
+	<-started
+
+	// HEAD requests are served while the downloads are saturated.
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/small.tar.gz", nil))
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+
+	// GET requests are queued until the client goes away.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/small.tar.gz", nil).WithContext(ctx))
+	g.Expect(served.Load()).To(Equal(int32(1)))
+
+	close(release)
+	<-done
+
+	// GET requests are served once a download completed.
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/small.tar.gz", nil))
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(served.Load()).To(Equal(int32(3)))
+}
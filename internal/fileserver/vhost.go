@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileserver
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ParseVirtualHosts parses a list of '<namespace>=<hostname>' entries into a
+// map of namespaces to host names. The host name may include a port and a
+// scheme.
+func ParseVirtualHosts(entries []string) (map[string]string, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	hosts := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		namespace, hostname, ok := strings.Cut(entry, "=")
+		namespace, hostname = strings.TrimSpace(namespace), strings.TrimSpace(hostname)
+		if !ok || namespace == "" || hostname == "" {
+			return nil, fmt.Errorf("invalid virtual host '%s', expected format '<namespace>=<hostname>'", entry)
+		}
+		if _, exists := hosts[namespace]; exists {
+			return nil, fmt.Errorf("duplicate virtual host for namespace '%s'", namespace)
+		}
+		hosts[namespace] = hostname
+	}
+	return hosts, nil
+}
+
+// VirtualHostHandler returns an http.Handler which restricts requests for the
+// host name of a virtual host to the artifacts of its namespace, responding
+// with a 404 to requests for the artifacts of any other namespace. Requests
+// for other host names are passed to next unrestricted.
+func VirtualHostHandler(hosts map[string]string, next http.Handler) http.Handler {
+	if len(hosts) == 0 {
+		return next
+	}
+	namespaces := make(map[string]string, len(hosts))
+	for namespace, hostname := range hosts {
+		namespaces[hostOnly(hostname)] = namespace
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namespace, ok := namespaces[hostOnly(r.Host)]
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// Artifact paths are in the form of '/<kind>/<namespace>/<name>/<file>'.
+		parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 3)
+		if len(parts) < 3 || parts[1] != namespace {
+			http.NotFound(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hostOnly returns the lower-cased host of the given host name, without
+// scheme and port.
+func hostOnly(hostname string) string {
+	hostname = strings.TrimPrefix(strings.TrimPrefix(hostname, "https://"), "http://")
+	hostname, _, _ = strings.Cut(hostname, "/")
+	if host, _, err := net.SplitHostPort(hostname); err == nil {
+		hostname = host
+	}
+	return strings.ToLower(hostname)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseVirtualHosts(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "valid entries",
+			entries: []string{"tenant-a=tenant-a.artifacts.svc", " tenant-b = https://tenant-b.example.com:9443"},
+			want: map[string]string{
+				"tenant-a": "tenant-a.artifacts.svc",
+				"tenant-b": "https://tenant-b.example.com:9443",
+			},
+		},
+		{
+			name: "no entries",
+		},
+		{
+			name:    "missing hostname",
+			entries: []string{"tenant-a="},
+			wantErr: true,
+		},
+		{
+			name:    "missing separator",
+			entries: []string{"tenant-a"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate namespace",
+			entries: []string{"tenant-a=a.example.com", "tenant-a=b.example.com"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := ParseVirtualHosts(tt.entries)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestVirtualHostHandler(t *testing.T) {
+	hosts := map[string]string{
+		"tenant-a": "tenant-a.artifacts.svc",
+		"tenant-b": "https://Tenant-B.example.com:9443",
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := VirtualHostHandler(hosts, next)
+
+	tests := []struct {
+		name     string
+		host     string
+		path     string
+		wantCode int
+	}{
+		{
+			name:     "virtual host serves own namespace",
+			host:     "tenant-a.artifacts.svc",
+			path:     "/gitrepository/tenant-a/app/latest.tar.gz",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "virtual host with port serves own namespace",
+			host:     "tenant-b.example.com:9443",
+			path:     "/ocirepository/tenant-b/app/latest.tar.gz",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "virtual host rejects other namespace",
+			host:     "tenant-a.artifacts.svc",
+			path:     "/gitrepository/tenant-b/app/latest.tar.gz",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "virtual host rejects listing",
+			host:     "tenant-a.artifacts.svc",
+			path:     "/gitrepository/",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "default host serves all namespaces",
+			host:     "source-controller.flux-system.svc",
+			path:     "/gitrepository/tenant-b/app/latest.tar.gz",
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			req := httptest.NewRequest(http.MethodGet, "http://"+tt.host+tt.path, nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			g.Expect(rec.Code).To(Equal(tt.wantCode))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+//go:build windows
+// +build windows
+
+/*
+Copyright 2025 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"errors"
+)
+
+// LinkCount returns the number of hard links to the file at the given path.
+// It is not supported on Windows.
+func LinkCount(path string) (uint64, error) {
+	return 0, errors.New("hard link counts are not supported on windows")
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+// DiskUsage contains the usage statistics of the file system a path is
+// located on.
+type DiskUsage struct {
+	// TotalBytes is the size of the file system in bytes.
+	TotalBytes uint64
+	// AvailableBytes is the number of bytes available to unprivileged users.
+	AvailableBytes uint64
+	// UsedBytes is the number of bytes in use.
+	UsedBytes uint64
+	// TotalInodes is the number of inodes of the file system.
+	TotalInodes uint64
+	// AvailableInodes is the number of free inodes.
+	AvailableInodes uint64
+	// UsedInodes is the number of inodes in use.
+	UsedInodes uint64
+}
+
+// BytesUsedPercentage returns the percentage of bytes in use, relative to
+// the bytes available to unprivileged users.
+func (u DiskUsage) BytesUsedPercentage() float64 {
+	return percentage(u.UsedBytes, u.UsedBytes+u.AvailableBytes)
+}
+
+// InodesUsedPercentage returns the percentage of inodes in use.
+func (u DiskUsage) InodesUsedPercentage() float64 {
+	return percentage(u.UsedInodes, u.TotalInodes)
+}
+
+func percentage(used, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(used) / float64(total) * 100
+}
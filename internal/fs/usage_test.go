@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestUsage(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("disk usage statistics are not supported on windows")
+	}
+
+	u, err := Usage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.TotalBytes == 0 {
+		t.Error("expected total bytes to be greater than zero")
+	}
+	if u.UsedBytes > u.TotalBytes {
+		t.Errorf("expected used bytes %d to not exceed total bytes %d", u.UsedBytes, u.TotalBytes)
+	}
+}
+
+func TestDiskUsage_Percentage(t *testing.T) {
+	u := DiskUsage{
+		UsedBytes:       75,
+		AvailableBytes:  25,
+		TotalInodes:     200,
+		UsedInodes:      50,
+		AvailableInodes: 150,
+	}
+	if got := u.BytesUsedPercentage(); got != 75 {
+		t.Errorf("expected bytes used percentage of 75, got %v", got)
+	}
+	if got := u.InodesUsedPercentage(); got != 25 {
+		t.Errorf("expected inodes used percentage of 25, got %v", got)
+	}
+	if got := (DiskUsage{}).InodesUsedPercentage(); got != 0 {
+		t.Errorf("expected inodes used percentage of 0 for empty usage, got %v", got)
+	}
+}
@@ -0,0 +1,42 @@
+//go:build !windows
+// +build !windows
+
+/*
+Copyright 2025 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"syscall"
+)
+
+// Usage returns the DiskUsage of the file system the given path is located
+// on.
+func Usage(path string) (*DiskUsage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return nil, err
+	}
+	bsize := uint64(stat.Bsize)
+	return &DiskUsage{
+		TotalBytes:      stat.Blocks * bsize,
+		AvailableBytes:  stat.Bavail * bsize,
+		UsedBytes:       (stat.Blocks - stat.Bfree) * bsize,
+		TotalInodes:     stat.Files,
+		AvailableInodes: stat.Ffree,
+		UsedInodes:      stat.Files - stat.Ffree,
+	}, nil
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitref resolves the branches and tags of Git repositories hosted
+// on GitHub, GitLab and Azure DevOps to commit SHAs with the REST API of the
+// provider, without any Git transport traffic.
+package gitref
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// ProviderGitHub is the GitHub REST API.
+	ProviderGitHub = "github"
+	// ProviderGitLab is the GitLab REST API.
+	ProviderGitLab = "gitlab"
+	// ProviderAzure is the Azure DevOps REST API.
+	ProviderAzure = "azure"
+)
+
+// ErrUnsupported is returned when the repository or the reference can not
+// be resolved with a provider API.
+var ErrUnsupported = errors.New("reference can not be resolved with a provider API")
+
+// Request describes the reference to resolve.
+type Request struct {
+	// URL of the Git repository.
+	URL string
+	// Provider of the repository. It is derived from the host of the URL
+	// when empty.
+	Provider string
+	// Branch to resolve.
+	Branch string
+	// Tag to resolve. It takes precedence over Branch.
+	Tag string
+
+	// Username and Password are used for basic authentication, the
+	// Password is used as a token for the GitLab API.
+	Username string
+	Password string
+	// BearerToken is used for bearer authentication, when set.
+	BearerToken string
+}
+
+// Resolve returns the SHA of the commit the reference of the given Request
+// points to, or ErrUnsupported.
+func Resolve(ctx context.Context, client *http.Client, req Request) (string, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", ErrUnsupported
+	}
+	if req.Tag == "" && req.Branch == "" {
+		return "", ErrUnsupported
+	}
+
+	var sha string
+	switch provider(u, req.Provider) {
+	case ProviderGitHub:
+		sha, err = resolveGitHub(ctx, client, u, req)
+	case ProviderGitLab:
+		sha, err = resolveGitLab(ctx, client, u, req)
+	case ProviderAzure:
+		sha, err = resolveAzure(ctx, client, u, req)
+	default:
+		return "", ErrUnsupported
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(sha) != 40 && len(sha) != 64 {
+		return "", fmt.Errorf("unexpected commit SHA '%s'", sha)
+	}
+	return sha, nil
+}
+
+// provider returns the provider of the repository at the given URL.
+func provider(u *url.URL, provider string) string {
+	switch host := strings.ToLower(u.Hostname()); {
+	case provider == ProviderGitHub || host == "github.com":
+		return ProviderGitHub
+	case provider == ProviderAzure || host == "dev.azure.com":
+		return ProviderAzure
+	case provider == ProviderGitLab || host == "gitlab.com":
+		return ProviderGitLab
+	default:
+		return ""
+	}
+}
+
+// repoPath returns the path of the repository at the given URL, without
+// the leading slash and the .git suffix.
+func repoPath(u *url.URL) string {
+	return strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+}
+
+func resolveGitHub(ctx context.Context, client *http.Client, u *url.URL, req Request) (string, error) {
+	parts := strings.Split(repoPath(u), "/")
+	if len(parts) != 2 {
+		return "", ErrUnsupported
+	}
+	api := "https://api.github.com"
+	if !strings.EqualFold(u.Hostname(), "github.com") {
+		// GitHub Enterprise Server.
+		api = fmt.Sprintf("%s://%s/api/v3", u.Scheme, u.Host)
+	}
+	// The qualified reference name does not resolve to a tag with the same
+	// name as the branch, or the other way around.
+	ref := "heads/" + url.PathEscape(req.Branch)
+	if req.Tag != "" {
+		ref = "tags/" + url.PathEscape(req.Tag)
+	}
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/commits/%s", api, parts[0], parts[1], ref)
+	body, err := get(ctx, client, endpoint, "application/vnd.github.sha", req, false)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func resolveGitLab(ctx context.Context, client *http.Client, u *url.URL, req Request) (string, error) {
+	// The branches and tags endpoints do not resolve to a tag with the same
+	// name as the branch, or the other way around.
+	ref := "branches/" + url.PathEscape(req.Branch)
+	if req.Tag != "" {
+		ref = "tags/" + url.PathEscape(req.Tag)
+	}
+	endpoint := fmt.Sprintf("%s://%s/api/v4/projects/%s/repository/%s",
+		u.Scheme, u.Host, url.PathEscape(repoPath(u)), ref)
+	body, err := get(ctx, client, endpoint, "application/json", req, true)
+	if err != nil {
+		return "", err
+	}
+	// Both the branches and the tags have the commit they point to.
+	var r struct {
+		Commit struct {
+			ID string `json:"id"`
+		} `json:"commit"`
+	}
+	if err := json.Unmarshal(body, &r); err != nil {
+		return "", fmt.Errorf("failed to decode GitLab reference: %w", err)
+	}
+	return r.Commit.ID, nil
+}
+
+func resolveAzure(ctx context.Context, client *http.Client, u *url.URL, req Request) (string, error) {
+	// Repository URLs are in the form of '<org>/<project>/_git/<repo>'.
+	parts := strings.Split(repoPath(u), "/")
+	if len(parts) != 4 || parts[2] != "_git" {
+		return "", ErrUnsupported
+	}
+	name := "refs/heads/" + req.Branch
+	if req.Tag != "" {
+		name = "refs/tags/" + req.Tag
+	}
+	endpoint := fmt.Sprintf("%s://%s/%s/%s/_apis/git/repositories/%s/refs?filter=%s&peelTags=true&api-version=7.1",
+		u.Scheme, u.Host, parts[0], parts[1], parts[3], url.QueryEscape(strings.TrimPrefix(name, "refs/")))
+	body, err := get(ctx, client, endpoint, "application/json", req, false)
+	if err != nil {
+		return "", err
+	}
+	var refs struct {
+		Value []struct {
+			Name           string `json:"name"`
+			ObjectID       string `json:"objectId"`
+			PeeledObjectID string `json:"peeledObjectId"`
+		} `json:"value"`
+	}
+	if err := json.Unmarshal(body, &refs); err != nil {
+		return "", fmt.Errorf("failed to decode Azure DevOps refs: %w", err)
+	}
+	// The filter matches the prefix of the reference names.
+	for _, r := range refs.Value {
+		if r.Name != name {
+			continue
+		}
+		if r.PeeledObjectID != "" {
+			// The commit of an annotated tag.
+			return r.PeeledObjectID, nil
+		}
+		return r.ObjectID, nil
+	}
+	return "", fmt.Errorf("reference '%s' not found", name)
+}
+
+// get performs an authenticated GET request to the given endpoint and
+// returns the response body.
+func get(ctx context.Context, client *http.Client, endpoint, accept string, req Request, privateToken bool) ([]byte, error) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Accept", accept)
+	switch {
+	case req.BearerToken != "":
+		r.Header.Set("Authorization", "Bearer "+req.BearerToken)
+	case privateToken && req.Password != "":
+		r.Header.Set("PRIVATE-TOKEN", req.Password)
+	case req.Password != "":
+		r.SetBasicAuth(req.Username, req.Password)
+	}
+
+	resp, err := client.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from '%s'", resp.StatusCode, r.URL.Redacted())
+	}
+	return body, nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitref
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testSHA      = "6f2bc0a8b4ce4c5ab7e5e0f4e2b0e1b4a3f9d2c1"
+	testOtherSHA = "0c1e4b8a9d3f2e7c6b5a4d3c2b1a0f9e8d7c6b5a"
+)
+
+func TestResolve(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/org/repo/commits/heads/main", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/vnd.github.sha" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		if _, password, _ := r.BasicAuth(); password != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, testSHA)
+	})
+	// A tag with the same name as the branch.
+	mux.HandleFunc("GET /api/v3/repos/org/repo/commits/tags/main", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testOtherSHA)
+	})
+	mux.HandleFunc("GET /api/v4/projects/group%2Fproject/repository/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"name": "v1.0.0", "target": "tag", "commit": {"id": "%s"}}`, testSHA)
+	})
+	// A branch with the same name as the tag.
+	mux.HandleFunc("GET /api/v4/projects/group%2Fproject/repository/branches/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name": "v1.0.0", "commit": {"id": "%s"}}`, testOtherSHA)
+	})
+	mux.HandleFunc("GET /org/project/_apis/git/repositories/repo/refs", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.URL.Query().Get("filter") != "tags/v1.0.0" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"value": [{"name": "refs/tags/v1.0.0-rc.1", "objectId": "other"}, {"name": "refs/tags/v1.0.0", "objectId": "tag", "peeledObjectId": "%s"}]}`, testSHA)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		req     Request
+		want    string
+		wantErr error
+	}{
+		{
+			name: "GitHub Enterprise branch",
+			req:  Request{URL: server.URL + "/org/repo.git", Provider: ProviderGitHub, Branch: "main", Username: "git", Password: "token"},
+			want: testSHA,
+		},
+		{
+			name: "GitHub Enterprise tag with the name of a branch",
+			req:  Request{URL: server.URL + "/org/repo.git", Provider: ProviderGitHub, Tag: "main"},
+			want: testOtherSHA,
+		},
+		{
+			name: "GitLab tag",
+			req:  Request{URL: server.URL + "/group/project.git", Provider: ProviderGitLab, Tag: "v1.0.0", Password: "token"},
+			want: testSHA,
+		},
+		{
+			name: "GitLab branch with the name of a tag",
+			req:  Request{URL: server.URL + "/group/project.git", Provider: ProviderGitLab, Branch: "v1.0.0"},
+			want: testOtherSHA,
+		},
+		{
+			name: "Azure DevOps annotated tag",
+			req:  Request{URL: server.URL + "/org/project/_git/repo", Provider: ProviderAzure, Tag: "v1.0.0", BearerToken: "token"},
+			want: testSHA,
+		},
+		{
+			name:    "unknown host",
+			req:     Request{URL: server.URL + "/org/repo.git", Branch: "main"},
+			wantErr: ErrUnsupported,
+		},
+		{
+			name:    "SSH URL",
+			req:     Request{URL: "ssh://git@github.com/org/repo.git", Branch: "main"},
+			wantErr: ErrUnsupported,
+		},
+		{
+			name:    "no branch or tag",
+			req:     Request{URL: "https://github.com/org/repo.git"},
+			wantErr: ErrUnsupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			sha, err := Resolve(context.TODO(), server.Client(), tt.req)
+			if tt.wantErr != nil {
+				g.Expect(errors.Is(err, tt.wantErr)).To(BeTrue())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(sha).To(Equal(tt.want))
+		})
+	}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := Resolve(context.TODO(), server.Client(), Request{
+			URL: server.URL + "/org/repo.git", Provider: ProviderGitHub, Branch: "main", Password: "wrong",
+		})
+		g.Expect(err).To(MatchError(ContainSubstring("unexpected status code 401")))
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	helmchart "helm.sh/helm/v3/pkg/chart"
@@ -149,6 +150,12 @@ type Build struct {
 	// ResolvedDependencies is the number of local and remote dependencies
 	// collected by the DependencyManager before building the chart.
 	ResolvedDependencies int
+	// Dependencies contains the details of the dependencies resolved by
+	// the DependencyManager before building the chart.
+	Dependencies []ResolvedDependency
+	// Warnings contains any non-fatal issues encountered during the build,
+	// for example values files which were ignored because they are missing.
+	Warnings []string
 	// Packaged indicates if the Builder has packaged the chart.
 	// This can for example be false if ValuesFiles is empty and the chart
 	// source was already packaged.
@@ -182,6 +189,18 @@ func (b *Build) Summary() string {
 	return s.String()
 }
 
+// ignoredValuesFilesWarnings returns a warning for each of the requested
+// values files which is not part of the merged values files.
+func ignoredValuesFilesWarnings(requested, merged []string) []string {
+	var warnings []string
+	for _, p := range requested {
+		if !slices.Contains(merged, p) {
+			warnings = append(warnings, fmt.Sprintf("ignored missing values file '%s'", p))
+		}
+	}
+	return warnings
+}
+
 // HasMetadata returns if the Build contains chart metadata.
 //
 // NOTE: This may return True while the build did not Complete successfully.
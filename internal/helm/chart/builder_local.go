@@ -153,6 +153,7 @@ func (b *localChartBuilder) Build(ctx context.Context, ref Reference, p string,
 		if mergedValues, valuesFiles, err = mergeFileValues(localRef.WorkDir, opts.ValuesFiles, opts.IgnoreMissingValuesFiles); err != nil {
 			return result, &BuildError{Reason: ErrValuesFilesMerge, Err: err}
 		}
+		result.Warnings = ignoredValuesFilesWarnings(opts.ValuesFiles, valuesFiles)
 	}
 
 	// At this point we are certain we need to load the chart;
@@ -180,9 +181,10 @@ func (b *localChartBuilder) Build(ctx context.Context, ref Reference, p string,
 			err = fmt.Errorf("local chart builder requires dependency manager for unpackaged charts")
 			return result, &BuildError{Reason: ErrDependencyBuild, Err: err}
 		}
-		if result.ResolvedDependencies, err = b.dm.Build(ctx, ref, loadedChart); err != nil {
+		if result.Dependencies, err = b.dm.Resolve(ctx, ref, loadedChart); err != nil {
 			return result, &BuildError{Reason: ErrDependencyBuild, Err: err}
 		}
+		result.ResolvedDependencies = len(result.Dependencies)
 	}
 
 	// Package the chart
@@ -19,6 +19,7 @@ package chart
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -108,6 +109,7 @@ func (b *remoteChartBuilder) Build(ctx context.Context, ref Reference, p string,
 		err = fmt.Errorf("failed to merge chart values: %w", err)
 		return result, &BuildError{Reason: ErrValuesFilesMerge, Err: err}
 	}
+	result.Warnings = ignoredValuesFilesWarnings(opts.ValuesFiles, valuesFiles)
 	// Overwrite default values with merged values, if any
 	if ok, err = OverwriteChartDefaultValues(chart, mergedValues); ok || err != nil {
 		if err != nil {
@@ -165,8 +167,12 @@ func (b *remoteChartBuilder) downloadFromRepository(ctx context.Context, remote
 	// Download the package for the resolved version
 	res, err := remote.DownloadChart(cv)
 	if err != nil {
+		reason := ErrChartPull
+		if errors.Is(err, repository.ErrDigestMismatch) {
+			reason = ErrChartDigest
+		}
 		err = fmt.Errorf("failed to download chart for remote reference: %w", err)
-		return nil, nil, &BuildError{Reason: ErrChartPull, Err: err}
+		return nil, nil, &BuildError{Reason: reason, Err: err}
 	}
 
 	return res, result, nil
@@ -115,6 +115,27 @@ entries:
 		}
 	}
 
+	digestMismatchRepo := func() *repository.ChartRepository {
+		return &repository.ChartRepository{
+			URL: "https://grafana.github.io/helm-charts/",
+			Client: &mockIndexChartGetter{
+				IndexResponse: []byte(`
+apiVersion: v1
+entries:
+  grafana:
+    - urls:
+        - https://example.com/grafana.tgz
+      description: string
+      version: 6.17.4
+      name: grafana
+      digest: 0000000000000000000000000000000000000000000000000000000000000000
+`),
+				ChartResponse: chartGrafana,
+			},
+			RWMutex: &sync.RWMutex{},
+		}
+	}
+
 	tests := []struct {
 		name         string
 		reference    Reference
@@ -154,6 +175,12 @@ entries:
 			buildOpts:  BuildOptions{VersionMetadata: "^"},
 			wantErr:    "Invalid Metadata string",
 		},
+		{
+			name:       "chart digest mismatch",
+			reference:  RemoteReference{Name: "grafana"},
+			repository: digestMismatchRepo(),
+			wantErr:    "chart digest mismatch",
+		},
 		{
 			name:         "with version metadata",
 			reference:    RemoteReference{Name: "grafana"},
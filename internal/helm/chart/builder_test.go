@@ -241,6 +241,15 @@ func TestChartBuildResult_String(t *testing.T) {
 	g.Expect(result.String()).To(Equal("/foo/"))
 }
 
+func Test_ignoredValuesFilesWarnings(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(ignoredValuesFilesWarnings([]string{"values.yaml", "override.yaml"}, []string{"values.yaml", "override.yaml"})).To(BeEmpty())
+	g.Expect(ignoredValuesFilesWarnings([]string{"values.yaml", "missing.yaml", "override.yaml"}, []string{"values.yaml", "override.yaml"})).To(Equal([]string{
+		"ignored missing values file 'missing.yaml'",
+	}))
+}
+
 func Test_packageToPath(t *testing.T) {
 	g := NewWithT(t)
 
@@ -22,6 +22,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
@@ -109,6 +110,13 @@ func (dm *DependencyManager) Clear() error {
 // resolve and build them using the information from Reference.
 // It returns the number of resolved local and remote dependencies, or an error.
 func (dm *DependencyManager) Build(ctx context.Context, ref Reference, chart *helmchart.Chart) (int, error) {
+	resolved, err := dm.Resolve(ctx, ref, chart)
+	return len(resolved), err
+}
+
+// Resolve is like Build, but returns the details of the resolved local and
+// remote dependencies, sorted by name.
+func (dm *DependencyManager) Resolve(ctx context.Context, ref Reference, chart *helmchart.Chart) ([]ResolvedDependency, error) {
 	// Collect dependency metadata
 	var (
 		deps = chart.Dependencies()
@@ -122,20 +130,51 @@ func (dm *DependencyManager) Build(ctx context.Context, ref Reference, chart *he
 	// Collect missing dependencies
 	missing := collectMissing(deps, reqs)
 	if len(missing) == 0 {
-		return 0, nil
+		return nil, nil
 	}
 
 	// Run the build for the missing dependencies
-	if err := dm.build(ctx, ref, chart, missing); err != nil {
-		return 0, err
+	c := &chartWithLock{Chart: chart}
+	if err := dm.buildWithLock(ctx, ref, c, missing); err != nil {
+		return nil, err
 	}
-	return len(missing), nil
+	sort.Slice(c.resolved, func(i, j int) bool {
+		return c.resolved[i].Name < c.resolved[j].Name
+	})
+	return c.resolved, nil
+}
+
+// ResolvedDependency holds the details of a dependency resolved by the
+// DependencyManager.
+type ResolvedDependency struct {
+	// Name of the dependency, or its alias if set.
+	Name string
+	// Version of the dependency chart which was resolved.
+	Version string
+	// Repository the dependency was resolved from, as declared in the
+	// chart metadata.
+	Repository string
+	// Digest of the dependency chart as advertised by the repository index.
+	// Empty for local dependencies.
+	Digest string
 }
 
 // chartWithLock holds a chart.Chart with a sync.Mutex to lock for writes.
 type chartWithLock struct {
 	*helmchart.Chart
 	mu sync.Mutex
+
+	// resolved contains the dependencies added to the chart.
+	resolved []ResolvedDependency
+}
+
+// addDependency adds the given dependency chart to the chart, and records it
+// as resolved.
+func (c *chartWithLock) addDependency(ch *helmchart.Chart, resolved ResolvedDependency) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.AddDependency(ch)
+	c.resolved = append(c.resolved, resolved)
 }
 
 // build adds the given list of deps to the chart with the configured number of
@@ -143,6 +182,11 @@ type chartWithLock struct {
 // LocalReference is given, or any dependency could not be added, an error
 // is returned. The first error it encounters cancels all other workers.
 func (dm *DependencyManager) build(ctx context.Context, ref Reference, c *helmchart.Chart, deps map[string]*helmchart.Dependency) error {
+	return dm.buildWithLock(ctx, ref, &chartWithLock{Chart: c}, deps)
+}
+
+// buildWithLock is like build, but adds the deps to the given chartWithLock.
+func (dm *DependencyManager) buildWithLock(ctx context.Context, ref Reference, c *chartWithLock, deps map[string]*helmchart.Dependency) error {
 	current := dm.concurrent
 	if current <= 0 {
 		current = 1
@@ -151,7 +195,6 @@ func (dm *DependencyManager) build(ctx context.Context, ref Reference, c *helmch
 	group, groupCtx := errgroup.WithContext(ctx)
 	group.Go(func() error {
 		sem := semaphore.NewWeighted(current)
-		c := &chartWithLock{Chart: c}
 		for name, dep := range deps {
 			name, dep := name, dep
 			if err := sem.Acquire(groupCtx, 1); err != nil {
@@ -222,9 +265,11 @@ func (dm *DependencyManager) addLocalDependency(ref LocalReference, c *chartWith
 		ch.Metadata.Name = dep.Alias
 	}
 
-	c.mu.Lock()
-	c.AddDependency(ch)
-	c.mu.Unlock()
+	c.addDependency(ch, ResolvedDependency{
+		Name:       ch.Metadata.Name,
+		Version:    ch.Metadata.Version,
+		Repository: dep.Repository,
+	})
 	return nil
 }
 
@@ -254,9 +299,12 @@ func (dm *DependencyManager) addRemoteDependency(chart *chartWithLock, dep *helm
 		ch.Metadata.Name = dep.Alias
 	}
 
-	chart.mu.Lock()
-	chart.AddDependency(ch)
-	chart.mu.Unlock()
+	chart.addDependency(ch, ResolvedDependency{
+		Name:       ch.Metadata.Name,
+		Version:    ver.Version,
+		Repository: dep.Repository,
+		Digest:     ver.Digest,
+	})
 	return nil
 }
 
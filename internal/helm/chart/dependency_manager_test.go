@@ -571,13 +571,18 @@ func TestDependencyManager_addRemoteDependency(t *testing.T) {
 				downloaders: tt.downloaders,
 			}
 			chart := &helmchart.Chart{}
-			err := dm.addRemoteDependency(&chartWithLock{Chart: chart}, tt.dep)
+			c := &chartWithLock{Chart: chart}
+			err := dm.addRemoteDependency(c, tt.dep)
 			if tt.wantErr != "" {
 				g.Expect(err).To(HaveOccurred())
 				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
 				return
 			}
 			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(c.resolved).To(HaveLen(len(chart.Dependencies())))
+			for _, dep := range c.resolved {
+				g.Expect(dep.Repository).To(Equal(tt.dep.Repository))
+			}
 			if tt.wantFunc != nil {
 				tt.wantFunc(g, chart)
 			}
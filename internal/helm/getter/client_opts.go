@@ -21,6 +21,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	neturl "net/url"
 	"os"
 	"path"
 
@@ -34,6 +35,7 @@ import (
 	"github.com/fluxcd/pkg/runtime/secrets"
 
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/fluxcd/source-controller/internal/helm/netrc"
 	"github.com/fluxcd/source-controller/internal/helm/registry"
 	soci "github.com/fluxcd/source-controller/internal/oci"
 )
@@ -148,6 +150,15 @@ func configureAuthentication(ctx context.Context, c client.Client, obj *sourcev1
 				helmgetter.WithBasicAuth(methods.Basic.Username, methods.Basic.Password))
 		}
 
+		// Credentials from a netrc entry matching the URL take precedence
+		// over the basic auth credentials of the secret.
+		if entry, ok, err := netrcEntryFromSecret(secret, url); err != nil {
+			return false, nil, nil, err
+		} else if ok {
+			opts.GetterOpts = append(opts.GetterOpts,
+				helmgetter.WithBasicAuth(entry.Login, entry.Password))
+		}
+
 		// Use TLS from SecretRef only if CertSecretRef is not specified (CertSecretRef takes priority)
 		if opts.TlsConfig == nil && methods.HasTLS() {
 			opts.TlsConfig = methods.TLS
@@ -217,6 +228,26 @@ func configureOCIRegistryWithSecrets(ctx context.Context, obj *sourcev1.HelmRepo
 	return tempCertDir, nil
 }
 
+// netrcEntryFromSecret looks up the netrc entry for the given URL in the
+// netrc.SecretKey of the secret. It returns false if the secret has no such
+// key, or no entry matches.
+func netrcEntryFromSecret(secret *corev1.Secret, repositoryURL string) (netrc.Entry, bool, error) {
+	data, ok := secret.Data[netrc.SecretKey]
+	if !ok {
+		return netrc.Entry{}, false, nil
+	}
+	entries, err := netrc.Parse(data)
+	if err != nil {
+		return netrc.Entry{}, false, fmt.Errorf("invalid '%s' in secret '%s': %w", netrc.SecretKey, secret.Name, err)
+	}
+	u, err := neturl.Parse(repositoryURL)
+	if err != nil {
+		return netrc.Entry{}, false, err
+	}
+	entry, ok := entries.Lookup(u)
+	return entry, ok, nil
+}
+
 func fetchSecret(ctx context.Context, c client.Client, name, namespace string) (*corev1.Secret, error) {
 	key := types.NamespacedName{
 		Namespace: namespace,
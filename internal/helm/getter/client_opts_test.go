@@ -18,6 +18,8 @@ package getter
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -26,6 +28,7 @@ import (
 	"github.com/fluxcd/pkg/apis/meta"
 	"github.com/google/go-containerregistry/pkg/name"
 	. "github.com/onsi/gomega"
+	helmgetter "helm.sh/helm/v3/pkg/getter"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -114,6 +117,46 @@ func TestGetClientOpts(t *testing.T) {
 			},
 			oci: true,
 		},
+		{
+			name: "HelmRepository with netrc in secretRef has matching auth configured",
+			authSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "auth-netrc",
+				},
+				Data: map[string][]byte{
+					"username": []byte("user"),
+					"password": []byte("pass"),
+					"netrc":    []byte("machine ghcr.io/dummy login netrc-user password netrc#pass # comment"),
+				},
+			},
+			afterFunc: func(t *WithT, hcOpts *ClientOpts) {
+				username, password := getterBasicAuth(t, hcOpts.GetterOpts)
+				t.Expect(username).To(Equal("netrc-user"))
+				t.Expect(password).To(Equal("netrc#pass"))
+			},
+		},
+		{
+			name: "OCI HelmRepository with netrc in secretRef has matching auth configured",
+			authSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "auth-oci-netrc",
+				},
+				Data: map[string][]byte{
+					"netrc": []byte("machine ghcr.io/other login other password other\nmachine ghcr.io/dummy login netrc-user password netrc-pass"),
+				},
+			},
+			afterFunc: func(t *WithT, hcOpts *ClientOpts) {
+				repo, err := name.NewRepository("ghcr.io/dummy")
+				t.Expect(err).ToNot(HaveOccurred())
+				authenticator, err := hcOpts.Keychain.Resolve(repo)
+				t.Expect(err).ToNot(HaveOccurred())
+				config, err := authenticator.Authorization()
+				t.Expect(err).ToNot(HaveOccurred())
+				t.Expect(config.Username).To(Equal("netrc-user"))
+				t.Expect(config.Password).To(Equal("netrc-pass"))
+			},
+			oci: true,
+		},
 		{
 			name: "OCI HelmRepository with insecure repository",
 			authSecret: &corev1.Secret{
@@ -175,6 +218,22 @@ func TestGetClientOpts(t *testing.T) {
 	}
 }
 
+// getterBasicAuth returns the basic auth credentials sent by an HTTP getter
+// configured with the given options.
+func getterBasicAuth(t *WithT, opts []helmgetter.Option) (string, string) {
+	var username, password string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ = r.BasicAuth()
+	}))
+	defer server.Close()
+
+	g, err := helmgetter.NewHTTPGetter(opts...)
+	t.Expect(err).ToNot(HaveOccurred())
+	_, err = g.Get(server.URL+"/index.yaml", helmgetter.WithURL(server.URL))
+	t.Expect(err).ToNot(HaveOccurred())
+	return username, password
+}
+
 func TestGetClientOpts_registryTLSLoginOption(t *testing.T) {
 	tlsCA, err := os.ReadFile("../../controller/testdata/certs/ca.pem")
 	if err != nil {
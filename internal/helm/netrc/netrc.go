@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package netrc parses netrc formatted credentials, extended to allow
+// a path after the machine name so that several repositories behind the
+// same host can be given different credentials.
+//
+// For example:
+//
+//	machine charts.example.com/team-a login alice password secret-a
+//	machine charts.example.com/team-b login bob password secret-b
+//	default login anonymous password guest
+package netrc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SecretKey is the key in a Secret holding the netrc formatted credentials.
+const SecretKey = "netrc"
+
+// Entry holds the credentials for a machine.
+type Entry struct {
+	// Host is the host, optionally including a port, the entry applies to.
+	// It is empty for the default entry.
+	Host string
+	// Path is the path prefix the entry applies to.
+	Path string
+	// Login is the username.
+	Login string
+	// Password is the password.
+	Password string
+}
+
+// Entries is a list of netrc entries.
+type Entries []Entry
+
+// Parse parses the given netrc formatted data. Comments start with a token
+// starting with '#' and run to the end of the line, a '#' within a token,
+// e.g. of a password, is not a comment. The 'account' token is accepted but
+// ignored, macro definitions are not supported.
+func Parse(data []byte) (Entries, error) {
+	var tokens []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		for _, tok := range strings.Fields(scanner.Text()) {
+			if strings.HasPrefix(tok, "#") {
+				break
+			}
+			tokens = append(tokens, tok)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var entries Entries
+	var current *Entry
+	for i := 0; i < len(tokens); i++ {
+		switch tok := tokens[i]; tok {
+		case "machine", "default":
+			entries = append(entries, Entry{})
+			current = &entries[len(entries)-1]
+			if tok == "default" {
+				continue
+			}
+			if i+1 >= len(tokens) {
+				return nil, fmt.Errorf("missing value for '%s'", tok)
+			}
+			i++
+			host, path, _ := strings.Cut(tokens[i], "/")
+			if host == "" {
+				return nil, fmt.Errorf("invalid machine '%s'", tokens[i])
+			}
+			current.Host = host
+			current.Path = "/" + strings.Trim(path, "/")
+		case "login", "password", "account":
+			if current == nil {
+				return nil, fmt.Errorf("'%s' must follow a machine or default", tok)
+			}
+			if i+1 >= len(tokens) {
+				return nil, fmt.Errorf("missing value for '%s'", tok)
+			}
+			i++
+			switch tok {
+			case "login":
+				current.Login = tokens[i]
+			case "password":
+				current.Password = tokens[i]
+			}
+		case "macdef":
+			return nil, fmt.Errorf("macro definitions are not supported")
+		default:
+			return nil, fmt.Errorf("unexpected token '%s'", tok)
+		}
+	}
+	return entries, nil
+}
+
+// Lookup returns the entry matching the given URL. Entries match on the host,
+// which must include the port if the entry specifies one, and on the longest
+// path prefix. For equal path prefixes, an entry with a port is preferred.
+// The default entry is returned if no machine matches.
+func (e Entries) Lookup(u *url.URL) (Entry, bool) {
+	var match, def *Entry
+	var matchScore int
+	for i := range e {
+		entry := &e[i]
+		if entry.Host == "" {
+			if def == nil {
+				def = entry
+			}
+			continue
+		}
+		if entry.Host != u.Host && entry.Host != u.Hostname() {
+			continue
+		}
+		if !hasPathPrefix(u.Path, entry.Path) {
+			continue
+		}
+		score := 2 * len(strings.TrimSuffix(entry.Path, "/"))
+		if entry.Host == u.Host {
+			score++
+		}
+		if match == nil || score > matchScore {
+			match, matchScore = entry, score
+		}
+	}
+	switch {
+	case match != nil:
+		return *match, true
+	case def != nil:
+		return *def, true
+	default:
+		return Entry{}, false
+	}
+}
+
+// hasPathPrefix reports whether p is equal to, or a sub path of, prefix.
+func hasPathPrefix(p, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return true
+	}
+	p = "/" + strings.Trim(p, "/")
+	return p == prefix || strings.HasPrefix(p, prefix+"/")
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netrc
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Entries
+		wantErr string
+	}{
+		{
+			name: "machines and default",
+			data: `# corporate proxy
+machine proxy.example.com/charts/team-a login alice password a
+machine proxy.example.com:8443
+  login bob
+  password b
+  account ignored
+default login anonymous password guest
+`,
+			want: Entries{
+				{Host: "proxy.example.com", Path: "/charts/team-a", Login: "alice", Password: "a"},
+				{Host: "proxy.example.com:8443", Path: "/", Login: "bob", Password: "b"},
+				{Login: "anonymous", Password: "guest"},
+			},
+		},
+		{
+			name: "hash within tokens",
+			data: `machine example.com login al#ice password s3cr#t # trailing comment
+#machine ignored.example.com login bob password b
+`,
+			want: Entries{
+				{Host: "example.com", Path: "/", Login: "al#ice", Password: "s3cr#t"},
+			},
+		},
+		{
+			name: "empty",
+			data: "",
+		},
+		{
+			name:    "login without machine",
+			data:    "login alice password a",
+			wantErr: "must follow a machine or default",
+		},
+		{
+			name:    "missing value",
+			data:    "machine example.com login",
+			wantErr: "missing value for 'login'",
+		},
+		{
+			name:    "macdef",
+			data:    "machine example.com macdef init",
+			wantErr: "macro definitions are not supported",
+		},
+		{
+			name:    "unexpected token",
+			data:    "machine example.com user alice",
+			wantErr: "unexpected token 'user'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := Parse([]byte(tt.data))
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestEntries_Lookup(t *testing.T) {
+	entries, err := Parse([]byte(`
+machine proxy.example.com login root password r
+machine proxy.example.com/charts/team-a login alice password a
+machine proxy.example.com/charts/team-a/sub login carol password c
+machine proxy.example.com:8443 login bob password b
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		entries   Entries
+		url       string
+		wantLogin string
+		wantOK    bool
+	}{
+		{
+			name:      "host match",
+			entries:   entries,
+			url:       "https://proxy.example.com/charts/team-b/",
+			wantLogin: "root",
+			wantOK:    true,
+		},
+		{
+			name:      "path prefix match",
+			entries:   entries,
+			url:       "https://proxy.example.com/charts/team-a/",
+			wantLogin: "alice",
+			wantOK:    true,
+		},
+		{
+			name:      "longest path prefix match",
+			entries:   entries,
+			url:       "https://proxy.example.com/charts/team-a/sub/index.yaml",
+			wantLogin: "carol",
+			wantOK:    true,
+		},
+		{
+			name:      "path prefix on segment boundary",
+			entries:   entries,
+			url:       "https://proxy.example.com/charts/team-ab/",
+			wantLogin: "root",
+			wantOK:    true,
+		},
+		{
+			name:      "host with port",
+			entries:   entries,
+			url:       "https://proxy.example.com:8443/charts/team-b/",
+			wantLogin: "bob",
+			wantOK:    true,
+		},
+		{
+			name:    "no match",
+			entries: entries,
+			url:     "https://charts.example.com/",
+		},
+		{
+			name:      "default",
+			entries:   append(entries, Entry{Login: "anonymous"}),
+			url:       "https://charts.example.com/",
+			wantLogin: "anonymous",
+			wantOK:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			u, err := url.Parse(tt.url)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got, ok := tt.entries.Lookup(u)
+			g.Expect(ok).To(Equal(tt.wantOK))
+			g.Expect(got.Login).To(Equal(tt.wantLogin))
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/docker/cli/cli/config"
 	"github.com/docker/cli/cli/config/credentials"
 	"github.com/fluxcd/source-controller/internal/helm/common"
+	"github.com/fluxcd/source-controller/internal/helm/netrc"
 	"github.com/fluxcd/source-controller/internal/oci"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"helm.sh/helm/v3/pkg/registry"
@@ -46,8 +47,9 @@ func (h helper) Get(serverURL string) (string, string, error) {
 
 // LoginOptionFromSecret derives authentication data from a Secret to login to an OCI registry. This Secret
 // may either hold "username" and "password" fields or be of the corev1.SecretTypeDockerConfigJson type and hold
-// a corev1.DockerConfigJsonKey field with a complete Docker configuration. If both, "username" and "password" are
-// empty, a nil LoginOption and a nil error will be returned.
+// a corev1.DockerConfigJsonKey field with a complete Docker configuration. A netrc.SecretKey field with an entry
+// matching the registry URL takes precedence over the "username" and "password" fields. If both, "username" and
+// "password" are empty, a nil LoginOption and a nil error will be returned.
 func LoginOptionFromSecret(registryURL string, secret corev1.Secret) (authn.Keychain, error) {
 	var username, password string
 	parsedURL, err := url.Parse(registryURL)
@@ -76,6 +78,15 @@ func LoginOptionFromSecret(registryURL string, secret corev1.Secret) (authn.Keyc
 		password = authConfig.Password
 	} else {
 		username, password = string(secret.Data["username"]), string(secret.Data["password"])
+		if data, ok := secret.Data[netrc.SecretKey]; ok {
+			entries, err := netrc.Parse(data)
+			if err != nil {
+				return nil, fmt.Errorf("invalid '%s' in Secret '%s': %w", netrc.SecretKey, secret.Name, err)
+			}
+			if entry, ok := entries.Lookup(parsedURL); ok {
+				username, password = entry.Login, entry.Password
+			}
+		}
 	}
 	switch {
 	case username == "" && password == "":
@@ -117,6 +117,32 @@ func TestLoginOptionFromSecret(t *testing.T) {
 				dockerconfigjsonKey: []byte(testDockerconfigjsonHTTPS),
 			},
 		},
+		{
+			name:       "generic secret with netrc",
+			url:        testURL,
+			secretType: corev1.SecretTypeOpaque,
+			secretData: map[string][]byte{
+				"netrc": []byte("machine registry.example.com/foo login flux password somepassword"),
+			},
+		},
+		{
+			name:       "generic secret with netrc without password",
+			url:        testURL,
+			secretType: corev1.SecretTypeOpaque,
+			secretData: map[string][]byte{
+				"netrc": []byte("machine registry.example.com/foo login flux"),
+			},
+			wantErr: true,
+		},
+		{
+			name:       "generic secret with invalid netrc",
+			url:        testURL,
+			secretType: corev1.SecretTypeOpaque,
+			secretData: map[string][]byte{
+				"netrc": []byte("login flux"),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
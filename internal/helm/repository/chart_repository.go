@@ -47,6 +47,9 @@ import (
 
 var (
 	ErrNoChartIndex = errors.New("no chart index")
+	// ErrDigestMismatch is returned when a downloaded chart does not match
+	// the digest recorded in the repository index.
+	ErrDigestMismatch = errors.New("chart digest mismatch")
 )
 
 // IndexFromFile loads a repo.IndexFile from the given path. It returns an
@@ -268,6 +271,8 @@ func (r *ChartRepository) getChartVersion(name, ver string) (*repo.ChartVersion,
 // DownloadChart confirms the given repo.ChartVersion has a downloadable URL,
 // and then attempts to download the chart using the Client and Options of the
 // ChartRepository. It returns a bytes.Buffer containing the chart data.
+// If the repo.ChartVersion has a digest, the chart data is verified against it
+// and an error wrapping ErrDigestMismatch is returned on mismatch.
 func (r *ChartRepository) DownloadChart(chart *repo.ChartVersion) (*bytes.Buffer, error) {
 	if len(chart.URLs) == 0 {
 		return nil, fmt.Errorf("chart '%s' has no downloadable URLs", chart.Name)
@@ -286,7 +291,36 @@ func (r *ChartRepository) DownloadChart(chart *repo.ChartVersion) (*bytes.Buffer
 	clientOpts := append(r.Options, getter.WithTransport(t))
 	defer transport.Release(t)
 
-	return r.Client.Get(resolvedUrl, clientOpts...)
+	res, err := r.Client.Get(resolvedUrl, clientOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifyChartDigest(chart, res.Bytes()); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// verifyChartDigest verifies the given chart data against the digest of the
+// repo.ChartVersion. Index files record the digest as a hex encoded SHA-256
+// checksum without an algorithm prefix. It is a no-op if no digest is set.
+func verifyChartDigest(chart *repo.ChartVersion, b []byte) error {
+	if chart.Digest == "" {
+		return nil
+	}
+	expected := chart.Digest
+	if !strings.Contains(expected, ":") {
+		expected = digest.SHA256.String() + ":" + expected
+	}
+	d, err := digest.Parse(expected)
+	if err != nil {
+		return fmt.Errorf("invalid digest '%s' for chart '%s' version '%s': %w", chart.Digest, chart.Name, chart.Version, err)
+	}
+	if actual := d.Algorithm().FromBytes(b); actual != d {
+		return fmt.Errorf("%w: chart '%s' version '%s' has digest '%s', expected '%s'",
+			ErrDigestMismatch, chart.Name, chart.Version, actual, d)
+	}
+	return nil
 }
 
 // CacheIndex attempts to write the index from the remote into a new temporary file
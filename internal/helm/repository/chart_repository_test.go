@@ -339,8 +339,10 @@ func TestChartRepository_DownloadChart(t *testing.T) {
 		name         string
 		url          string
 		chartVersion *repo.ChartVersion
+		response     []byte
 		wantURL      string
 		wantErr      bool
+		wantErrIs    error
 	}{
 		{
 			name: "relative URL",
@@ -351,6 +353,40 @@ func TestChartRepository_DownloadChart(t *testing.T) {
 			},
 			wantURL: "https://example.com/charts/foo-1.0.0.tgz",
 		},
+		{
+			name: "matching digest",
+			url:  "https://example.com",
+			chartVersion: &repo.ChartVersion{
+				Metadata: &chart.Metadata{Name: "chart"},
+				URLs:     []string{"charts/foo-1.0.0.tgz"},
+				Digest:   digest.SHA256.FromString("chart").Encoded(),
+			},
+			response: []byte("chart"),
+			wantURL:  "https://example.com/charts/foo-1.0.0.tgz",
+		},
+		{
+			name: "digest mismatch",
+			url:  "https://example.com",
+			chartVersion: &repo.ChartVersion{
+				Metadata: &chart.Metadata{Name: "chart"},
+				URLs:     []string{"charts/foo-1.0.0.tgz"},
+				Digest:   digest.SHA256.FromString("chart").Encoded(),
+			},
+			response:  []byte("tampered"),
+			wantErr:   true,
+			wantErrIs: ErrDigestMismatch,
+		},
+		{
+			name: "invalid digest",
+			url:  "https://example.com",
+			chartVersion: &repo.ChartVersion{
+				Metadata: &chart.Metadata{Name: "chart"},
+				URLs:     []string{"charts/foo-1.0.0.tgz"},
+				Digest:   "invalid",
+			},
+			response: []byte("chart"),
+			wantErr:  true,
+		},
 		{
 			name:         "no chart URL",
 			chartVersion: &repo.ChartVersion{Metadata: &chart.Metadata{Name: "chart"}},
@@ -371,7 +407,7 @@ func TestChartRepository_DownloadChart(t *testing.T) {
 			g := NewWithT(t)
 			t.Parallel()
 
-			mg := mockGetter{}
+			mg := mockGetter{Response: tt.response}
 			r := &ChartRepository{
 				URL:    tt.url,
 				Client: &mg,
@@ -379,6 +415,9 @@ func TestChartRepository_DownloadChart(t *testing.T) {
 			res, err := r.DownloadChart(tt.chartVersion)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
+				if tt.wantErrIs != nil {
+					g.Expect(errors.Is(err, tt.wantErrIs)).To(BeTrue())
+				}
 				g.Expect(res).To(BeNil())
 				return
 			}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package jitter configures the interval jitter per source kind, overriding
+// the global interval jitter of github.com/fluxcd/pkg/runtime/jitter.
+package jitter
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fluxcd/pkg/runtime/jitter"
+)
+
+// kindPercentages maps source kinds to their interval jitter percentage.
+var kindPercentages map[string]uint8
+
+// SetKindPercentages sets the interval jitter percentages per source kind.
+// It is not safe for concurrent use, and is expected to be called once
+// before the controllers are started.
+func SetKindPercentages(percentages map[string]uint8) {
+	kindPercentages = percentages
+}
+
+// ParseKindPercentages parses a list of '<kind>=<percentage>' entries into a
+// map of source kinds to jitter percentages. The kind must be one of the
+// given kinds, the percentage must be in the range of [0, 100).
+func ParseKindPercentages(entries []string, kinds ...string) (map[string]uint8, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	percentages := make(map[string]uint8, len(entries))
+	for _, entry := range entries {
+		kind, value, ok := strings.Cut(entry, "=")
+		kind, value = strings.TrimSpace(kind), strings.TrimSpace(value)
+		if !ok || kind == "" || value == "" {
+			return nil, fmt.Errorf("invalid interval jitter '%s', expected format '<kind>=<percentage>'", entry)
+		}
+		if !isKnownKind(kind, kinds) {
+			return nil, fmt.Errorf("invalid interval jitter '%s': unknown kind '%s', must be one of: %s",
+				entry, kind, strings.Join(kinds, ", "))
+		}
+		p, err := strconv.ParseUint(value, 10, 8)
+		if err != nil || p >= 100 {
+			return nil, fmt.Errorf("invalid interval jitter '%s': percentage must be in the range of [0, 100)", entry)
+		}
+		if _, exists := percentages[kind]; exists {
+			return nil, fmt.Errorf("duplicate interval jitter for kind '%s'", kind)
+		}
+		percentages[kind] = uint8(p)
+	}
+	return percentages, nil
+}
+
+// JitteredIntervalDuration returns the given interval with the jitter
+// configured for the given kind applied. A percentage of 0 disables the
+// jitter for the kind. Kinds without a configured percentage fall back to
+// the global interval jitter.
+func JitteredIntervalDuration(kind string, interval time.Duration) time.Duration {
+	p, ok := kindPercentages[kind]
+	if !ok {
+		return jitter.JitteredIntervalDuration(interval)
+	}
+	return applyPercentage(interval, p, rand.Float64())
+}
+
+// applyPercentage returns the interval with a jitter of up to the given
+// percentage added or subtracted, for a random value r in [0, 1).
+func applyPercentage(interval time.Duration, percentage uint8, r float64) time.Duration {
+	if percentage == 0 || interval <= 0 {
+		return interval
+	}
+	maxJitter := float64(interval) * float64(percentage) / 100
+	return interval + time.Duration((r*2-1)*maxJitter)
+}
+
+func isKnownKind(kind string, kinds []string) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jitter
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseKindPercentages(t *testing.T) {
+	kinds := []string{"GitRepository", "OCIRepository"}
+
+	tests := []struct {
+		name    string
+		entries []string
+		want    map[string]uint8
+		wantErr string
+	}{
+		{
+			name: "no entries",
+		},
+		{
+			name:    "valid entries",
+			entries: []string{"OCIRepository=30", " GitRepository = 0 "},
+			want:    map[string]uint8{"OCIRepository": 30, "GitRepository": 0},
+		},
+		{
+			name:    "missing separator",
+			entries: []string{"OCIRepository"},
+			wantErr: "expected format",
+		},
+		{
+			name:    "unknown kind",
+			entries: []string{"Kustomization=10"},
+			wantErr: "unknown kind 'Kustomization'",
+		},
+		{
+			name:    "percentage out of range",
+			entries: []string{"OCIRepository=100"},
+			wantErr: "percentage must be in the range",
+		},
+		{
+			name:    "negative percentage",
+			entries: []string{"OCIRepository=-5"},
+			wantErr: "percentage must be in the range",
+		},
+		{
+			name:    "duplicate kind",
+			entries: []string{"OCIRepository=10", "OCIRepository=20"},
+			wantErr: "duplicate interval jitter",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := ParseKindPercentages(tt.entries, kinds...)
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestJitteredIntervalDuration(t *testing.T) {
+	g := NewWithT(t)
+
+	SetKindPercentages(map[string]uint8{"GitRepository": 0, "OCIRepository": 50})
+	t.Cleanup(func() { SetKindPercentages(nil) })
+
+	g.Expect(JitteredIntervalDuration("GitRepository", time.Minute)).To(Equal(time.Minute))
+	for i := 0; i < 100; i++ {
+		g.Expect(JitteredIntervalDuration("OCIRepository", time.Minute)).To(
+			BeNumerically("~", time.Minute, 30*time.Second))
+	}
+}
+
+func Test_applyPercentage(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(applyPercentage(time.Minute, 0, 0.9)).To(Equal(time.Minute))
+	g.Expect(applyPercentage(time.Minute, 10, 0)).To(Equal(54 * time.Second))
+	g.Expect(applyPercentage(time.Minute, 10, 0.5)).To(Equal(time.Minute))
+	g.Expect(applyPercentage(time.Minute, 10, 0.75)).To(Equal(63 * time.Second))
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package proxy parses the proxy Secrets of the GitRepository, Bucket and
+// OCIRepository objects, and matches their noProxy lists.
+//
+// HelmRepository objects have no proxy Secret, and the controller-wide proxy
+// is configured with the standard HTTPS_PROXY and NO_PROXY environment
+// variables honored by the Go transports, so neither is handled here.
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// AddressKey is the key of the proxy Secret holding the address of the
+	// proxy server.
+	AddressKey = "address"
+	// UsernameKey and PasswordKey are the keys of the proxy Secret holding
+	// the basic authentication credentials of the proxy server.
+	UsernameKey = "username"
+	PasswordKey = "password"
+	// NoProxyKey is the key of the proxy Secret holding the comma separated
+	// list of hosts, domains and CIDR ranges to reach without the proxy.
+	NoProxyKey = "noProxy"
+)
+
+// URLFromSecret returns the URL of the proxy configured in the given Secret
+// to reach the target address with. The credentials of the proxy, if any,
+// are set as the user info of the URL.
+//
+// It returns nil if the host of the target matches the noProxy list of the
+// Secret, in which case the target is to be reached as if no proxy Secret
+// was configured.
+func URLFromSecret(secret *corev1.Secret, target string) (*url.URL, error) {
+	address, ok := secret.Data[AddressKey]
+	if !ok {
+		return nil, fmt.Errorf("invalid proxy secret '%s/%s': key '%s' is missing",
+			secret.Namespace, secret.Name, AddressKey)
+	}
+	if Bypass(string(secret.Data[NoProxyKey]), target) {
+		return nil, nil
+	}
+	proxyURL, err := url.Parse(string(address))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proxy address '%s': %w", address, err)
+	}
+	user, hasUser := secret.Data[UsernameKey]
+	password, hasPassword := secret.Data[PasswordKey]
+	if hasUser || hasPassword {
+		proxyURL.User = url.UserPassword(string(user), string(password))
+	}
+	return proxyURL, nil
+}
+
+// Bypass reports whether the host of the target address matches an entry
+// of the given comma separated noProxy list. An entry matches:
+//   - '*', any host;
+//   - a CIDR range, e.g. '10.0.0.0/8', the IP addresses within the range;
+//   - a domain, e.g. 'example.com', the domain and its subdomains;
+//   - a domain with a leading dot or wildcard, e.g. '.example.com' or
+//     '*.example.com', only the subdomains.
+//
+// Ports are not taken into account. The target may be a URL, a host with
+// an optional port, or an SCP-like Git address.
+func Bypass(noProxy, target string) bool {
+	if noProxy == "" {
+		return false
+	}
+	host := targetHost(target)
+	if host == "" {
+		return false
+	}
+	for _, entry := range strings.Split(noProxy, ",") {
+		if matches(strings.ToLower(strings.TrimSpace(entry)), host) {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(entry, host string) bool {
+	switch entry {
+	case "":
+		return false
+	case "*":
+		return true
+	}
+	if _, cidr, err := net.ParseCIDR(entry); err == nil {
+		ip := net.ParseIP(host)
+		return ip != nil && cidr.Contains(ip)
+	}
+	if h, _, err := net.SplitHostPort(entry); err == nil {
+		entry = h
+	}
+	entry = strings.Trim(entry, "[]")
+	if strings.HasPrefix(entry, "*.") {
+		entry = entry[1:]
+	}
+	if strings.HasPrefix(entry, ".") {
+		return strings.HasSuffix(host, entry)
+	}
+	return host == entry || strings.HasSuffix(host, "."+entry)
+}
+
+// targetHost returns the lower-cased host of the given target address.
+func targetHost(target string) string {
+	if strings.Contains(target, "://") {
+		u, err := url.Parse(target)
+		if err != nil {
+			return ""
+		}
+		return strings.ToLower(u.Hostname())
+	}
+	if i := strings.IndexByte(target, '/'); i >= 0 {
+		target = target[:i]
+	}
+	if i := strings.LastIndexByte(target, '@'); i >= 0 {
+		target = target[i+1:]
+	}
+	if h, _, err := net.SplitHostPort(target); err == nil {
+		target = h
+	} else if !strings.HasPrefix(target, "[") {
+		// SCP-like address, e.g. 'github.com:org/repo'.
+		target, _, _ = strings.Cut(target, ":")
+	}
+	return strings.ToLower(strings.Trim(target, "[]"))
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit limits the rate at which the objects of a namespace are
+// reconciled, so that a single tenant can not consume the reconcile capacity
+// of the controller.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/pflag"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Options contains the configuration of the NamespaceLimiter.
+type Options struct {
+	// QPS is the maximum number of reconciliations per second of the
+	// objects of a namespace, across all kinds. A value of 0 disables the
+	// rate limiting.
+	QPS float64
+	// Burst is the maximum number of reconciliations of the objects of a
+	// namespace which may happen at once.
+	Burst int
+}
+
+// BindFlags will parse the given pflag.FlagSet for the namespace rate
+// limiting option flags and set the Options accordingly.
+func (o *Options) BindFlags(fs *pflag.FlagSet) {
+	fs.Float64Var(&o.QPS, "namespace-reconcile-qps", 0,
+		"The maximum number of reconciliations per second of the sources of a namespace. A value of 0 disables the per-namespace rate limiting.")
+	fs.IntVar(&o.Burst, "namespace-reconcile-burst", 100,
+		"The maximum number of reconciliations of the sources of a namespace which may happen at once.")
+}
+
+// sweepInterval is the interval at which the idle token buckets and the
+// unclaimed reservations are removed.
+const sweepInterval = time.Minute
+
+// NamespaceLimiter limits the rate of the reconciliations of the objects of
+// each namespace with a token bucket per namespace. Reconciliations over the
+// limit are requeued after the duration at which a token is reserved for
+// them, instead of being processed. As the requeued reconciliations hold a
+// reservation, they are processed in order instead of competing for the
+// next token all at once.
+//
+// The token buckets of the namespaces which are idle, i.e. whose bucket is
+// full again, are removed, as are those of deleted namespaces.
+//
+// The limit is enforced in front of the reconciler instead of in the
+// workqueue rate limiter, as the latter only applies to retries and not to
+// the watch events of created or annotated objects.
+//
+// All methods are safe to call on a nil NamespaceLimiter, which does not
+// limit.
+type NamespaceLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mu        sync.Mutex
+	limiters  map[string]*rate.Limiter
+	reserved  map[reservationKey]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+
+	throttledCounter *prometheus.CounterVec
+}
+
+// NewNamespaceLimiter returns a new NamespaceLimiter configured with the
+// given Options, or nil if the QPS is not greater than zero. The configured
+// labels are: kind, namespace.
+func NewNamespaceLimiter(opts Options) *NamespaceLimiter {
+	if opts.QPS <= 0 {
+		return nil
+	}
+	burst := opts.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	return &NamespaceLimiter{
+		limit:    rate.Limit(opts.QPS),
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+		reserved: make(map[reservationKey]time.Time),
+		now:      time.Now,
+		throttledCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "gotk_namespace_reconcile_throttled_total",
+				Help: "Total number of reconciliations delayed by the per-namespace rate limit.",
+			},
+			[]string{"kind", "namespace"},
+		),
+	}
+}
+
+// Collectors returns the metrics.Collector objects for the NamespaceLimiter.
+func (l *NamespaceLimiter) Collectors() []prometheus.Collector {
+	if l == nil {
+		return nil
+	}
+	return []prometheus.Collector{l.throttledCounter}
+}
+
+// reservationKey identifies the object a token is reserved for.
+type reservationKey struct {
+	kind string
+	types.NamespacedName
+}
+
+// Delay takes a token for a reconciliation of the object of the given kind
+// and key and returns zero, or reserves the next token of its namespace for
+// it and returns the duration after which the token is available. A
+// reconciliation of the object after that duration is admitted with the
+// reserved token.
+func (l *NamespaceLimiter) Delay(kind string, key types.NamespacedName) time.Duration {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+	rkey := reservationKey{kind: kind, NamespacedName: key}
+	if at, ok := l.reserved[rkey]; ok {
+		if d := at.Sub(now); d > 0 {
+			return d
+		}
+		delete(l.reserved, rkey)
+		return 0
+	}
+	if d := l.limiter(key.Namespace).ReserveN(now, 1).DelayFrom(now); d > 0 {
+		l.reserved[rkey] = now.Add(d)
+		return d
+	}
+	return 0
+}
+
+// Reconciler returns a reconcile.Reconciler which requeues the requests of
+// the given kind over the limit of their namespace, and passes the others
+// to the given reconcile.Reconciler.
+func (l *NamespaceLimiter) Reconciler(kind string, next reconcile.Reconciler) reconcile.Reconciler {
+	if l == nil {
+		return next
+	}
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		if d := l.Delay(kind, req.NamespacedName); d > 0 {
+			l.throttledCounter.WithLabelValues(kind, req.Namespace).Inc()
+			ctrl.LoggerFrom(ctx).V(1).Info("namespace reconcile rate limit exceeded", "requeueAfter", d)
+			return reconcile.Result{RequeueAfter: d}, nil
+		}
+		return next.Reconcile(ctx, req)
+	})
+}
+
+// limiter returns the token bucket of the given namespace. It must be
+// called with the lock held.
+func (l *NamespaceLimiter) limiter(namespace string) *rate.Limiter {
+	limiter, ok := l.limiters[namespace]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[namespace] = limiter
+	}
+	return limiter
+}
+
+// sweep removes the token buckets which are full, as they do not differ
+// from new ones, and the reservations which were not claimed within the
+// sweep interval, at most once per sweep interval. It must be called with
+// the lock held.
+func (l *NamespaceLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for namespace, limiter := range l.limiters {
+		if limiter.TokensAt(now) >= float64(l.burst) {
+			delete(l.limiters, namespace)
+		}
+	}
+	for key, at := range l.reserved {
+		if now.Sub(at) > sweepInterval {
+			delete(l.reserved, key)
+		}
+	}
+}
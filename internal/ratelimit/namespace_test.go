@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestNamespaceLimiter_Reconciler(t *testing.T) {
+	g := NewWithT(t)
+
+	l := NewNamespaceLimiter(Options{QPS: 0.001, Burst: 2})
+	var calls int
+	r := l.Reconciler("GitRepository", reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		calls++
+		return reconcile.Result{}, nil
+	}))
+
+	request := func(namespace string) reconcile.Result {
+		result, err := r.Reconcile(context.TODO(), reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: namespace, Name: "podinfo"},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+
+	g.Expect(request("team-a")).To(Equal(reconcile.Result{}))
+	g.Expect(request("team-a")).To(Equal(reconcile.Result{}))
+	g.Expect(request("team-a").RequeueAfter).To(BeNumerically(">", 0))
+	g.Expect(calls).To(Equal(2))
+
+	// Other namespaces have their own bucket.
+	g.Expect(request("team-b")).To(Equal(reconcile.Result{}))
+	g.Expect(calls).To(Equal(3))
+
+	g.Expect(testutil.ToFloat64(l.throttledCounter.WithLabelValues("GitRepository", "team-a"))).To(Equal(float64(1)))
+}
+
+func TestNamespaceLimiter_Delay(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	l := NewNamespaceLimiter(Options{QPS: 1, Burst: 1})
+	l.now = func() time.Time { return now }
+	key := func(name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: "team-a", Name: name}
+	}
+
+	g.Expect(l.Delay("GitRepository", key("a"))).To(BeZero())
+
+	// The throttled objects reserve the next tokens in order, instead of
+	// all being requeued for the same one.
+	g.Expect(l.Delay("GitRepository", key("b"))).To(Equal(time.Second))
+	g.Expect(l.Delay("GitRepository", key("c"))).To(Equal(2 * time.Second))
+	// An object which is requeued again keeps its reservation.
+	g.Expect(l.Delay("GitRepository", key("b"))).To(Equal(time.Second))
+
+	now = now.Add(time.Second)
+	g.Expect(l.Delay("GitRepository", key("b"))).To(BeZero())
+	g.Expect(l.Delay("GitRepository", key("c"))).To(Equal(time.Second))
+	g.Expect(l.reserved).To(HaveLen(1))
+
+	// The idle token buckets and the unclaimed reservations are removed.
+	now = now.Add(2 * sweepInterval)
+	g.Expect(l.Delay("GitRepository", key("d"))).To(BeZero())
+	g.Expect(l.reserved).To(BeEmpty())
+	g.Expect(l.limiters).To(HaveLen(1))
+	l.lastSweep = time.Time{}
+	l.sweep(now.Add(2 * time.Second))
+	g.Expect(l.limiters).To(BeEmpty())
+}
+
+func TestNamespaceLimiter_DelayKinds(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	l := NewNamespaceLimiter(Options{QPS: 1, Burst: 1})
+	l.now = func() time.Time { return now }
+	key := types.NamespacedName{Namespace: "team-a", Name: "podinfo"}
+
+	g.Expect(l.Delay("GitRepository", types.NamespacedName{Namespace: "team-a", Name: "other"})).To(BeZero())
+
+	// Objects of different kinds with the same name hold their own
+	// reservations.
+	g.Expect(l.Delay("GitRepository", key)).To(Equal(time.Second))
+	g.Expect(l.Delay("HelmChart", key)).To(Equal(2 * time.Second))
+	g.Expect(l.reserved).To(HaveLen(2))
+
+	now = now.Add(time.Second)
+	g.Expect(l.Delay("GitRepository", key)).To(BeZero())
+	g.Expect(l.Delay("HelmChart", key)).To(Equal(time.Second))
+
+	now = now.Add(time.Second)
+	g.Expect(l.Delay("HelmChart", key)).To(BeZero())
+	g.Expect(l.reserved).To(BeEmpty())
+}
+
+func TestNamespaceLimiter_Disabled(t *testing.T) {
+	g := NewWithT(t)
+
+	l := NewNamespaceLimiter(Options{})
+	g.Expect(l).To(BeNil())
+	g.Expect(l.Delay("GitRepository", types.NamespacedName{Namespace: "team-a", Name: "podinfo"})).To(BeZero())
+	g.Expect(l.Collectors()).To(BeEmpty())
+
+	next := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, nil
+	})
+	g.Expect(l.Reconciler("GitRepository", next)).ToNot(BeNil())
+}
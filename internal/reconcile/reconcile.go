@@ -19,6 +19,7 @@ package reconcile
 import (
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/fluxcd/pkg/apis/meta"
@@ -218,6 +219,30 @@ func FailureRecovery(oldObj, newObj conditions.Getter, failConditions []string)
 	return failuresBefore > 0
 }
 
+// MinFailureBackoff is the interval after which an object which just started
+// failing is retried, when failure backoff is enabled.
+const MinFailureBackoff = 10 * time.Second
+
+// FailureBackoff returns the interval after which a failing object should be
+// retried. The interval equals the time the object has been failing for,
+// which is derived from the last transition of its Ready condition to False.
+// Every retry thereby doubles the time the object has been failing for,
+// resulting in an exponential backoff between MinFailureBackoff and
+// maxInterval. As the interval is derived from the object status, the
+// backoff survives controller restarts.
+func FailureBackoff(obj conditions.Getter, maxInterval time.Duration, now time.Time) time.Duration {
+	interval := MinFailureBackoff
+	if c := conditions.Get(obj, meta.ReadyCondition); c != nil && c.Status == metav1.ConditionFalse {
+		if failing := now.Sub(c.LastTransitionTime.Time); failing > interval {
+			interval = failing
+		}
+	}
+	if interval > maxInterval {
+		interval = maxInterval
+	}
+	return interval.Round(time.Second)
+}
+
 // addPatchOptionWithStatusObservedGeneration adds patch option
 // WithStatusObservedGeneration to the provided patch option slice only if there
 // is any condition present on the object, and returns it. This is necessary to
@@ -393,6 +393,82 @@ func TestFailureRecovery(t *testing.T) {
 	}
 }
 
+func TestFailureBackoff(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		readyFunc   func(obj *sourcev1.GitRepository)
+		maxInterval time.Duration
+		want        time.Duration
+	}{
+		{
+			name:        "no Ready condition",
+			maxInterval: time.Hour,
+			want:        MinFailureBackoff,
+		},
+		{
+			name: "just started failing",
+			readyFunc: func(obj *sourcev1.GitRepository) {
+				obj.Status.Conditions = []metav1.Condition{{
+					Type:               meta.ReadyCondition,
+					Status:             metav1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Second)),
+				}}
+			},
+			maxInterval: time.Hour,
+			want:        MinFailureBackoff,
+		},
+		{
+			name: "failing for a while",
+			readyFunc: func(obj *sourcev1.GitRepository) {
+				obj.Status.Conditions = []metav1.Condition{{
+					Type:               meta.ReadyCondition,
+					Status:             metav1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(now.Add(-5 * time.Minute)),
+				}}
+			},
+			maxInterval: time.Hour,
+			want:        5 * time.Minute,
+		},
+		{
+			name: "capped at max interval",
+			readyFunc: func(obj *sourcev1.GitRepository) {
+				obj.Status.Conditions = []metav1.Condition{{
+					Type:               meta.ReadyCondition,
+					Status:             metav1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(now.Add(-3 * time.Hour)),
+				}}
+			},
+			maxInterval: time.Hour,
+			want:        time.Hour,
+		},
+		{
+			name: "Ready condition True",
+			readyFunc: func(obj *sourcev1.GitRepository) {
+				obj.Status.Conditions = []metav1.Condition{{
+					Type:               meta.ReadyCondition,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(now.Add(-time.Hour)),
+				}}
+			},
+			maxInterval: time.Hour,
+			want:        MinFailureBackoff,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := &sourcev1.GitRepository{}
+			if tt.readyFunc != nil {
+				tt.readyFunc(obj)
+			}
+			g.Expect(FailureBackoff(obj, tt.maxInterval, now)).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestAddOptionWithStatusObservedGeneration(t *testing.T) {
 	tests := []struct {
 		name       string
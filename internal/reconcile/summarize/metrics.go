@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarize
+
+import (
+	"reflect"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// backoffErrorsCounter counts the reconciliation errors retried with the
+// failure backoff. These errors are not returned to controller-runtime, and
+// are therefore missing from its controller_runtime_reconcile_errors_total
+// metric.
+var backoffErrorsCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "gotk_reconcile_backoff_errors_total",
+		Help: "Total number of reconciliation errors retried with the failure backoff instead of the work queue.",
+	},
+	[]string{"kind"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(backoffErrorsCounter)
+}
+
+// recordBackoffError increments the backoff errors counter for the kind of
+// the given object. The kind is derived from the Go type of the object, as
+// the type meta of typed objects is not reliably set.
+func recordBackoffError(obj any) {
+	backoffErrorsCounter.WithLabelValues(reflect.Indirect(reflect.ValueOf(obj)).Type().Name()).Inc()
+}
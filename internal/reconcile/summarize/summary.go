@@ -19,6 +19,7 @@ package summarize
 import (
 	"context"
 	"errors"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -93,6 +94,12 @@ type HelperOptions struct {
 	// BiPolarityConditionTypes is a list of bipolar conditions in the order
 	// of priority.
 	BiPolarityConditionTypes []string
+	// MaxFailureBackoff enables the failure backoff when non-zero. Failing
+	// objects are requeued with reconcile.FailureBackoff up to this interval,
+	// instead of returning the error to the rate limited work queue. The
+	// error is logged and counted in gotk_reconcile_backoff_errors_total
+	// instead.
+	MaxFailureBackoff time.Duration
 }
 
 // Option is configuration that modifies SummarizeAndPatch.
@@ -160,6 +167,14 @@ func WithBiPolarityConditionTypes(types ...string) Option {
 	}
 }
 
+// WithFailureBackoff enables the failure backoff with the given maximum
+// interval. A zero interval disables the failure backoff.
+func WithFailureBackoff(maxInterval time.Duration) Option {
+	return func(s *HelperOptions) {
+		s.MaxFailureBackoff = maxInterval
+	}
+}
+
 // SummarizeAndPatch summarizes and patches the result to the target object.
 // When used at the very end of a reconciliation, the result builder must be
 // specified using the Option WithResultBuilder(). The returned result and error
@@ -249,6 +264,17 @@ func (h *Helper) SummarizeAndPatch(ctx context.Context, obj conditions.Setter, o
 		}
 	}
 
+	// Back off from retrying a failing object, recording the interval in the
+	// Reconciling condition.
+	var backoffErr error
+	if opts.MaxFailureBackoff > 0 && recErr != nil && !conditions.IsStalled(obj) {
+		backoff := reconcile.FailureBackoff(obj, opts.MaxFailureBackoff, time.Now())
+		conditions.MarkReconciling(obj, meta.ProgressingWithRetryReason,
+			"reconciliation failed, retrying in %s", backoff)
+		backoffErr, recErr = recErr, nil
+		result = ctrl.Result{RequeueAfter: backoff}
+	}
+
 	// Finally, patch the resource.
 	if err := h.serialPatcher.Patch(ctx, obj, patchOpts...); err != nil {
 		// Ignore patch error "not found" when the object is being deleted.
@@ -258,6 +284,15 @@ func (h *Helper) SummarizeAndPatch(ctx context.Context, obj conditions.Setter, o
 		recErr = kerrors.NewAggregate([]error{recErr, err})
 	}
 
+	// The backoff error is not returned to the work queue, which would retry
+	// it with its own rate limit, and ignore the result. Log and count it
+	// instead, as controller-runtime does for returned errors.
+	if backoffErr != nil {
+		ctrl.LoggerFrom(ctx).Error(backoffErr, "reconciliation failed, backing off",
+			"retryAfter", result.RequeueAfter.String())
+		recordBackoffError(obj)
+	}
+
 	return result, recErr
 }
 
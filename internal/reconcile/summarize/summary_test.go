@@ -24,6 +24,7 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
@@ -492,6 +493,54 @@ func TestSummarizeAndPatch_Intermediate(t *testing.T) {
 	}
 }
 
+func TestSummarizeAndPatch_FailureBackoff(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(sourcev1.AddToScheme(scheme)).To(Succeed())
+
+	c := fakeclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&sourcev1.GitRepository{}).
+		Build()
+
+	obj := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "test-",
+		},
+		Spec: sourcev1.GitRepositorySpec{
+			Interval: metav1.Duration{Duration: time.Minute},
+		},
+	}
+	conditions.MarkTrue(obj, sourcev1.FetchFailedCondition, sourcev1.GitOperationFailedReason, "failed to checkout")
+
+	ctx := context.TODO()
+	g.Expect(c.Create(ctx, obj)).To(Succeed())
+	serialPatcher := patch.NewSerialPatcher(obj, c)
+
+	before := testutil.ToFloat64(backoffErrorsCounter.WithLabelValues("GitRepository"))
+
+	summaryHelper := NewHelper(record.NewFakeRecorder(32), serialPatcher)
+	result, err := summaryHelper.SummarizeAndPatch(ctx, obj,
+		WithConditions(Conditions{
+			Target:           meta.ReadyCondition,
+			Owned:            []string{sourcev1.FetchFailedCondition, meta.ReadyCondition, meta.ReconcilingCondition},
+			Summarize:        []string{sourcev1.FetchFailedCondition},
+			NegativePolarity: []string{sourcev1.FetchFailedCondition, meta.ReconcilingCondition},
+		}),
+		WithReconcileResult(reconcile.ResultEmpty),
+		WithReconcileError(errors.New("failed to checkout")),
+		WithResultBuilder(reconcile.AlwaysRequeueResultBuilder{RequeueAfter: time.Minute}),
+		WithFailureBackoff(time.Hour),
+	)
+
+	// The error is not returned to the work queue, but counted instead.
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: reconcile.MinFailureBackoff}))
+	g.Expect(conditions.IsReconciling(obj)).To(BeTrue())
+	g.Expect(testutil.ToFloat64(backoffErrorsCounter.WithLabelValues("GitRepository"))).To(Equal(before + 1))
+}
+
 func TestIsNonStalledSuccess(t *testing.T) {
 	interval := 5 * time.Second
 
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing configures the OpenTelemetry trace pipeline of
+// source-controller.
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/contrib/exporters/autoexport"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer used by source-controller.
+const TracerName = "github.com/fluxcd/source-controller"
+
+// Options contains the configuration of the trace pipeline.
+type Options struct {
+	// Enabled configures whether spans are exported. The exporter is
+	// configured using the standard OTEL_* environment variables, e.g.
+	// OTEL_TRACES_EXPORTER and OTEL_EXPORTER_OTLP_ENDPOINT.
+	Enabled bool
+}
+
+// BindFlags will parse the given pflag.FlagSet for the tracing option flags
+// and set the Options accordingly.
+func (o *Options) BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Enabled, "enable-tracing", false,
+		"Enable the export of OpenTelemetry traces. The exporter is configured using the OTEL_* environment variables.")
+}
+
+// Setup configures the global W3C trace context propagator, and when enabled,
+// a tracer provider exporting the recorded spans. The returned function must
+// be called on shutdown to flush any buffered spans.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	// Always propagate incoming trace context, even when the spans of this
+	// process are not exported, so that downstream calls remain correlated.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if !opts.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := autoexport.NewSpanExporter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// Tracer returns the tracer of source-controller from the global tracer
+// provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// StartSpan starts a span with the given name and attributes, as a child of
+// the span in the given context if there is one.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends the given span, after recording the given error and marking
+// the span as failed if the error is not nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ObjectAttributes returns the span attributes identifying the object with
+// the given kind, namespace and name.
+func ObjectAttributes(kind, namespace, name string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("source.kind", kind),
+		attribute.String("source.namespace", namespace),
+		attribute.String("source.name", name),
+	}
+}
+
+// HTTPHandler wraps the given http.Handler to extract the trace context from
+// the incoming request headers, and to record a span for every request.
+func HTTPHandler(h http.Handler, operation string) http.Handler {
+	return otelhttp.NewHandler(h, operation,
+		otelhttp.WithSpanNameFormatter(func(operation string, r *http.Request) string {
+			return operation + " " + r.Method
+		}),
+	)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	g := NewWithT(t)
+
+	shutdown, err := Setup(context.TODO(), Options{})
+	g.Expect(err).ToNot(HaveOccurred())
+	defer shutdown(context.TODO())
+
+	var sc trace.SpanContext
+	h := HTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sc = trace.SpanContextFromContext(r.Context())
+	}), "test")
+
+	req := httptest.NewRequest(http.MethodGet, "/gitrepository/default/podinfo/latest.tar.gz", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	g.Expect(sc.IsValid()).To(BeTrue())
+	g.Expect(sc.TraceID().String()).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+}
+
+func TestStartSpan(t *testing.T) {
+	g := NewWithT(t)
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(prev)
+
+	ctx, parent := StartSpan(context.TODO(), "reconcile GitRepository", ObjectAttributes("GitRepository", "default", "podinfo")...)
+	_, child := StartSpan(ctx, "git clone")
+	EndSpan(child, errors.New("authentication required"))
+	EndSpan(parent, nil)
+
+	spans := sr.Ended()
+	g.Expect(spans).To(HaveLen(2))
+
+	g.Expect(spans[0].Name()).To(Equal("git clone"))
+	g.Expect(spans[0].Parent().SpanID()).To(Equal(spans[1].SpanContext().SpanID()))
+	g.Expect(spans[0].Status().Code).To(Equal(codes.Error))
+	g.Expect(spans[0].Status().Description).To(Equal("authentication required"))
+
+	g.Expect(spans[1].Name()).To(Equal("reconcile GitRepository"))
+	g.Expect(spans[1].Status().Code).To(Equal(codes.Unset))
+	g.Expect(spans[1].Attributes()).To(ContainElement(attribute.String("source.name", "podinfo")))
+}
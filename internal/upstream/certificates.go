@@ -0,0 +1,361 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrNoTLS is returned by CertificateMonitor.Observe for upstreams which
+// are not reached over TLS.
+var ErrNoTLS = errors.New("upstream is not reached over TLS")
+
+// Certificate describes the leaf certificate presented by an upstream host.
+type Certificate struct {
+	// Fingerprint is the hex encoded SHA-256 digest of the certificate.
+	Fingerprint string
+	// Subject and Issuer are the distinguished names of the certificate.
+	Subject string
+	Issuer  string
+	// NotAfter is the expiry time of the certificate.
+	NotAfter time.Time
+}
+
+// Observation is the result of CertificateMonitor.Observe.
+type Observation struct {
+	// Certificate presented by the upstream.
+	Certificate Certificate
+	// PreviousFingerprint is the fingerprint of the certificate observed at
+	// the previous observation of the same source, if it differs.
+	PreviousFingerprint string
+	// Expiring is true when the certificate expires within the expiry
+	// warning duration.
+	Expiring bool
+}
+
+// CertificateMonitor records the TLS certificates presented by the upstream
+// hosts. The certificate of a host is retrieved with a TLS handshake, at
+// most once per probe interval, as the clients of the fetch protocols do
+// not expose it. The handshake goes through the same proxy as the fetch,
+// and does not verify the certificate, which is left to the fetch itself.
+// A failed handshake is not retried before the next probe interval either.
+//
+// All methods are safe to call on a nil CertificateMonitor, in which case
+// nothing is recorded.
+type CertificateMonitor struct {
+	expiryWarning time.Duration
+	probeInterval time.Duration
+
+	expiryGauge    *prometheus.GaugeVec
+	changesCounter *prometheus.CounterVec
+
+	mu      sync.Mutex
+	probed  map[string]probe
+	sources map[string]string
+	now     func() time.Time
+	proxy   func(*http.Request) (*url.URL, error)
+	dial    func(ctx context.Context, address, serverName string, proxyURL *url.URL) (*tls.ConnectionState, error)
+}
+
+type probe struct {
+	certificate Certificate
+	err         error
+	at          time.Time
+}
+
+// NewCertificateMonitor returns a new CertificateMonitor warning about the
+// certificates expiring within the given duration, and probing each host
+// at most once per the given interval. The configured label is: host.
+func NewCertificateMonitor(expiryWarning, probeInterval time.Duration) *CertificateMonitor {
+	return &CertificateMonitor{
+		expiryWarning: expiryWarning,
+		probeInterval: probeInterval,
+		expiryGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gotk_upstream_certificate_expiry_timestamp_seconds",
+				Help: "The expiry time of the TLS certificate presented by an upstream host.",
+			},
+			[]string{"host"},
+		),
+		changesCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "gotk_upstream_certificate_changes_total",
+				Help: "Total number of times the TLS certificate presented by an upstream host changed.",
+			},
+			[]string{"host"},
+		),
+		probed:  make(map[string]probe),
+		sources: make(map[string]string),
+		now:     time.Now,
+		proxy:   http.ProxyFromEnvironment,
+		dial:    dialTLS,
+	}
+}
+
+// Collectors returns the metrics.Collector objects for the
+// CertificateMonitor.
+func (m *CertificateMonitor) Collectors() []prometheus.Collector {
+	if m == nil {
+		return nil
+	}
+	return []prometheus.Collector{
+		m.expiryGauge,
+		m.changesCounter,
+	}
+}
+
+// Observe returns the certificate presented by the host of the given
+// upstream address for the source identified by the given key, and whether
+// it changed since the previous observation of that source. Addresses
+// without a scheme are considered to be reached over TLS, unless insecure is
+// true. The host is reached through the given proxy, or when it is nil
+// through the proxy configured in the environment (HTTPS_PROXY and
+// NO_PROXY). It returns a nil Observation for a nil CertificateMonitor.
+func (m *CertificateMonitor) Observe(ctx context.Context, source, address string, insecure bool, proxyURL *url.URL) (*Observation, error) {
+	if m == nil {
+		return nil, nil
+	}
+	hostPort, err := tlsAddress(address, insecure)
+	if err != nil {
+		return nil, err
+	}
+	host := Host(hostPort)
+	if proxyURL == nil {
+		proxyURL, err = m.proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: hostPort}})
+		if err != nil {
+			return nil, err
+		}
+	}
+	// A proxy may present certificates of its own, the probes through
+	// different proxies are therefore kept apart.
+	probeKey := hostPort
+	if proxyURL != nil {
+		probeKey += " via " + proxyURL.Redacted()
+	}
+
+	m.mu.Lock()
+	p, ok := m.probed[probeKey]
+	m.mu.Unlock()
+	if !ok || m.now().Sub(p.at) >= m.probeInterval {
+		cert, err := m.probeCertificate(ctx, hostPort, host, proxyURL)
+		// A failed probe keeps the previous certificate, to compare the
+		// certificate of the next successful probe with.
+		p = probe{certificate: p.certificate, err: err, at: m.now()}
+		if err != nil {
+			m.mu.Lock()
+			m.probed[probeKey] = p
+			m.mu.Unlock()
+			return nil, err
+		}
+		p.certificate = cert
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if previous, ok := m.probed[probeKey]; ok && previous.certificate.Fingerprint != "" && previous.certificate.Fingerprint != p.certificate.Fingerprint {
+		m.changesCounter.WithLabelValues(host).Inc()
+	}
+	m.probed[probeKey] = p
+	m.expiryGauge.WithLabelValues(host).Set(float64(p.certificate.NotAfter.Unix()))
+
+	obs := &Observation{
+		Certificate: p.certificate,
+		Expiring:    p.certificate.NotAfter.Sub(m.now()) < m.expiryWarning,
+	}
+	key := source + "@" + hostPort
+	if previous, ok := m.sources[key]; ok && previous != p.certificate.Fingerprint {
+		obs.PreviousFingerprint = previous
+	}
+	m.sources[key] = p.certificate.Fingerprint
+	return obs, nil
+}
+
+// probeCertificate returns the leaf certificate presented by the given
+// address, reached through the given proxy if it is not nil.
+func (m *CertificateMonitor) probeCertificate(ctx context.Context, address, serverName string, proxyURL *url.URL) (Certificate, error) {
+	state, err := m.dial(ctx, address, serverName, proxyURL)
+	if err != nil {
+		return Certificate{}, err
+	}
+	if len(state.PeerCertificates) == 0 {
+		return Certificate{}, ErrNoTLS
+	}
+	leaf := state.PeerCertificates[0]
+	digest := sha256.Sum256(leaf.Raw)
+	return Certificate{
+		Fingerprint: hex.EncodeToString(digest[:]),
+		Subject:     leaf.Subject.String(),
+		Issuer:      leaf.Issuer.String(),
+		NotAfter:    leaf.NotAfter,
+	}, nil
+}
+
+// Forget removes the observations of the source identified by the given
+// key, e.g. once it has been deleted.
+func (m *CertificateMonitor) Forget(source string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range m.sources {
+		if strings.HasPrefix(key, source+"@") {
+			delete(m.sources, key)
+		}
+	}
+}
+
+// tlsAddress returns the host:port reached over TLS for the given upstream
+// address, or ErrNoTLS.
+func tlsAddress(address string, insecure bool) (string, error) {
+	host := address
+	port := "443"
+	if strings.Contains(address, "://") {
+		u, err := url.Parse(address)
+		if err != nil {
+			return "", err
+		}
+		switch u.Scheme {
+		case "https", "oci":
+		default:
+			return "", ErrNoTLS
+		}
+		host = u.Host
+	} else if insecure {
+		return "", ErrNoTLS
+	}
+	if i := strings.IndexByte(host, '/'); i >= 0 {
+		host = host[:i]
+	}
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		host, port = h, p
+	}
+	if host == "" {
+		return "", ErrNoTLS
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// dialTLS performs a TLS handshake with the given address, through the
+// given HTTP(S) proxy if it is not nil, without verifying the presented
+// certificate, and returns the connection state.
+func dialTLS(ctx context.Context, address, serverName string, proxyURL *url.URL) (*tls.ConnectionState, error) {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var conn net.Conn
+	var err error
+	if proxyURL != nil {
+		conn, err = dialProxy(ctx, dialer, proxyURL, address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName: serverName,
+		// The certificate is only inspected, the fetch verifies it.
+		InsecureSkipVerify: true, // #nosec G402
+	})
+	handshakeCtx, cancel := context.WithTimeout(ctx, dialer.Timeout)
+	defer cancel()
+	if err := tlsConn.HandshakeContext(handshakeCtx); err != nil {
+		return nil, err
+	}
+	state := tlsConn.ConnectionState()
+	return &state, nil
+}
+
+// dialProxy opens a tunnel to the given address with a CONNECT request to
+// the HTTP(S) proxy at the given URL, authenticating with the user info of
+// the URL if it has any.
+func dialProxy(ctx context.Context, dialer *net.Dialer, proxyURL *url.URL, address string) (net.Conn, error) {
+	port := proxyURL.Port()
+	switch proxyURL.Scheme {
+	case "http", "":
+		if port == "" {
+			port = "80"
+		}
+	case "https":
+		if port == "" {
+			port = "443"
+		}
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme '%s'", proxyURL.Scheme)
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(proxyURL.Hostname(), port))
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.SetDeadline(time.Now().Add(dialer.Timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if proxyURL.Scheme == "https" {
+		conn = tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname()})
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxyURL.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy refused to connect to '%s': %s", address, resp.Status)
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
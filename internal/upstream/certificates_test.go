@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCertificateMonitor_Observe(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	leaf := server.Certificate()
+	digest := sha256.Sum256(leaf.Raw)
+
+	m := NewCertificateMonitor(time.Until(leaf.NotAfter)+time.Hour, time.Hour)
+	obs, err := m.Observe(context.TODO(), "gitrepository/default/podinfo", server.URL, false, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(obs.Certificate.Fingerprint).To(Equal(hex.EncodeToString(digest[:])))
+	g.Expect(obs.Certificate.NotAfter).To(Equal(leaf.NotAfter))
+	g.Expect(obs.PreviousFingerprint).To(BeEmpty())
+	g.Expect(obs.Expiring).To(BeTrue())
+	g.Expect(testutil.ToFloat64(m.expiryGauge.WithLabelValues("127.0.0.1"))).To(Equal(float64(leaf.NotAfter.Unix())))
+
+	// The certificate is not retrieved again within the probe interval.
+	m.dial = func(context.Context, string, string, *url.URL) (*tls.ConnectionState, error) {
+		return nil, errors.New("unexpected probe")
+	}
+	_, err = m.Observe(context.TODO(), "gitrepository/default/podinfo", server.URL, false, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// A changed certificate is reported once to each source.
+	now := time.Now()
+	m.now = func() time.Time { return now.Add(time.Hour) }
+	other := &x509.Certificate{Raw: []byte("other"), NotAfter: now.Add(365 * 24 * time.Hour)}
+	m.dial = func(context.Context, string, string, *url.URL) (*tls.ConnectionState, error) {
+		return &tls.ConnectionState{PeerCertificates: []*x509.Certificate{other}}, nil
+	}
+	obs, err = m.Observe(context.TODO(), "gitrepository/default/podinfo", server.URL, false, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(obs.PreviousFingerprint).To(Equal(hex.EncodeToString(digest[:])))
+	g.Expect(testutil.ToFloat64(m.changesCounter.WithLabelValues("127.0.0.1"))).To(Equal(float64(1)))
+
+	obs, err = m.Observe(context.TODO(), "gitrepository/default/podinfo", server.URL, false, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(obs.PreviousFingerprint).To(BeEmpty())
+
+	// A source observing the host for the first time has nothing to compare.
+	obs, err = m.Observe(context.TODO(), "helmrepository/default/podinfo", server.URL, false, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(obs.PreviousFingerprint).To(BeEmpty())
+
+	m.Forget("gitrepository/default/podinfo")
+	g.Expect(m.sources).To(HaveLen(1))
+}
+
+func TestCertificateMonitor_ObserveFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	var probes int
+	m := NewCertificateMonitor(time.Hour, time.Hour)
+	m.dial = func(context.Context, string, string, *url.URL) (*tls.ConnectionState, error) {
+		probes++
+		return nil, errors.New("connection refused")
+	}
+
+	_, err := m.Observe(context.TODO(), "gitrepository/default/podinfo", "https://example.com", false, nil)
+	g.Expect(err).To(MatchError("connection refused"))
+
+	// The failure is returned again without a probe within the interval.
+	_, err = m.Observe(context.TODO(), "gitrepository/default/podinfo", "https://example.com", false, nil)
+	g.Expect(err).To(MatchError("connection refused"))
+	g.Expect(probes).To(Equal(1))
+
+	now := time.Now()
+	m.now = func() time.Time { return now.Add(time.Hour) }
+	_, err = m.Observe(context.TODO(), "gitrepository/default/podinfo", "https://example.com", false, nil)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(probes).To(Equal(2))
+}
+
+func TestCertificateMonitor_ObserveProxy(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	leaf := server.Certificate()
+	digest := sha256.Sum256(leaf.Raw)
+
+	var connects atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.Header.Get("Proxy-Authorization") != "Basic dXNlcjpwYXNz" {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		upstream, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer upstream.Close()
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		connects.Add(1)
+		if _, err := conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n")); err != nil {
+			return
+		}
+		go io.Copy(upstream, conn)
+		io.Copy(conn, upstream)
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	m := NewCertificateMonitor(time.Hour, time.Hour)
+	_, err = m.Observe(context.TODO(), "gitrepository/default/podinfo", server.URL, false, proxyURL)
+	g.Expect(err).To(MatchError(ContainSubstring("407 Proxy Authentication Required")))
+
+	proxyURL.User = url.UserPassword("user", "pass")
+	obs, err := m.Observe(context.TODO(), "gitrepository/default/podinfo", server.URL, false, proxyURL)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(obs.Certificate.Fingerprint).To(Equal(hex.EncodeToString(digest[:])))
+	g.Expect(connects.Load()).To(Equal(int32(1)))
+
+	// The proxy from the environment is used when none is given.
+	m = NewCertificateMonitor(time.Hour, time.Hour)
+	m.proxy = func(*http.Request) (*url.URL, error) {
+		return proxyURL, nil
+	}
+	_, err = m.Observe(context.TODO(), "gitrepository/default/podinfo", server.URL, false, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(connects.Load()).To(Equal(int32(2)))
+}
+
+func TestCertificateMonitor_Nil(t *testing.T) {
+	g := NewWithT(t)
+
+	var m *CertificateMonitor
+	obs, err := m.Observe(context.TODO(), "gitrepository/default/podinfo", "https://github.com", false, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(obs).To(BeNil())
+	g.Expect(m.Collectors()).To(BeEmpty())
+	m.Forget("gitrepository/default/podinfo")
+}
+
+func TestTLSAddress(t *testing.T) {
+	tests := []struct {
+		address  string
+		insecure bool
+		want     string
+		wantErr  bool
+	}{
+		{address: "https://github.com/org/repo", want: "github.com:443"},
+		{address: "oci://ghcr.io/org/repo", want: "ghcr.io:443"},
+		{address: "https://charts.example.com:8443/", want: "charts.example.com:8443"},
+		{address: "minio.example.com:9000", want: "minio.example.com:9000"},
+		{address: "s3.amazonaws.com", want: "s3.amazonaws.com:443"},
+		{address: "minio.example.com:9000", insecure: true, wantErr: true},
+		{address: "http://charts.example.com", wantErr: true},
+		{address: "ssh://git@github.com/org/repo", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := tlsAddress(tt.address, tt.insecure)
+			if tt.wantErr {
+				g.Expect(errors.Is(err, ErrNoTLS)).To(BeTrue())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
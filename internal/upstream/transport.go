@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"helm.sh/helm/v3/pkg/getter"
+)
+
+// RoundTripper returns an http.RoundTripper recording the requests made
+// through next, and the bytes read from their response bodies. Requests to
+// a host which exceeded its budget fail with a BudgetExceededError.
+// It returns next as is for a nil Accountant.
+func (a *Accountant) RoundTripper(next http.RoundTripper) http.RoundTripper {
+	if a == nil {
+		return next
+	}
+	return &roundTripper{accountant: a, next: next}
+}
+
+type roundTripper struct {
+	accountant *Accountant
+	next       http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if err := t.accountant.Allow(host); err != nil {
+		return nil, err
+	}
+	t.accountant.RecordRequest(host)
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+	resp.Body = &countingReadCloser{ReadCloser: resp.Body, accountant: t.accountant, host: host}
+	return resp, nil
+}
+
+// countingReadCloser records the bytes read from the wrapped io.ReadCloser.
+type countingReadCloser struct {
+	io.ReadCloser
+	accountant *Accountant
+	host       string
+}
+
+// Read implements io.Reader.
+func (r *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.accountant.RecordBytes(r.host, int64(n))
+	return n, err
+}
+
+// Getters returns the given getter.Providers with the getters they construct
+// recording the requests made, and the bytes downloaded. It returns the
+// providers as is for a nil Accountant.
+func (a *Accountant) Getters(providers getter.Providers) getter.Providers {
+	if a == nil {
+		return providers
+	}
+	wrapped := make(getter.Providers, 0, len(providers))
+	for _, p := range providers {
+		newGetter := p.New
+		wrapped = append(wrapped, getter.Provider{
+			Schemes: p.Schemes,
+			New: func(options ...getter.Option) (getter.Getter, error) {
+				g, err := newGetter(options...)
+				if err != nil {
+					return nil, err
+				}
+				return &countingGetter{Getter: g, accountant: a}, nil
+			},
+		})
+	}
+	return wrapped
+}
+
+// countingGetter records the requests made through the wrapped
+// getter.Getter, and the size of the returned data.
+type countingGetter struct {
+	getter.Getter
+	accountant *Accountant
+}
+
+// Get implements getter.Getter.
+func (g *countingGetter) Get(url string, options ...getter.Option) (*bytes.Buffer, error) {
+	if err := g.accountant.Allow(url); err != nil {
+		return nil, err
+	}
+	g.accountant.RecordRequest(url)
+
+	buf, err := g.Getter.Get(url, options...)
+	if buf != nil {
+		g.accountant.RecordBytes(url, int64(buf.Len()))
+	}
+	return buf, err
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package upstream accounts for the requests made to, and the bytes
+// downloaded from, the upstream hosts source-controller fetches from, and
+// enforces optional per-host download budgets.
+package upstream
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Options contains the configuration of the Accountant.
+type Options struct {
+	// Budgets contains the maximum number of bytes which may be downloaded
+	// from a host per BudgetInterval, indexed by host. The values are
+	// Kubernetes quantities, e.g. "50Gi".
+	Budgets map[string]string
+	// BudgetInterval is the interval after which the used budgets are reset.
+	BudgetInterval time.Duration
+
+	// CertificateProbeInterval is the interval at which the TLS certificate
+	// of an upstream host is retrieved, see CertificateMonitor. A value of 0
+	// disables the monitoring of the certificates.
+	CertificateProbeInterval time.Duration
+	// CertificateExpiryWarning is the duration before the expiry of a
+	// certificate from which a warning is recorded.
+	CertificateExpiryWarning time.Duration
+}
+
+// BindFlags will parse the given pflag.FlagSet for the upstream option flags
+// and set the Options accordingly.
+func (o *Options) BindFlags(fs *pflag.FlagSet) {
+	fs.StringToStringVar(&o.Budgets, "upstream-budgets", nil,
+		"The maximum number of bytes which may be downloaded from an upstream host per --upstream-budget-interval, e.g. 'ghcr.io=50Gi,charts.example.com=10Gi'.")
+	fs.DurationVar(&o.BudgetInterval, "upstream-budget-interval", 24*time.Hour,
+		"The interval after which the upstream budgets are reset.")
+	fs.DurationVar(&o.CertificateProbeInterval, "upstream-certificate-probe-interval", 0,
+		"The interval at which the TLS certificate of an upstream host is retrieved after a successful fetch, to record its expiry and warn about changes. A value of 0 disables the certificate monitoring.")
+	fs.DurationVar(&o.CertificateExpiryWarning, "upstream-certificate-expiry-warning", 14*24*time.Hour,
+		"The duration before the expiry of an upstream TLS certificate from which a warning event is recorded for the sources fetching from it.")
+}
+
+// BudgetExceededError is returned when the download budget of a host has
+// been used up.
+type BudgetExceededError struct {
+	// Host the budget applies to.
+	Host string
+	// Budget in bytes per interval.
+	Budget int64
+	// Reset is the time at which the budget is reset.
+	Reset time.Time
+}
+
+// Error returns the error message.
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("download budget of %d bytes for upstream host '%s' exceeded until %s",
+		e.Budget, e.Host, e.Reset.UTC().Format(time.RFC3339))
+}
+
+// Accountant records the requests made to, and the bytes downloaded from,
+// upstream hosts. All methods are safe to call on a nil Accountant, in which
+// case nothing is recorded or enforced.
+type Accountant struct {
+	requestsCounter *prometheus.CounterVec
+	bytesCounter    *prometheus.CounterVec
+	rejectedCounter *prometheus.CounterVec
+
+	budgets  map[string]int64
+	interval time.Duration
+
+	mu          sync.Mutex
+	used        map[string]int64
+	windowStart time.Time
+	now         func() time.Time
+}
+
+// NewAccountant returns a new Accountant configured with the given Options.
+// The configured label is: host, which is the host (without port) of the
+// upstream.
+func NewAccountant(opts Options) (*Accountant, error) {
+	budgets := make(map[string]int64, len(opts.Budgets))
+	for host, v := range opts.Budgets {
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid budget '%s' for upstream host '%s': %w", v, host, err)
+		}
+		budgets[Host(host)] = q.Value()
+	}
+	if len(budgets) > 0 && opts.BudgetInterval <= 0 {
+		return nil, fmt.Errorf("upstream budget interval must be greater than zero")
+	}
+
+	return &Accountant{
+		requestsCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "gotk_upstream_requests_total",
+				Help: "Total number of requests made to an upstream host.",
+			},
+			[]string{"host"},
+		),
+		bytesCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "gotk_upstream_received_bytes_total",
+				Help: "Total number of bytes downloaded from an upstream host.",
+			},
+			[]string{"host"},
+		),
+		rejectedCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "gotk_upstream_budget_exceeded_total",
+				Help: "Total number of requests to an upstream host rejected because its download budget was exceeded.",
+			},
+			[]string{"host"},
+		),
+		budgets:  budgets,
+		interval: opts.BudgetInterval,
+		used:     make(map[string]int64),
+		now:      time.Now,
+	}, nil
+}
+
+// Collectors returns the metrics.Collector objects for the Accountant.
+func (a *Accountant) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		a.requestsCounter,
+		a.bytesCounter,
+		a.rejectedCounter,
+	}
+}
+
+// Allow returns a BudgetExceededError if the download budget of the given
+// host has been used up for the current interval.
+func (a *Accountant) Allow(host string) error {
+	if a == nil {
+		return nil
+	}
+	host = Host(host)
+	budget, ok := a.budgets[host]
+	if !ok {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.resetExpired()
+	if a.used[host] < budget {
+		return nil
+	}
+	a.rejectedCounter.WithLabelValues(host).Inc()
+	return &BudgetExceededError{
+		Host:   host,
+		Budget: budget,
+		Reset:  a.windowStart.Add(a.interval),
+	}
+}
+
+// RecordRequest records a request made to the given host.
+func (a *Accountant) RecordRequest(host string) {
+	if a == nil {
+		return
+	}
+	a.requestsCounter.WithLabelValues(Host(host)).Inc()
+}
+
+// RecordBytes records the given number of bytes downloaded from the given
+// host, and accounts them against its budget.
+func (a *Accountant) RecordBytes(host string, n int64) {
+	if a == nil || n <= 0 {
+		return
+	}
+	host = Host(host)
+	a.bytesCounter.WithLabelValues(host).Add(float64(n))
+
+	if _, ok := a.budgets[host]; !ok {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.resetExpired()
+	a.used[host] += n
+}
+
+// resetExpired resets the used budgets if the current interval has passed.
+// It must be called with the lock held.
+func (a *Accountant) resetExpired() {
+	now := a.now()
+	if a.windowStart.IsZero() || now.Sub(a.windowStart) >= a.interval {
+		a.windowStart = now
+		a.used = make(map[string]int64)
+	}
+}
+
+// Host returns the lower-cased host without user info and port of the given
+// URL, or of the given [user@]host[:port][/path] string.
+func Host(s string) string {
+	if strings.Contains(s, "://") {
+		if u, err := url.Parse(s); err == nil {
+			s = u.Host
+		}
+	}
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.LastIndexByte(s, '@'); i >= 0 {
+		s = s[i+1:]
+	}
+	if h, _, err := net.SplitHostPort(s); err == nil {
+		s = h
+	}
+	return strings.ToLower(s)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"helm.sh/helm/v3/pkg/getter"
+)
+
+func TestHost(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "https://Charts.Example.com/index.yaml", want: "charts.example.com"},
+		{in: "oci://ghcr.io/org/repo", want: "ghcr.io"},
+		{in: "ssh://git@github.com:22/org/repo", want: "github.com"},
+		{in: "git@github.com:org/repo", want: "github.com"},
+		{in: "minio.example.com:9000", want: "minio.example.com"},
+		{in: "ghcr.io/org/repo", want: "ghcr.io"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(Host(tt.in)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestNewAccountant(t *testing.T) {
+	g := NewWithT(t)
+
+	a, err := NewAccountant(Options{
+		Budgets:        map[string]string{"GHCR.io:443": "1Ki"},
+		BudgetInterval: time.Hour,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(a.budgets).To(Equal(map[string]int64{"ghcr.io": 1024}))
+
+	_, err = NewAccountant(Options{Budgets: map[string]string{"ghcr.io": "invalid"}, BudgetInterval: time.Hour})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid budget 'invalid' for upstream host 'ghcr.io'")))
+
+	_, err = NewAccountant(Options{Budgets: map[string]string{"ghcr.io": "1Ki"}})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestAccountant_Allow(t *testing.T) {
+	g := NewWithT(t)
+
+	a, err := NewAccountant(Options{
+		Budgets:        map[string]string{"ghcr.io": "100"},
+		BudgetInterval: time.Hour,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	now := time.Now()
+	a.now = func() time.Time { return now }
+
+	g.Expect(a.Allow("ghcr.io")).To(Succeed())
+	a.RecordBytes("https://ghcr.io/v2/", 60)
+	g.Expect(a.Allow("ghcr.io")).To(Succeed())
+	a.RecordBytes("ghcr.io", 40)
+
+	err = a.Allow("oci://ghcr.io/org/repo")
+	var budgetErr *BudgetExceededError
+	g.Expect(errors.As(err, &budgetErr)).To(BeTrue())
+	g.Expect(budgetErr.Host).To(Equal("ghcr.io"))
+	g.Expect(budgetErr.Reset).To(Equal(now.Add(time.Hour)))
+	g.Expect(testutil.ToFloat64(a.rejectedCounter.WithLabelValues("ghcr.io"))).To(Equal(float64(1)))
+
+	// Hosts without a budget are always allowed.
+	a.RecordBytes("docker.io", 1000)
+	g.Expect(a.Allow("docker.io")).To(Succeed())
+
+	// The budget is reset after the interval.
+	now = now.Add(time.Hour)
+	g.Expect(a.Allow("ghcr.io")).To(Succeed())
+
+	g.Expect(testutil.ToFloat64(a.bytesCounter.WithLabelValues("ghcr.io"))).To(Equal(float64(100)))
+	g.Expect(testutil.ToFloat64(a.bytesCounter.WithLabelValues("docker.io"))).To(Equal(float64(1000)))
+}
+
+func TestAccountant_Nil(t *testing.T) {
+	g := NewWithT(t)
+
+	var a *Accountant
+	g.Expect(a.Allow("ghcr.io")).To(Succeed())
+	a.RecordRequest("ghcr.io")
+	a.RecordBytes("ghcr.io", 10)
+	g.Expect(a.RoundTripper(http.DefaultTransport)).To(BeIdenticalTo(http.DefaultTransport))
+}
+
+func TestAccountant_RoundTripper(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("0123456789"))
+	}))
+	defer server.Close()
+
+	a, err := NewAccountant(Options{
+		Budgets:        map[string]string{"127.0.0.1": "15"},
+		BudgetInterval: time.Hour,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	client := &http.Client{Transport: a.RoundTripper(http.DefaultTransport)}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = io.ReadAll(resp.Body)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(resp.Body.Close()).To(Succeed())
+	}
+
+	_, err = client.Get(server.URL)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(errors.As(err, new(*BudgetExceededError))).To(BeTrue())
+
+	g.Expect(testutil.ToFloat64(a.requestsCounter.WithLabelValues("127.0.0.1"))).To(Equal(float64(2)))
+	g.Expect(testutil.ToFloat64(a.bytesCounter.WithLabelValues("127.0.0.1"))).To(Equal(float64(20)))
+}
+
+type mockGetter struct {
+	data []byte
+}
+
+func (g *mockGetter) Get(_ string, _ ...getter.Option) (*bytes.Buffer, error) {
+	return bytes.NewBuffer(g.data), nil
+}
+
+func TestAccountant_Getters(t *testing.T) {
+	g := NewWithT(t)
+
+	a, err := NewAccountant(Options{})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	providers := a.Getters(getter.Providers{
+		getter.Provider{
+			Schemes: []string{"http", "https"},
+			New: func(_ ...getter.Option) (getter.Getter, error) {
+				return &mockGetter{data: []byte("chart")}, nil
+			},
+		},
+	})
+	gt, err := providers.ByScheme("https")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	buf, err := gt.Get("https://charts.example.com/chart-0.1.0.tgz")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(buf.String()).To(Equal("chart"))
+
+	g.Expect(testutil.ToFloat64(a.requestsCounter.WithLabelValues("charts.example.com"))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(a.bytesCounter.WithLabelValues("charts.example.com"))).To(Equal(float64(5)))
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package workspace manages the temporary working directories in which the
+// reconcilers fetch and build the content of the Source objects.
+package workspace
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fluxcd/source-controller/internal/util"
+)
+
+// rootDirName is the name of the directory holding the workspaces, created
+// in the configured parent directory.
+const rootDirName = "source-controller-workspaces"
+
+// Options contains the configuration of the Manager.
+type Options struct {
+	// Dir is the parent directory of the workspaces root directory. The
+	// default directory for temporary files is used when empty.
+	Dir string
+	// Quota is the maximum size of a workspace, as a Kubernetes quantity.
+	// An empty value disables the quota.
+	Quota string
+}
+
+// BindFlags will parse the given pflag.FlagSet for the workspace option
+// flags and set the Options accordingly.
+func (o *Options) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Dir, "workspace-dir", "",
+		"The directory in which the temporary working directories of the reconciliations are created. Defaults to the directory for temporary files.")
+	fs.StringVar(&o.Quota, "workspace-quota", "",
+		"The maximum size of the temporary working directory of a reconciliation, e.g. '2Gi'. An empty value disables the quota.")
+}
+
+// QuotaExceededError is returned when a workspace exceeds the quota.
+type QuotaExceededError struct {
+	// Size of the workspace in bytes.
+	Size int64
+	// Quota in bytes.
+	Quota int64
+}
+
+// Error returns the error message.
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("working directory size of %d bytes exceeds the quota of %d bytes", e.Size, e.Quota)
+}
+
+// Manager creates the workspaces in a dedicated root directory, which is
+// emptied on start to remove the workspaces left behind by a previous run of
+// the process, e.g. after it was OOM killed. All methods are safe to call on
+// a nil Manager, which creates the workspaces in the directory for temporary
+// files without quota.
+type Manager struct {
+	root  string
+	quota int64
+
+	mu    sync.Mutex
+	kinds map[string]string
+
+	activeGauge   *prometheus.GaugeVec
+	sizeHistogram *prometheus.HistogramVec
+	quotaCounter  *prometheus.CounterVec
+}
+
+// NewManager returns a new Manager configured with the given Options, after
+// removing the workspaces left in its root directory. It returns the number
+// of removed workspaces. The configured label is: kind, which is the
+// lower-cased kind of the object the workspace is created for.
+func NewManager(opts Options) (*Manager, int, error) {
+	var quota int64
+	if opts.Quota != "" {
+		q, err := resource.ParseQuantity(opts.Quota)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid workspace quota '%s': %w", opts.Quota, err)
+		}
+		quota = q.Value()
+	}
+	dir := opts.Dir
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	root := filepath.Join(dir, rootDirName)
+
+	removed, err := clean(root)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &Manager{
+		root:  root,
+		quota: quota,
+		kinds: make(map[string]string),
+		activeGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gotk_workspace_active",
+				Help: "Number of temporary working directories in use.",
+			},
+			[]string{"kind"},
+		),
+		sizeHistogram: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "gotk_workspace_size_bytes",
+				Help:    "Size of the temporary working directories when they are removed.",
+				Buckets: prometheus.ExponentialBuckets(1<<20, 4, 8),
+			},
+			[]string{"kind"},
+		),
+		quotaCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "gotk_workspace_quota_exceeded_total",
+				Help: "Total number of temporary working directories which exceeded the quota.",
+			},
+			[]string{"kind"},
+		),
+	}, removed, nil
+}
+
+// Collectors returns the metrics.Collector objects for the Manager.
+func (m *Manager) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.activeGauge,
+		m.sizeHistogram,
+		m.quotaCounter,
+	}
+}
+
+// Create creates a new workspace for the given object, and returns its
+// path. The workspace must be removed with Remove.
+func (m *Manager) Create(obj client.Object) (string, error) {
+	if m == nil {
+		return util.TempDirForObj("", obj)
+	}
+	dir, err := util.TempDirForObj(m.root, obj)
+	if err != nil {
+		return "", err
+	}
+	kind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+	m.mu.Lock()
+	m.kinds[dir] = kind
+	m.mu.Unlock()
+	m.activeGauge.WithLabelValues(kind).Inc()
+	return dir, nil
+}
+
+// CheckQuota returns a QuotaExceededError if the size of the given workspace
+// exceeds the quota.
+func (m *Manager) CheckQuota(dir string) error {
+	if m == nil || m.quota <= 0 {
+		return nil
+	}
+	size, err := dirSize(dir)
+	if err != nil {
+		return err
+	}
+	if size <= m.quota {
+		return nil
+	}
+	m.mu.Lock()
+	kind := m.kinds[dir]
+	m.mu.Unlock()
+	m.quotaCounter.WithLabelValues(kind).Inc()
+	return &QuotaExceededError{Size: size, Quota: m.quota}
+}
+
+// Remove removes the given workspace.
+func (m *Manager) Remove(dir string) error {
+	if m == nil {
+		return os.RemoveAll(dir)
+	}
+	m.mu.Lock()
+	kind, ok := m.kinds[dir]
+	delete(m.kinds, dir)
+	m.mu.Unlock()
+	if ok {
+		if size, err := dirSize(dir); err == nil {
+			m.sizeHistogram.WithLabelValues(kind).Observe(float64(size))
+		}
+		m.activeGauge.WithLabelValues(kind).Dec()
+	}
+	return os.RemoveAll(dir)
+}
+
+// clean creates the given root directory, and removes all its entries.
+func clean(root string) (int, error) {
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return 0, fmt.Errorf("failed to create workspace root '%s': %w", root, err)
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list workspace root '%s': %w", root, err)
+	}
+	for _, e := range entries {
+		if err := os.RemoveAll(filepath.Join(root, e.Name())); err != nil {
+			return 0, fmt.Errorf("failed to remove stale workspace: %w", err)
+		}
+	}
+	return len(entries), nil
+}
+
+// dirSize returns the total size of the regular files in the given
+// directory.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestNewManager_RemovesStaleWorkspaces(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	stale := filepath.Join(dir, rootDirName, "gitrepository-default-podinfo-123")
+	g.Expect(os.MkdirAll(stale, 0o700)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(stale, "file"), []byte("content"), 0o600)).To(Succeed())
+
+	_, removed, err := NewManager(Options{Dir: dir})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(removed).To(Equal(1))
+	g.Expect(stale).ToNot(BeADirectory())
+	g.Expect(filepath.Join(dir, rootDirName)).To(BeADirectory())
+
+	_, _, err = NewManager(Options{Dir: dir, Quota: "invalid"})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid workspace quota")))
+}
+
+func TestManager(t *testing.T) {
+	g := NewWithT(t)
+
+	m, _, err := NewManager(Options{Dir: t.TempDir(), Quota: "10"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	obj := &sourcev1.GitRepository{
+		TypeMeta:   metav1.TypeMeta{Kind: sourcev1.GitRepositoryKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "default"},
+	}
+	dir, err := m.Create(obj)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(filepath.Dir(dir)).To(Equal(m.root))
+	g.Expect(testutil.ToFloat64(m.activeGauge.WithLabelValues("gitrepository"))).To(Equal(float64(1)))
+
+	g.Expect(os.WriteFile(filepath.Join(dir, "small"), []byte("12345"), 0o600)).To(Succeed())
+	g.Expect(m.CheckQuota(dir)).To(Succeed())
+
+	g.Expect(os.WriteFile(filepath.Join(dir, "large"), []byte("123456"), 0o600)).To(Succeed())
+	err = m.CheckQuota(dir)
+	var quotaErr *QuotaExceededError
+	g.Expect(errors.As(err, &quotaErr)).To(BeTrue())
+	g.Expect(quotaErr.Size).To(Equal(int64(11)))
+	g.Expect(testutil.ToFloat64(m.quotaCounter.WithLabelValues("gitrepository"))).To(Equal(float64(1)))
+
+	g.Expect(m.Remove(dir)).To(Succeed())
+	g.Expect(dir).ToNot(BeADirectory())
+	g.Expect(testutil.ToFloat64(m.activeGauge.WithLabelValues("gitrepository"))).To(Equal(float64(0)))
+}
+
+func TestManager_Nil(t *testing.T) {
+	g := NewWithT(t)
+
+	var m *Manager
+	dir, err := m.Create(&sourcev1.Bucket{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(m.CheckQuota(dir)).To(Succeed())
+	g.Expect(m.Remove(dir)).To(Succeed())
+	g.Expect(dir).ToNot(BeADirectory())
+}
@@ -17,10 +17,17 @@ limitations under the License.
 package main
 
 import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	flag "github.com/spf13/pflag"
@@ -57,11 +64,18 @@ import (
 	// +kubebuilder:scaffold:imports
 
 	"github.com/fluxcd/source-controller/internal/cache"
+	"github.com/fluxcd/source-controller/internal/cdn"
 	"github.com/fluxcd/source-controller/internal/controller"
 	intdigest "github.com/fluxcd/source-controller/internal/digest"
 	"github.com/fluxcd/source-controller/internal/features"
+	"github.com/fluxcd/source-controller/internal/fileserver"
 	"github.com/fluxcd/source-controller/internal/helm"
 	"github.com/fluxcd/source-controller/internal/helm/registry"
+	intjitter "github.com/fluxcd/source-controller/internal/jitter"
+	"github.com/fluxcd/source-controller/internal/ratelimit"
+	"github.com/fluxcd/source-controller/internal/tracing"
+	"github.com/fluxcd/source-controller/internal/upstream"
+	"github.com/fluxcd/source-controller/internal/workspace"
 )
 
 const controllerName = "source-controller"
@@ -100,8 +114,21 @@ func main() {
 		storagePath              string
 		storageAddr              string
 		storageAdvAddr           string
+		storageServiceName       string
+		storageClusterDomain     string
+		storageVirtualHosts      []string
+		storageTLSDir            string
+		storageHTTPSOnly         bool
+		storageImmutable         bool
+		storageDeduplicate       bool
+		storageQuarantine        bool
+		storageContentEncodings  []string
+		storageMaxDownloads      int
+		storageCDNOptions        cdn.Options
+		storagePurgeOptions      cdn.PurgeOptions
 		concurrent               int
 		requeueDependency        time.Duration
+		maxFailureBackoff        time.Duration
 		helmIndexLimit           int64
 		helmChartLimit           int64
 		helmChartFileLimit       int64
@@ -112,13 +139,45 @@ func main() {
 		featureGates             feathelper.FeatureGates
 		watchOptions             helper.WatchOptions
 		intervalJitterOptions    jitter.IntervalOptions
+		intervalJitterPerKind    []string
 		helmCacheMaxSize         int
 		helmCacheTTL             string
 		helmCachePurgeInterval   string
 		artifactRetentionTTL     time.Duration
 		artifactRetentionRecords int
+		artifactRetentionMaxTTL  time.Duration
+		artifactRetentionMaxRecs int
+		artifactRetentionMaxSize int64
+		artifactRetentionSusp    string
+		artifactShareWindow      time.Duration
 		artifactDigestAlgo       string
+		artifactCompression      int
+		artifactAuditInterval    time.Duration
+		backlogInterval          time.Duration
+		artifactReadBackTimeout  time.Duration
+		storageGCInterval        time.Duration
+		storageGCRateLimit       float64
+		storageGCConcurrency     int
+		storageGCDeferred        bool
+		artifactConsumerKinds    []string
+		storageShared            bool
+		storageFencePath         string
+		storageLeaseLocks        bool
+		storageLeaseDuration     time.Duration
+		featureGatesConfigMap    string
+		maintenance              bool
+		maintenanceConfigMap     string
+		adminAddr                string
+		helmWebhookAddr          string
+		diagnosticsPath          string
+		storageRetryInterval     time.Duration
+		storageUsageInterval     time.Duration
+		storageUsageThreshold    float64
 		tokenCacheOptions        pkgcache.TokenFlags
+		tracingOptions           tracing.Options
+		upstreamOptions          upstream.Options
+		namespaceLimiterOptions  ratelimit.Options
+		workspaceOptions         workspace.Options
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-addr", envOrDefault("METRICS_ADDR", ":8080"),
@@ -132,6 +191,42 @@ func main() {
 		"The address the static file server binds to.")
 	flag.StringVar(&storageAdvAddr, "storage-adv-addr", envOrDefault("STORAGE_ADV_ADDR", ""),
 		"The advertised address of the static file server.")
+	flag.StringVar(&storageServiceName, "storage-service-name", "source-controller",
+		"The name of the Service exposing the static file server. When no advertised address is set, it is derived from this Service in the runtime namespace, and updated when the Service changes.")
+	flag.StringVar(&storageClusterDomain, "storage-cluster-domain", "cluster.local",
+		"The DNS domain of the cluster, used to derive the advertised address from the storage Service.")
+	flag.StringSliceVar(&storageVirtualHosts, "storage-virtual-hosts", nil,
+		"The list of '<namespace>=<hostname>' virtual hosts of the static file server. The artifacts of a namespace are advertised under its virtual host, which only serves artifacts of that namespace.")
+	flag.StringVar(&storageTLSDir, "storage-tls-dir", envOrDefault("STORAGE_TLS_DIR", ""),
+		"The directory containing the 'tls.crt' and 'tls.key' files the static file server serves HTTPS with. Certificates for virtual hosts are read from sub directories named after their host name.")
+	flag.BoolVar(&storageHTTPSOnly, "storage-https-only", false,
+		"Advertise artifact URLs with the https scheme only. The controller refuses to start if an advertised address or virtual host has the http scheme.")
+	flag.BoolVar(&storageImmutable, "storage-immutable-artifacts", false,
+		"Never replace the content of a stored artifact. An artifact with a different content for the same path is stored at a path qualified with its digest instead.")
+	flag.BoolVar(&storageDeduplicate, "storage-deduplicate-artifacts", false,
+		"Store identical artifacts once, and hard link the artifact files to the stored content. Content no artifact links to anymore is removed by the storage garbage collection sweep, see --storage-gc-interval.")
+	flag.BoolVar(&storageQuarantine, "storage-quarantine-unverified-artifacts", false,
+		"Store the content of the GitRepository and OCIRepository revisions which fail verification in the quarantine area of the storage, and record its URL in a warning event for inspection. Quarantined content is never advertised in the status of the objects.")
+	flag.StringSliceVar(&storageContentEncodings, "storage-content-encodings", nil,
+		"The list of content encodings the static file server may re-encode tarball artifacts with on the fly for clients preferring them over gzip, e.g. 'zstd'.")
+	flag.IntVar(&storageMaxDownloads, "storage-max-concurrent-downloads", 0,
+		"The maximum number of artifact downloads the static file server serves concurrently, queueing the others. HEAD requests are never queued. A value of 0 disables the limit.")
+	flag.StringVar(&storageCDNOptions.BaseURL, "storage-cdn-url", envOrDefault("STORAGE_CDN_URL", ""),
+		"The URL of a CDN in front of the static file server, e.g. a CloudFront distribution. When set, artifact URLs are advertised under this URL instead of the advertised address.")
+	flag.StringVar(&storageCDNOptions.KeyPairID, "storage-cdn-key-pair-id", "",
+		"The ID of the CloudFront key pair used to sign the advertised CDN URLs.")
+	flag.StringVar(&storageCDNOptions.PrivateKeyFile, "storage-cdn-private-key-file", "",
+		"The path to the PEM encoded RSA private key of the CloudFront key pair, e.g. mounted from a Secret. When set, the advertised CDN URLs are signed.")
+	flag.DurationVar(&storageCDNOptions.URLTTL, "storage-cdn-url-ttl", cdn.DefaultURLTTL,
+		"The duration signed CDN URLs are valid for. Signed URLs are refreshed on reconciliation once half of the duration has passed.")
+	flag.StringVar(&storagePurgeOptions.Provider, "storage-purge-provider", "",
+		"The cache invalidation API called for the URLs of replaced and garbage collected artifacts, one of 'generic' or 'fastly'. The 'generic' provider POSTs the URLs as JSON to the purge address, e.g. a function invalidating a CloudFront distribution.")
+	flag.StringVar(&storagePurgeOptions.Address, "storage-purge-address", "",
+		"The address the 'generic' purge provider sends the URLs to purge to.")
+	flag.StringVar(&storagePurgeOptions.TokenFile, "storage-purge-token-file", "",
+		"The path to a file containing the token used to authenticate with the purge provider, e.g. mounted from a Secret.")
+	flag.StringSliceVar(&intervalJitterPerKind, "interval-jitter-percentage-per-kind", nil,
+		"The list of '<kind>=<percentage>' interval jitter overrides per source kind, e.g. 'OCIRepository=20'. A percentage of 0 disables the jitter for the kind.")
 	flag.IntVar(&concurrent, "concurrent", 2, "The number of concurrent reconciles per controller.")
 	flag.Int64Var(&helmIndexLimit, "helm-index-max-size", helm.MaxIndexSize,
 		"The max allowed size in bytes of a Helm repository index file.")
@@ -141,6 +236,8 @@ func main() {
 		"The max allowed size in bytes of a file in a Helm chart.")
 	flag.DurationVar(&requeueDependency, "requeue-dependency", 30*time.Second,
 		"The interval at which failing dependencies are reevaluated.")
+	flag.DurationVar(&maxFailureBackoff, "max-failure-backoff", 0,
+		"The maximum interval at which failing objects are retried. When set, failing objects back off exponentially from 10s up to this interval, and are retried immediately when a reconciliation is requested. A value of 0 keeps the rate limited retries.")
 	flag.IntVar(&helmCacheMaxSize, "helm-cache-max-size", 0,
 		"The maximum size of the cache in number of indexes.")
 	flag.StringVar(&helmCacheTTL, "helm-cache-ttl", "15m",
@@ -155,8 +252,63 @@ func main() {
 		"The duration of time that artifacts from previous reconciliations will be kept in storage before being garbage collected.")
 	flag.IntVar(&artifactRetentionRecords, "artifact-retention-records", 2,
 		"The maximum number of artifacts to be kept in storage after a garbage collection.")
+	flag.DurationVar(&artifactRetentionMaxTTL, "artifact-retention-max-override-ttl", 0,
+		"The maximum artifact retention TTL a source can set in its spec.artifact.retention.ttl, larger values are capped to it. A value of 0 disables the limit.")
+	flag.IntVar(&artifactRetentionMaxRecs, "artifact-retention-max-override-records", 0,
+		"The maximum number of artifacts a source can set in its spec.artifact.retention.maxRecords, larger values are capped to it. A value of 0 disables the limit.")
+	flag.Int64Var(&artifactRetentionMaxSize, "artifact-retention-max-size", 0,
+		"The maximum total size in bytes of the artifacts to be kept in storage for a source after a garbage collection. The current and pinned artifacts are always kept. A value of 0 disables the limit.")
+	flag.StringVar(&artifactRetentionSusp, "artifact-retention-suspended", string(controller.SuspendedRetentionPrune),
+		"The retention policy of the artifacts of suspended sources, one of: 'retain' to keep them until resumed, 'prune' to garbage collect them as for other sources, 'aggressive' to remove all but the current and pinned artifacts.")
+	flag.DurationVar(&artifactShareWindow, "artifact-share-window", 0,
+		"The duration for which an OCI artifact stored for a digest is reused by the other OCIRepositories resolving the same digest, instead of pulling it again. A value of 0 disables the sharing.")
 	flag.StringVar(&artifactDigestAlgo, "artifact-digest-algo", intdigest.Canonical.String(),
 		"The algorithm to use to calculate the digest of artifacts.")
+	flag.IntVar(&artifactCompression, "artifact-compression-level", gzip.DefaultCompression,
+		"The gzip compression level of the artifact archives, from 0 (no compression) to 9 (best compression). The default level is used when -1.")
+	flag.DurationVar(&storageUsageInterval, "storage-usage-interval", time.Minute,
+		"The interval at which the usage of the storage path is recorded. A value of 0 disables the recording.")
+	flag.Float64Var(&storageUsageThreshold, "storage-usage-warning-threshold", 90,
+		"The percentage of used bytes or inodes of the storage path above which Warning events are emitted for all sources. A value of 0 disables the events.")
+	flag.DurationVar(&artifactAuditInterval, "artifact-audit-interval", 10*time.Minute,
+		"The interval at which the artifacts advertised by sources are checked for presence in storage, objects with a missing artifact are reconciled immediately. A value of 0 disables the audit.")
+	flag.DurationVar(&backlogInterval, "reconcile-backlog-interval", time.Minute,
+		"The interval at which the number of sources not reconciled within their interval is recorded. A value of 0 disables the recording.")
+	flag.DurationVar(&artifactReadBackTimeout, "artifact-read-back-timeout", 0,
+		"The maximum duration to wait for a newly stored artifact to be downloadable from its URL with a matching digest, before it is advertised in the status of the source. A value of 0 disables the verification.")
+	flag.DurationVar(&storageGCInterval, "storage-gc-interval", time.Hour,
+		"The interval at which the storage is garbage collected for all sources, including suspended and deleted ones. A value of 0 disables the garbage collection sweep.")
+	flag.Float64Var(&storageGCRateLimit, "storage-gc-rate-limit", 10,
+		"The maximum number of sources garbage collected per second by the storage garbage collection sweep. A value of 0 disables the rate limiting.")
+	flag.IntVar(&storageGCConcurrency, "storage-gc-concurrency", 1,
+		"The maximum number of sources garbage collected at the same time by the storage garbage collection sweep.")
+	flag.StringSliceVar(&artifactConsumerKinds, "artifact-consumer-kinds", nil,
+		"The kinds of the consumers of sources, in the form of <group>/<version>/<kind>, e.g. 'kustomize.toolkit.fluxcd.io/v1/Kustomization'. The artifacts with the last attempted or applied revision of a consumer are not garbage collected. Requires the permission to list and watch the kinds.")
+	flag.BoolVar(&storageGCDeferred, "storage-gc-deferred", false,
+		"Leave the garbage collection of the artifacts of sources to the storage garbage collection sweep instead of running it at the end of each reconciliation. Requires a non-zero --storage-gc-interval.")
+	flag.BoolVar(&storageShared, "storage-shared", false,
+		"Fence the writes to a storage path shared by multiple replicas, e.g. on a ReadWriteMany volume, to the elected leader. All replicas serve the artifacts. Requires --storage-fence-path.")
+	flag.StringVar(&storageFencePath, "storage-fence-path", "",
+		"The path of the fencing token file of a shared storage. It must be on the shared volume, but outside the storage path to not be served with the artifacts.")
+	flag.BoolVar(&storageLeaseLocks, "storage-lease-locks", false,
+		"Lock the artifacts with Kubernetes Leases in the runtime namespace instead of lock files, so that replicas sharing the storage can not interleave writes to the same artifact regardless of the file locking support of the volume.")
+	flag.DurationVar(&storageLeaseDuration, "storage-lease-duration", 30*time.Second,
+		"The duration after which an artifact Lease which has not been renewed by its holder can be taken over. A lock is waited for at most ten times this duration.")
+
+	flag.DurationVar(&storageRetryInterval, "storage-retry-interval", time.Minute,
+		"The interval at which sources are retried while the storage is unavailable, instead of at the rate of the controller rate limiter. A value of 0 disables the backpressure.")
+	flag.StringVar(&featureGatesConfigMap, "feature-gates-configmap", "",
+		"The name of the ConfigMap in the runtime namespace with per-namespace feature gate overrides. An empty value disables the overrides.")
+	flag.BoolVar(&maintenance, "maintenance", false,
+		"Start in maintenance mode, in which sources are not reconciled and the storage is not garbage collected, while the artifacts are still served.")
+	flag.StringVar(&maintenanceConfigMap, "maintenance-configmap", "",
+		"The name of the ConfigMap in the runtime namespace whose '"+controller.MaintenanceAnnotation+"' annotation enables the maintenance mode when set to 'true'. An empty value disables the ConfigMap check.")
+	flag.StringVar(&adminAddr, "admin-addr", "",
+		"The address the admin API binds to, e.g. 'localhost:9091'. The API is not authenticated and is disabled when empty.")
+	flag.StringVar(&helmWebhookAddr, "helm-webhook-addr", "",
+		"The address the Helm repository webhook server binds to, e.g. ':9292'. Webhooks must present the token of the HelmRepository named by its '"+controller.HelmIndexRefreshSecretAnnotation+"' annotation. The server is disabled when empty.")
+	flag.StringVar(&diagnosticsPath, "diagnostics-path", "",
+		"The local directory to which a diagnostic bundle is written for every failed reconciliation, served by the admin API. It must be outside of the storage path. An empty value disables the bundles.")
 
 	clientOptions.BindFlags(flag.CommandLine)
 	logOptions.BindFlags(flag.CommandLine)
@@ -166,6 +318,10 @@ func main() {
 	watchOptions.BindFlags(flag.CommandLine)
 	intervalJitterOptions.BindFlags(flag.CommandLine)
 	tokenCacheOptions.BindFlags(flag.CommandLine, tokenCacheDefaultMaxSize)
+	tracingOptions.BindFlags(flag.CommandLine)
+	upstreamOptions.BindFlags(flag.CommandLine)
+	namespaceLimiterOptions.BindFlags(flag.CommandLine)
+	workspaceOptions.BindFlags(flag.CommandLine)
 
 	flag.Parse()
 
@@ -188,6 +344,14 @@ func main() {
 		setupLog.Error(err, "unable to set global jitter")
 		os.Exit(1)
 	}
+	jitterPerKind, err := intjitter.ParseKindPercentages(intervalJitterPerKind, sourcev1.GitRepositoryKind,
+		sourcev1.HelmRepositoryKind, sourcev1.HelmChartKind, sourcev1.BucketKind, sourcev1.OCIRepositoryKind,
+		sourcev1.SourceSetKind)
+	if err != nil {
+		setupLog.Error(err, "unable to parse interval jitter per kind")
+		os.Exit(1)
+	}
+	intjitter.SetKindPercentages(jitterPerKind)
 
 	mgr := mustSetupManager(metricsAddr, healthAddr, concurrent, watchOptions, clientOptions, leaderElectionOptions)
 
@@ -195,11 +359,59 @@ func main() {
 
 	metrics := helper.NewMetrics(mgr, metrics.MustMakeRecorder(), sourcev1.SourceFinalizer)
 	cacheRecorder := cache.MustMakeMetrics()
+	accountant := mustSetupUpstreamAccountant(upstreamOptions)
+	certificates := mustSetupUpstreamCertificates(upstreamOptions)
+	var artifactShare *controller.ArtifactShare
+	if artifactShareWindow > 0 {
+		artifactShare = controller.NewArtifactShare(artifactShareWindow)
+	}
+	namespaceLimiter := ratelimit.NewNamespaceLimiter(namespaceLimiterOptions)
+	ctrlmetrics.Registry.MustRegister(namespaceLimiter.Collectors()...)
+	backlog := mustSetupReconcileBacklog(mgr, backlogInterval)
+	workspaces := mustSetupWorkspaces(workspaceOptions)
 	eventRecorder := mustSetupEventRecorder(mgr, eventsAddr, controllerName)
 	storage := mustInitStorage(storagePath, storageAdvAddr, artifactRetentionTTL, artifactRetentionRecords, artifactDigestAlgo)
+	mustConfigureStorageHosts(storage, storageVirtualHosts, storageTLSDir, storageCDNOptions, storageHTTPSOnly)
+	diagnostics := mustSetupReconcileDiagnostics(diagnosticsPath, storage)
+	mustConfigureStoragePurger(storage, storagePurgeOptions)
+	storage.ReadBackTimeout = artifactReadBackTimeout
+	storage.ArtifactRetentionMaxSize = artifactRetentionMaxSize
+	storage.ArtifactRetentionMaxTTL = artifactRetentionMaxTTL
+	storage.ArtifactRetentionMaxRecords = artifactRetentionMaxRecs
+	if err := controller.ValidateCompressionLevel(artifactCompression); err != nil {
+		setupLog.Error(err, "invalid artifact compression level")
+		os.Exit(1)
+	}
+	storage.CompressionLevel = artifactCompression
+	storage.Immutable = storageImmutable
+	storage.Deduplicate = storageDeduplicate
+	if storageGCDeferred && storageGCInterval <= 0 {
+		setupLog.Error(errors.New("--storage-gc-deferred requires a non-zero --storage-gc-interval"), "invalid storage garbage collection options")
+		os.Exit(1)
+	}
+	storage.DeferGarbageCollection = storageGCDeferred
+	storage.QuarantineUnverified = storageQuarantine
+	storage.SuspendedRetention = mustParseSuspendedRetention(artifactRetentionSusp)
+	storage.Metrics = controller.MustMakeStorageMetrics()
+	if storageRetryInterval > 0 {
+		storage.Backpressure = controller.NewStorageBackpressure(eventRecorder, storageRetryInterval)
+	}
+	storage.Maintenance = mustSetupMaintenance(mgr, maintenance, maintenanceConfigMap)
+	if storageShared {
+		storage.Fence = mustSetupStorageFence(mgr, storage, storageFencePath)
+	}
+	if storageLeaseLocks {
+		storage.Locker = mustSetupArtifactLeases(mgr, storageLeaseDuration)
+	}
+	storage.Consumers = mustSetupArtifactConsumers(mgr, artifactConsumerKinds)
+	if storageAdvAddr == "" {
+		mustResolveStorageAddr(mgr, storage, storageAddr, storageServiceName, storageClusterDomain)
+	}
+	mustSetupStorageChecks(mgr, storage)
 
 	mustSetupHelmLimits(helmIndexLimit, helmChartLimit, helmChartFileLimit)
 	helmIndexCache, helmIndexCacheItemTTL := mustInitHelmCache(helmCacheMaxSize, helmCacheTTL, helmCachePurgeInterval)
+	helmGetters := accountant.Getters(getters)
 
 	var tokenCache *pkgcache.TokenCache
 	if tokenCacheOptions.MaxSize > 0 {
@@ -216,6 +428,17 @@ func main() {
 
 	ctx := ctrl.SetupSignalHandler()
 
+	shutdownTracing, err := tracing.Setup(ctx, tracingOptions)
+	if err != nil {
+		setupLog.Error(err, "unable to configure tracing")
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			setupLog.Error(err, "unable to flush traces")
+		}
+	}()
+
 	if err := (&controller.GitRepositoryReconciler{
 		Client:         mgr.GetClient(),
 		EventRecorder:  eventRecorder,
@@ -223,9 +446,16 @@ func main() {
 		Storage:        storage,
 		ControllerName: controllerName,
 		TokenCache:     tokenCache,
+		Upstream:       accountant,
+		Certificates:   certificates,
+		Workspaces:     workspaces,
+		Diagnostics:    diagnostics,
 	}).SetupWithManagerAndOptions(mgr, controller.GitRepositoryReconcilerOptions{
 		DependencyRequeueInterval: requeueDependency,
 		RateLimiter:               helper.GetRateLimiter(rateLimiterOptions),
+		MaxFailureBackoff:         maxFailureBackoff,
+		NamespaceLimiter:          namespaceLimiter,
+		Backlog:                   backlog,
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", sourcev1.GitRepositoryKind)
 		os.Exit(1)
@@ -236,13 +466,18 @@ func main() {
 		EventRecorder:  eventRecorder,
 		Metrics:        metrics,
 		Storage:        storage,
-		Getters:        getters,
+		Getters:        helmGetters,
 		ControllerName: controllerName,
+		Certificates:   certificates,
+		Diagnostics:    diagnostics,
 		Cache:          helmIndexCache,
 		TTL:            helmIndexCacheItemTTL,
 		CacheRecorder:  cacheRecorder,
 	}).SetupWithManagerAndOptions(mgr, controller.HelmRepositoryReconcilerOptions{
-		RateLimiter: helper.GetRateLimiter(rateLimiterOptions),
+		RateLimiter:       helper.GetRateLimiter(rateLimiterOptions),
+		MaxFailureBackoff: maxFailureBackoff,
+		NamespaceLimiter:  namespaceLimiter,
+		Backlog:           backlog,
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", sourcev1.HelmRepositoryKind)
 		os.Exit(1)
@@ -252,15 +487,20 @@ func main() {
 		Client:                  mgr.GetClient(),
 		RegistryClientGenerator: registry.ClientGenerator,
 		Storage:                 storage,
-		Getters:                 getters,
+		Getters:                 helmGetters,
 		EventRecorder:           eventRecorder,
 		Metrics:                 metrics,
 		ControllerName:          controllerName,
+		Workspaces:              workspaces,
+		Diagnostics:             diagnostics,
 		Cache:                   helmIndexCache,
 		TTL:                     helmIndexCacheItemTTL,
 		CacheRecorder:           cacheRecorder,
 	}).SetupWithManagerAndOptions(ctx, mgr, controller.HelmChartReconcilerOptions{
-		RateLimiter: helper.GetRateLimiter(rateLimiterOptions),
+		RateLimiter:       helper.GetRateLimiter(rateLimiterOptions),
+		MaxFailureBackoff: maxFailureBackoff,
+		NamespaceLimiter:  namespaceLimiter,
+		Backlog:           backlog,
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", sourcev1.HelmChartKind)
 		os.Exit(1)
@@ -272,8 +512,16 @@ func main() {
 		Metrics:        metrics,
 		Storage:        storage,
 		ControllerName: controllerName,
+		TokenCache:     tokenCache,
+		Upstream:       accountant,
+		Certificates:   certificates,
+		Workspaces:     workspaces,
+		Diagnostics:    diagnostics,
 	}).SetupWithManagerAndOptions(mgr, controller.BucketReconcilerOptions{
-		RateLimiter: helper.GetRateLimiter(rateLimiterOptions),
+		RateLimiter:       helper.GetRateLimiter(rateLimiterOptions),
+		MaxFailureBackoff: maxFailureBackoff,
+		NamespaceLimiter:  namespaceLimiter,
+		Backlog:           backlog,
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", sourcev1.BucketKind)
 		os.Exit(1)
@@ -285,15 +533,89 @@ func main() {
 		EventRecorder:  eventRecorder,
 		ControllerName: controllerName,
 		TokenCache:     tokenCache,
+		Upstream:       accountant,
+		Certificates:   certificates,
+		Share:          artifactShare,
+		Workspaces:     workspaces,
+		Diagnostics:    diagnostics,
 		Metrics:        metrics,
 	}).SetupWithManagerAndOptions(mgr, controller.OCIRepositoryReconcilerOptions{
-		RateLimiter: helper.GetRateLimiter(rateLimiterOptions),
+		RateLimiter:       helper.GetRateLimiter(rateLimiterOptions),
+		MaxFailureBackoff: maxFailureBackoff,
+		NamespaceLimiter:  namespaceLimiter,
+		Backlog:           backlog,
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", sourcev1.OCIRepositoryKind)
 		os.Exit(1)
 	}
+
+	if err := (&controller.SourceSetReconciler{
+		Client:         mgr.GetClient(),
+		APIReader:      mgr.GetAPIReader(),
+		EventRecorder:  eventRecorder,
+		Metrics:        metrics,
+		ControllerName: controllerName,
+	}).SetupWithManagerAndOptions(mgr, controller.SourceSetReconcilerOptions{
+		RateLimiter: helper.GetRateLimiter(rateLimiterOptions),
+	}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", sourcev1.SourceSetKind)
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
+	if storageUsageInterval > 0 {
+		if err := mgr.Add(&controller.StorageUsageMonitor{
+			Client:           mgr.GetClient(),
+			EventRecorder:    eventRecorder,
+			Storage:          storage,
+			Recorder:         controller.MustMakeStorageUsageMetrics(),
+			Interval:         storageUsageInterval,
+			WarningThreshold: storageUsageThreshold,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up storage usage monitor")
+			os.Exit(1)
+		}
+	}
+
+	if storage.Purger != nil {
+		if err := mgr.Add(storage.Purger); err != nil {
+			setupLog.Error(err, "unable to set up CDN purger")
+			os.Exit(1)
+		}
+	}
+
+	if artifactAuditInterval > 0 {
+		if err := mgr.Add(&controller.ArtifactAuditor{
+			Client:        mgr.GetClient(),
+			EventRecorder: eventRecorder,
+			Storage:       storage,
+			Interval:      artifactAuditInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up artifact auditor")
+			os.Exit(1)
+		}
+	}
+
+	if featureGatesConfigMap != "" {
+		mustSetupFeatureGateOverrides(mgr, featureGatesConfigMap)
+	}
+
+	if storageGCInterval > 0 {
+		if err := mgr.Add(&controller.StorageJanitor{
+			Client:        mgr.GetClient(),
+			Storage:       storage,
+			Interval:      storageGCInterval,
+			RateLimit:     storageGCRateLimit,
+			Concurrency:   storageGCConcurrency,
+			Timeout:       5 * time.Second,
+			Recorder:      controller.MustMakeArtifactInventoryMetrics(),
+			EventRecorder: eventRecorder,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up storage janitor")
+			os.Exit(1)
+		}
+	}
+
 	go func() {
 		// Block until our controller manager is elected leader. We presume our
 		// entire process will terminate if we lose leadership, so we don't need
@@ -302,9 +624,39 @@ func main() {
 		// be ready to serve at all times! (https://github.com/fluxcd/source-controller/issues/837)
 		// <-mgr.Elected()
 
-		startFileServer(storage.BasePath, storageAddr)
+		digests := &controller.ArtifactDigests{Reader: mgr.GetCache()}
+		startFileServer(storage.BasePath, storageAddr, storage.VirtualHosts, storageTLSDir, storageContentEncodings, storageMaxDownloads, digests)
 	}()
 
+	if adminAddr != "" {
+		adminMux := http.NewServeMux()
+		adminMux.Handle("DELETE /namespaces/{namespace}", &controller.NamespaceOffboarder{
+			Storage: storage,
+			Cache:   helmIndexCache,
+		})
+		adminMux.Handle("GET /artifacts/{kind}/{namespace}/{name}", &controller.ArtifactIndexer{
+			Reader:  mgr.GetAPIReader(),
+			Storage: storage,
+		})
+		adminMux.Handle("GET /artifacts/{kind}/{namespace}/{name}/diff", &controller.ArtifactDiffer{
+			Reader:  mgr.GetAPIReader(),
+			Storage: storage,
+		})
+		if diagnostics != nil {
+			adminMux.Handle("GET /diagnostics/{kind}/{namespace}/{name}", diagnostics)
+		}
+		go startAdminServer(adminAddr, adminMux)
+	}
+
+	if helmWebhookAddr != "" {
+		webhookMux := http.NewServeMux()
+		webhookMux.Handle("POST /helmrepositories/{namespace}/{name}/refresh", &controller.HelmIndexRefresher{
+			Client: mgr.GetClient(),
+			Cache:  helmIndexCache,
+		})
+		go startHelmWebhookServer(helmWebhookAddr, webhookMux)
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -312,17 +664,66 @@ func main() {
 	}
 }
 
-func startFileServer(path string, address string) {
+func startFileServer(path string, address string, virtualHosts map[string]string, tlsDir string, encodings []string, maxDownloads int,
+	digests *controller.ArtifactDigests) {
 	setupLog.Info("starting file server")
-	fs := http.FileServer(http.Dir(path))
+	if err := fileserver.ValidateEncodings(encodings); err != nil {
+		setupLog.Error(err, "unable to configure file server content encodings")
+		os.Exit(1)
+	}
+	root := http.Dir(path)
+	fs := fileserver.DecompressHandler(root, fileserver.EncodingHandler(root, encodings, http.FileServer(root)))
+	fs = fileserver.OCILayoutHandler(root, digests.Digest, fs)
+	fs = fileserver.PriorityHandler(maxDownloads, fileserver.VirtualHostHandler(virtualHosts, fs))
 	mux := http.NewServeMux()
-	mux.Handle("/", fs)
-	err := http.ListenAndServe(address, mux)
+	mux.Handle("/", tracing.HTTPHandler(fs, "artifact-server"))
+	server := &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+
+	var err error
+	if tlsDir != "" {
+		certs, certErr := fileserver.NewCertificateStore(tlsDir)
+		if certErr != nil {
+			setupLog.Error(certErr, "unable to load file server certificates")
+			os.Exit(1)
+		}
+		server.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: certs.GetCertificate,
+		}
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = server.ListenAndServe()
+	}
 	if err != nil {
 		setupLog.Error(err, "file server error")
 	}
 }
 
+func startAdminServer(address string, handler http.Handler) {
+	setupLog.Info("starting admin server", "addr", address)
+	server := &http.Server{
+		Addr:    address,
+		Handler: handler,
+	}
+	if err := server.ListenAndServe(); err != nil {
+		setupLog.Error(err, "admin server error")
+	}
+}
+
+func startHelmWebhookServer(address string, handler http.Handler) {
+	setupLog.Info("starting Helm webhook server", "addr", address)
+	server := &http.Server{
+		Addr:    address,
+		Handler: handler,
+	}
+	if err := server.ListenAndServe(); err != nil {
+		setupLog.Error(err, "Helm webhook server error")
+	}
+}
+
 func mustSetupEventRecorder(mgr ctrl.Manager, eventsAddr, controllerName string) record.EventRecorder {
 	eventRecorder, err := events.NewRecorder(mgr, ctrl.Log, eventsAddr, controllerName)
 	if err != nil {
@@ -384,6 +785,7 @@ func mustSetupManager(metricsAddr, healthAddr string, maxConcurrent int,
 				&sourcev1.HelmChart{}:      {Label: watchSelector},
 				&sourcev1.Bucket{}:         {Label: watchSelector},
 				&sourcev1.OCIRepository{}:  {Label: watchSelector},
+				&sourcev1.SourceSet{}:      {Label: watchSelector},
 			},
 		},
 		Metrics: metricsserver.Options{
@@ -438,10 +840,6 @@ func mustInitHelmCache(maxSize int, itemTTL, purgeInterval string) (*cache.Cache
 }
 
 func mustInitStorage(path string, storageAdvAddr string, artifactRetentionTTL time.Duration, artifactRetentionRecords int, artifactDigestAlgo string) *controller.Storage {
-	if storageAdvAddr == "" {
-		storageAdvAddr = determineAdvStorageAddr(storageAdvAddr)
-	}
-
 	if artifactDigestAlgo != intdigest.Canonical.String() {
 		algo, err := intdigest.AlgorithmForName(artifactDigestAlgo)
 		if err != nil {
@@ -459,27 +857,344 @@ func mustInitStorage(path string, storageAdvAddr string, artifactRetentionTTL ti
 	return storage
 }
 
-func determineAdvStorageAddr(storageAddr string) string {
-	host, port, err := net.SplitHostPort(storageAddr)
+// mustConfigureStorageHosts configures the virtual hosts and CDN of the
+// storage, and advertises HTTPS URLs when the file server serves TLS. When
+// httpsOnly is set, it refuses host names which would advertise HTTP URLs.
+func mustConfigureStorageHosts(storage *controller.Storage, virtualHosts []string, tlsDir string, cdnOpts cdn.Options, httpsOnly bool) {
+	hosts, err := fileserver.ParseVirtualHosts(virtualHosts)
+	if err != nil {
+		setupLog.Error(err, "unable to parse storage virtual hosts")
+		os.Exit(1)
+	}
+	storage.VirtualHosts = hosts
+
+	if tlsDir != "" && !strings.HasPrefix(storage.Hostname, "https://") {
+		storage.Hostname = "https://" + strings.TrimPrefix(storage.Hostname, "http://")
+	}
+
+	if cdnOpts.BaseURL != "" {
+		rewriter, err := cdn.New(cdnOpts)
+		if err != nil {
+			setupLog.Error(err, "unable to configure storage CDN")
+			os.Exit(1)
+		}
+		storage.CDN = rewriter
+	}
+
+	storage.HTTPSOnly = httpsOnly
+	if err := storage.ValidateHTTPSOnly(); err != nil {
+		setupLog.Error(err, "refusing to advertise plain HTTP artifact URLs")
+		os.Exit(1)
+	}
+}
+
+// mustResolveStorageAddr derives the advertised address of the storage from
+// the Service exposing the file server in the runtime namespace, and keeps it
+// up-to-date when the Service changes.
+func mustResolveStorageAddr(mgr ctrl.Manager, storage *controller.Storage, storageAddr, serviceName, clusterDomain string) {
+	namespace := os.Getenv("RUNTIME_NAMESPACE")
+	if namespace == "" || serviceName == "" {
+		setupLog.Error(errors.New("RUNTIME_NAMESPACE or --storage-service-name not set"),
+			"unable to derive the advertised storage address, set --storage-adv-addr")
+		os.Exit(1)
+	}
+	_, port, err := net.SplitHostPort(storageAddr)
 	if err != nil {
 		setupLog.Error(err, "unable to parse storage address")
 		os.Exit(1)
 	}
-	switch host {
-	case "":
-		host = "localhost"
-	case "0.0.0.0":
-		host = os.Getenv("HOSTNAME")
-		if host == "" {
-			hn, err := os.Hostname()
-			if err != nil {
-				setupLog.Error(err, "0.0.0.0 specified in storage addr but hostname is invalid")
-				os.Exit(1)
-			}
-			host = hn
+	targetPort, err := strconv.ParseInt(port, 10, 32)
+	if err != nil {
+		setupLog.Error(err, "unable to parse storage address port")
+		os.Exit(1)
+	}
+
+	resolver := &controller.StorageAddressResolver{
+		Reader:           mgr.GetAPIReader(),
+		Storage:          storage,
+		ServiceName:      serviceName,
+		ServiceNamespace: namespace,
+		ClusterDomain:    clusterDomain,
+		TargetPortName:   "http",
+		TargetPort:       int32(targetPort),
+		Interval:         time.Minute,
+	}
+	addr, err := resolver.Resolve(context.Background())
+	if err != nil {
+		setupLog.Error(err, "unable to derive the advertised storage address, set --storage-adv-addr")
+		os.Exit(1)
+	}
+	setupLog.Info("derived advertised storage address from Service", "address", addr)
+	storage.SetAdvertisedHostname(addr)
+
+	if err := mgr.Add(resolver); err != nil {
+		setupLog.Error(err, "unable to set up storage address resolver")
+		os.Exit(1)
+	}
+}
+
+// mustSetupFeatureGateOverrides loads the per-namespace feature gate
+// overrides from the given ConfigMap in the runtime namespace, and keeps them
+// up-to-date when the ConfigMap changes.
+func mustSetupFeatureGateOverrides(mgr ctrl.Manager, name string) {
+	namespace := os.Getenv("RUNTIME_NAMESPACE")
+	if namespace == "" {
+		setupLog.Error(errors.New("RUNTIME_NAMESPACE not set"), "unable to set up feature gate overrides")
+		os.Exit(1)
+	}
+	if err := mgr.Add(&controller.FeatureGateOverridesLoader{
+		Reader:    mgr.GetAPIReader(),
+		Name:      name,
+		Namespace: namespace,
+		Interval:  time.Minute,
+	}); err != nil {
+		setupLog.Error(err, "unable to set up feature gate overrides")
+		os.Exit(1)
+	}
+}
+
+// mustSetupStorageFence fences the writes to the shared Storage to the
+// elected leader, which takes over the fencing token at the given path once
+// elected.
+func mustSetupStorageFence(mgr ctrl.Manager, storage *controller.Storage, path string) *controller.StorageFence {
+	if path == "" {
+		setupLog.Error(errors.New("--storage-fence-path is required"), "unable to set up storage fence")
+		os.Exit(1)
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		setupLog.Error(err, "unable to set up storage fence")
+		os.Exit(1)
+	}
+	base, err := filepath.Abs(storage.BasePath)
+	if err != nil {
+		setupLog.Error(err, "unable to set up storage fence")
+		os.Exit(1)
+	}
+	if rel, _ := filepath.Rel(base, path); rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		setupLog.Error(fmt.Errorf("fencing token path '%s' is in the storage path '%s'", path, base), "unable to set up storage fence")
+		os.Exit(1)
+	}
+	identity, err := os.Hostname()
+	if err != nil {
+		setupLog.Error(err, "unable to determine storage fencing identity")
+		os.Exit(1)
+	}
+	fence := &controller.StorageFence{
+		Path:     path,
+		Identity: identity,
+	}
+	if err := mgr.Add(fence); err != nil {
+		setupLog.Error(err, "unable to set up storage fence")
+		os.Exit(1)
+	}
+	return fence
+}
+
+// mustSetupMaintenance returns the Maintenance mode of the controller, forced
+// or toggled with the ConfigMap of the given name in the runtime namespace,
+// or nil if neither is set.
+func mustSetupMaintenance(mgr ctrl.Manager, forced bool, name string) *controller.Maintenance {
+	if !forced && name == "" {
+		return nil
+	}
+	m := &controller.Maintenance{
+		Forced:   forced,
+		Interval: time.Minute,
+	}
+	if name == "" {
+		return m
+	}
+
+	namespace := os.Getenv("RUNTIME_NAMESPACE")
+	if namespace == "" {
+		setupLog.Error(errors.New("RUNTIME_NAMESPACE not set"), "unable to set up maintenance mode")
+		os.Exit(1)
+	}
+	m.Reader = mgr.GetAPIReader()
+	m.Name = name
+	m.Namespace = namespace
+	// Load the mode before the controllers start, to not reconcile any
+	// object while the maintenance mode is enabled.
+	if _, err := m.Refresh(context.Background()); err != nil {
+		setupLog.Error(err, "unable to load maintenance mode")
+	}
+	if err := mgr.Add(m); err != nil {
+		setupLog.Error(err, "unable to set up maintenance mode")
+		os.Exit(1)
+	}
+	return m
+}
+
+// mustSetupArtifactLeases returns the ArtifactLeases locking the artifacts
+// of the Storage with Kubernetes Leases, held with the given duration.
+func mustSetupArtifactLeases(mgr ctrl.Manager, duration time.Duration) *controller.ArtifactLeases {
+	if duration < time.Second {
+		setupLog.Error(errors.New("must be at least 1s"), "invalid --storage-lease-duration")
+		os.Exit(1)
+	}
+	identity, err := os.Hostname()
+	if err != nil {
+		setupLog.Error(err, "unable to determine artifact lease identity")
+		os.Exit(1)
+	}
+	// The Leases are managed in the runtime namespace, in which the
+	// controller is already allowed to manage its leader election Leases.
+	namespace := os.Getenv("RUNTIME_NAMESPACE")
+	if namespace == "" {
+		setupLog.Error(errors.New("RUNTIME_NAMESPACE not set"), "unable to set up artifact leases")
+		os.Exit(1)
+	}
+	// The Leases are read from the API server, as caching them would
+	// require to watch them.
+	c, err := ctrlclient.New(mgr.GetConfig(), ctrlclient.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+	if err != nil {
+		setupLog.Error(err, "unable to create artifact lease client")
+		os.Exit(1)
+	}
+	return &controller.ArtifactLeases{
+		Client:        c,
+		Namespace:     namespace,
+		Identity:      identity,
+		LeaseDuration: duration,
+		RetryPeriod:   duration / 10,
+		Timeout:       10 * duration,
+	}
+}
+
+// mustConfigureStoragePurger configures the storage to purge the URLs of
+// replaced and garbage collected artifacts from the CDN cache, if a purge
+// provider is set.
+func mustConfigureStoragePurger(storage *controller.Storage, opts cdn.PurgeOptions) {
+	if opts.Provider == "" {
+		return
+	}
+	purger, err := cdn.NewPurger(opts)
+	if err != nil {
+		setupLog.Error(err, "unable to configure CDN purger")
+		os.Exit(1)
+	}
+	storage.Purger = purger
+}
+
+// mustSetupReconcileDiagnostics returns the ReconcileDiagnostics writing to
+// the given path, or nil if the path is empty. The path must not be within
+// the storage path, as the bundles would otherwise be served by the file
+// server.
+func mustSetupReconcileDiagnostics(path string, storage *controller.Storage) *controller.ReconcileDiagnostics {
+	if path == "" {
+		return nil
+	}
+	path, err := filepath.Abs(path)
+	if err == nil {
+		if rel, relErr := filepath.Rel(storage.BasePath, path); relErr == nil && filepath.IsLocal(rel) {
+			err = fmt.Errorf("path '%s' is within the storage path '%s'", path, storage.BasePath)
 		}
 	}
-	return net.JoinHostPort(host, port)
+	if err == nil {
+		err = os.MkdirAll(path, 0o700)
+	}
+	if err != nil {
+		setupLog.Error(err, "unable to set up reconcile diagnostics")
+		os.Exit(1)
+	}
+	return &controller.ReconcileDiagnostics{Path: path}
+}
+
+// mustSetupArtifactConsumers returns the ArtifactConsumers of the given kinds,
+// queried from an index on the cache of the manager, or nil if no kind is
+// given.
+func mustSetupArtifactConsumers(mgr ctrl.Manager, kinds []string) *controller.ArtifactConsumers {
+	if len(kinds) == 0 {
+		return nil
+	}
+	gvks, err := controller.ParseConsumerKinds(kinds)
+	if err != nil {
+		setupLog.Error(err, "unable to set up artifact consumers")
+		os.Exit(1)
+	}
+	consumers := &controller.ArtifactConsumers{Reader: mgr.GetCache(), Kinds: gvks}
+	if err := consumers.IndexConsumers(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to set up artifact consumers")
+		os.Exit(1)
+	}
+	return consumers
+}
+
+// mustParseSuspendedRetention returns the retention policy of the artifacts
+// of suspended sources with the given name.
+func mustParseSuspendedRetention(name string) controller.SuspendedRetention {
+	policy, err := controller.ParseSuspendedRetention(name)
+	if err != nil {
+		setupLog.Error(err, "invalid artifact retention policy for suspended sources")
+		os.Exit(1)
+	}
+	return policy
+}
+
+// mustSetupUpstreamAccountant creates the Accountant of the requests made to,
+// and the bytes downloaded from, upstream hosts, and registers its metrics.
+func mustSetupUpstreamAccountant(opts upstream.Options) *upstream.Accountant {
+	accountant, err := upstream.NewAccountant(opts)
+	if err != nil {
+		setupLog.Error(err, "unable to configure upstream accounting")
+		os.Exit(1)
+	}
+	ctrlmetrics.Registry.MustRegister(accountant.Collectors()...)
+	return accountant
+}
+
+// mustSetupUpstreamCertificates creates the monitor of the upstream TLS
+// certificates, or returns nil if the monitoring is disabled.
+func mustSetupUpstreamCertificates(opts upstream.Options) *upstream.CertificateMonitor {
+	if opts.CertificateProbeInterval <= 0 {
+		return nil
+	}
+	monitor := upstream.NewCertificateMonitor(opts.CertificateExpiryWarning, opts.CertificateProbeInterval)
+	ctrlmetrics.Registry.MustRegister(monitor.Collectors()...)
+	return monitor
+}
+
+// mustSetupReconcileBacklog records the backlog of the reconciliations at
+// the given interval, or returns nil if the interval is 0.
+func mustSetupReconcileBacklog(mgr ctrl.Manager, interval time.Duration) *controller.ReconcileBacklog {
+	if interval <= 0 {
+		return nil
+	}
+	backlog := controller.NewReconcileBacklog(mgr.GetClient(), interval)
+	if err := mgr.Add(backlog); err != nil {
+		setupLog.Error(err, "unable to set up reconcile backlog metrics")
+		os.Exit(1)
+	}
+	ctrlmetrics.Registry.MustRegister(backlog.Collectors()...)
+	return backlog
+}
+
+// mustSetupWorkspaces creates the workspace manager, which removes the
+// workspaces left behind by a previous run of the controller.
+func mustSetupWorkspaces(opts workspace.Options) *workspace.Manager {
+	workspaces, removed, err := workspace.NewManager(opts)
+	if err != nil {
+		setupLog.Error(err, "unable to configure workspaces")
+		os.Exit(1)
+	}
+	if removed > 0 {
+		setupLog.Info("removed stale workspaces", "count", removed)
+	}
+	ctrlmetrics.Registry.MustRegister(workspaces.Collectors()...)
+	return workspaces
+}
+
+// mustSetupStorageChecks registers a readiness check which fails when the
+// storage can not be written to, to stop routing artifact requests to this
+// replica.
+func mustSetupStorageChecks(mgr ctrl.Manager, storage *controller.Storage) {
+	if err := mgr.AddReadyzCheck("storage", func(_ *http.Request) error {
+		return storage.Healthy()
+	}); err != nil {
+		setupLog.Error(err, "unable to set up storage readiness check")
+		os.Exit(1)
+	}
 }
 
 func envOrDefault(envName, defaultValue string) string {
@@ -37,6 +37,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/fluxcd/pkg/auth"
+	azureauth "github.com/fluxcd/pkg/auth/azure"
 	"github.com/fluxcd/pkg/masktoken"
 
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
@@ -82,9 +84,19 @@ func WithProxyURL(proxyURL *url.URL) Option {
 	}
 }
 
+// WithAuth sets the options to fetch an access token for the BlobClient
+// with the Azure provider of pkg/auth, e.g. for object-level workload
+// identity.
+func WithAuth(authOpts ...auth.Option) Option {
+	return func(o *options) {
+		o.authOpts = authOpts
+	}
+}
+
 type options struct {
 	secret             *corev1.Secret
 	proxyURL           *url.URL
+	authOpts           []auth.Option
 	withoutCredentials bool
 	withoutRetries     bool
 }
@@ -109,8 +121,10 @@ func withoutRetries() Option {
 
 // NewClient creates a new Azure Blob storage client.
 // The credential config on the client is set based on the data from the
-// Bucket and Secret. It detects credentials in the Secret in the following
-// order:
+// Bucket and Secret. When auth options are set with WithAuth, the access
+// token is fetched with the Azure provider of pkg/auth instead, e.g. for the
+// ServiceAccount of the Bucket. Otherwise, it detects credentials in the
+// Secret in the following order:
 //
 //   - azidentity.ClientSecretCredential when `tenantId`, `clientId` and
 //     `clientSecret` fields are found.
@@ -130,7 +144,7 @@ func withoutRetries() Option {
 //
 // If no credentials are found, and the azidentity.ChainedTokenCredential can
 // not be established. A simple client without credentials is returned.
-func NewClient(obj *sourcev1.Bucket, opts ...Option) (c *BlobClient, err error) {
+func NewClient(ctx context.Context, obj *sourcev1.Bucket, opts ...Option) (c *BlobClient, err error) {
 	c = &BlobClient{}
 
 	var o options
@@ -157,6 +171,12 @@ func NewClient(obj *sourcev1.Bucket, opts ...Option) (c *BlobClient, err error)
 		return
 	}
 
+	if o.authOpts != nil {
+		token := azureauth.NewTokenCredential(ctx, o.authOpts...)
+		c.Client, err = azblob.NewClient(obj.Spec.Endpoint, token, clientOpts)
+		return
+	}
+
 	var token azcore.TokenCredential
 
 	if o.secret != nil && len(o.secret.Data) > 0 {
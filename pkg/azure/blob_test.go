@@ -106,7 +106,7 @@ func TestNewClientAndBucketExistsWithProxy(t *testing.T) {
 				},
 			}
 
-			client, err := NewClient(bucket,
+			client, err := NewClient(context.Background(), bucket,
 				WithProxyURL(tt.proxyURL),
 				withoutCredentials(),
 				withoutRetries())
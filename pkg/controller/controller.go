@@ -0,0 +1,313 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package controller exposes the reconcilers of the source-controller for
+// embedding a subset of them in another controller manager.
+//
+// The reconcilers are configured with the Options and HelmOptions defined in
+// this package, which only hold types of public packages, and are mapped
+// onto the reconcilers used by the source-controller binary, so that both
+// behave the same. The features of the binary which are configured with
+// types of its internal packages, e.g. the upstream accounting or the
+// workspace quotas, are disabled when embedded. The Artifacts written to the
+// Storage are to be served over HTTP by the embedding binary, from the
+// Storage BasePath at the Storage Hostname.
+//
+// Example:
+//
+//	storage, err := controller.NewStorage("/data", "source-controller.flux-system.svc", time.Hour, 2)
+//	if err != nil {
+//		return err
+//	}
+//	if err := controller.NewGitRepositoryReconciler(controller.Options{
+//		Client:         mgr.GetClient(),
+//		EventRecorder:  mgr.GetEventRecorderFor("platform-manager"),
+//		Storage:        storage,
+//		ControllerName: "platform-manager",
+//	}).SetupWithManager(mgr); err != nil {
+//		return err
+//	}
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	helmgetter "helm.sh/helm/v3/pkg/getter"
+	helmreg "helm.sh/helm/v3/pkg/registry"
+	kuberecorder "k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/fluxcd/pkg/cache"
+	helper "github.com/fluxcd/pkg/runtime/controller"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/fluxcd/source-controller/internal/controller"
+	"github.com/fluxcd/source-controller/internal/helm/registry"
+	"github.com/fluxcd/source-controller/pkg/fetch"
+)
+
+// Storage manages the Artifacts written by the reconcilers. It implements
+// fetch.Store, for custom Source kinds to store their Artifacts with a
+// fetch.Pipeline.
+type Storage struct {
+	storage *controller.Storage
+}
+
+var _ fetch.Store = &Storage{}
+
+// NewStorage creates the Storage for the given path and hostname. The
+// Artifacts of an object which are older than the retention TTL are garbage
+// collected, keeping at least the given number of retention records.
+func NewStorage(basePath, hostname string, retentionTTL time.Duration, retentionRecords int) (*Storage, error) {
+	storage, err := controller.NewStorage(basePath, hostname, retentionTTL, retentionRecords)
+	if err != nil {
+		return nil, err
+	}
+	return &Storage{storage: storage}, nil
+}
+
+// BasePath returns the path of the directory the Artifacts are stored in.
+func (s *Storage) BasePath() string {
+	return s.storage.BasePath
+}
+
+// Hostname returns the hostname the Artifacts are advertised at.
+func (s *Storage) Hostname() string {
+	return s.storage.Hostname
+}
+
+// Store archives the given directory to the path of the given Artifact, and
+// sets its digest, size and last update time.
+func (s *Storage) Store(ctx context.Context, artifact *sourcev1.Artifact, dir string) error {
+	return s.storage.Store(ctx, artifact, dir)
+}
+
+// ArtifactExist returns true if the file of the given Artifact exists.
+func (s *Storage) ArtifactExist(artifact sourcev1.Artifact) bool {
+	return s.storage.ArtifactExist(artifact)
+}
+
+// VerifyArtifact verifies the file of the given Artifact against its
+// digest.
+func (s *Storage) VerifyArtifact(artifact sourcev1.Artifact) error {
+	return s.storage.VerifyArtifact(artifact)
+}
+
+// Options configures a reconciler.
+type Options struct {
+	// Client is the client of the manager. Required.
+	Client client.Client
+	// EventRecorder records the events of the reconciled objects. Required.
+	EventRecorder kuberecorder.EventRecorder
+	// Metrics records the metrics of the reconciled objects. Optional.
+	Metrics helper.Metrics
+	// Storage stores the Artifacts. Required.
+	Storage *Storage
+	// ControllerName is the field owner of the patches of the reconciled
+	// objects. Required.
+	ControllerName string
+
+	// TokenCache caches the tokens of the cloud providers. Optional.
+	TokenCache *cache.TokenCache
+	// RateLimiter is the rate limiter of the work queue. Optional.
+	RateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+	// MaxFailureBackoff enables the failure backoff with the given maximum
+	// interval when non-zero.
+	MaxFailureBackoff time.Duration
+	// DependencyRequeueInterval is the interval at which the objects are
+	// requeued while waiting for a dependency, for the kinds which have
+	// dependencies.
+	DependencyRequeueInterval time.Duration
+}
+
+// RegistryClientGeneratorFunc creates the Helm OCI registry clients of the
+// HelmChartReconciler.
+type RegistryClientGeneratorFunc func(tlsConfig *tls.Config, isLogin, insecure bool) (*helmreg.Client, string, error)
+
+// HelmOptions configures a reconciler of Helm objects.
+type HelmOptions struct {
+	Options
+
+	// Getters are the Helm getters of the repository URL schemes. Required.
+	Getters helmgetter.Providers
+	// RegistryClientGenerator creates the Helm OCI registry clients of the
+	// HelmChartReconciler. Defaults to the one of the source-controller
+	// binary.
+	RegistryClientGenerator RegistryClientGeneratorFunc
+}
+
+// GitRepositoryReconciler reconciles v1.GitRepository objects.
+type GitRepositoryReconciler struct {
+	reconciler *controller.GitRepositoryReconciler
+	options    controller.GitRepositoryReconcilerOptions
+}
+
+// NewGitRepositoryReconciler returns a GitRepositoryReconciler configured
+// with the given Options.
+func NewGitRepositoryReconciler(opts Options) *GitRepositoryReconciler {
+	return &GitRepositoryReconciler{
+		reconciler: &controller.GitRepositoryReconciler{
+			Client:         opts.Client,
+			EventRecorder:  opts.EventRecorder,
+			Metrics:        opts.Metrics,
+			Storage:        opts.Storage.storage,
+			ControllerName: opts.ControllerName,
+			TokenCache:     opts.TokenCache,
+		},
+		options: controller.GitRepositoryReconcilerOptions{
+			DependencyRequeueInterval: opts.DependencyRequeueInterval,
+			RateLimiter:               opts.RateLimiter,
+			MaxFailureBackoff:         opts.MaxFailureBackoff,
+		},
+	}
+}
+
+// SetupWithManager sets up the reconciler with the given manager.
+func (r *GitRepositoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return r.reconciler.SetupWithManagerAndOptions(mgr, r.options)
+}
+
+// HelmRepositoryReconciler reconciles v1.HelmRepository objects.
+type HelmRepositoryReconciler struct {
+	reconciler *controller.HelmRepositoryReconciler
+	options    controller.HelmRepositoryReconcilerOptions
+}
+
+// NewHelmRepositoryReconciler returns a HelmRepositoryReconciler configured
+// with the given HelmOptions.
+func NewHelmRepositoryReconciler(opts HelmOptions) *HelmRepositoryReconciler {
+	return &HelmRepositoryReconciler{
+		reconciler: &controller.HelmRepositoryReconciler{
+			Client:         opts.Client,
+			EventRecorder:  opts.EventRecorder,
+			Metrics:        opts.Metrics,
+			Getters:        opts.Getters,
+			Storage:        opts.Storage.storage,
+			ControllerName: opts.ControllerName,
+		},
+		options: controller.HelmRepositoryReconcilerOptions{
+			RateLimiter:       opts.RateLimiter,
+			MaxFailureBackoff: opts.MaxFailureBackoff,
+		},
+	}
+}
+
+// SetupWithManager sets up the reconciler with the given manager.
+func (r *HelmRepositoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return r.reconciler.SetupWithManagerAndOptions(mgr, r.options)
+}
+
+// HelmChartReconciler reconciles v1.HelmChart objects.
+type HelmChartReconciler struct {
+	reconciler *controller.HelmChartReconciler
+	options    controller.HelmChartReconcilerOptions
+}
+
+// NewHelmChartReconciler returns a HelmChartReconciler configured with the
+// given HelmOptions.
+func NewHelmChartReconciler(opts HelmOptions) *HelmChartReconciler {
+	generator := controller.RegistryClientGeneratorFunc(registry.ClientGenerator)
+	if opts.RegistryClientGenerator != nil {
+		generator = controller.RegistryClientGeneratorFunc(opts.RegistryClientGenerator)
+	}
+	return &HelmChartReconciler{
+		reconciler: &controller.HelmChartReconciler{
+			Client:                  opts.Client,
+			EventRecorder:           opts.EventRecorder,
+			Metrics:                 opts.Metrics,
+			RegistryClientGenerator: generator,
+			Storage:                 opts.Storage.storage,
+			Getters:                 opts.Getters,
+			ControllerName:          opts.ControllerName,
+		},
+		options: controller.HelmChartReconcilerOptions{
+			RateLimiter:       opts.RateLimiter,
+			MaxFailureBackoff: opts.MaxFailureBackoff,
+		},
+	}
+}
+
+// SetupWithManager sets up the reconciler with the given manager. The given
+// context is used to index the HelmCharts.
+func (r *HelmChartReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	return r.reconciler.SetupWithManagerAndOptions(ctx, mgr, r.options)
+}
+
+// BucketReconciler reconciles v1.Bucket objects.
+type BucketReconciler struct {
+	reconciler *controller.BucketReconciler
+	options    controller.BucketReconcilerOptions
+}
+
+// NewBucketReconciler returns a BucketReconciler configured with the given
+// Options.
+func NewBucketReconciler(opts Options) *BucketReconciler {
+	return &BucketReconciler{
+		reconciler: &controller.BucketReconciler{
+			Client:         opts.Client,
+			EventRecorder:  opts.EventRecorder,
+			Metrics:        opts.Metrics,
+			Storage:        opts.Storage.storage,
+			ControllerName: opts.ControllerName,
+			TokenCache:     opts.TokenCache,
+		},
+		options: controller.BucketReconcilerOptions{
+			RateLimiter:       opts.RateLimiter,
+			MaxFailureBackoff: opts.MaxFailureBackoff,
+		},
+	}
+}
+
+// SetupWithManager sets up the reconciler with the given manager.
+func (r *BucketReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return r.reconciler.SetupWithManagerAndOptions(mgr, r.options)
+}
+
+// OCIRepositoryReconciler reconciles v1.OCIRepository objects.
+type OCIRepositoryReconciler struct {
+	reconciler *controller.OCIRepositoryReconciler
+	options    controller.OCIRepositoryReconcilerOptions
+}
+
+// NewOCIRepositoryReconciler returns an OCIRepositoryReconciler configured
+// with the given Options.
+func NewOCIRepositoryReconciler(opts Options) *OCIRepositoryReconciler {
+	return &OCIRepositoryReconciler{
+		reconciler: &controller.OCIRepositoryReconciler{
+			Client:         opts.Client,
+			EventRecorder:  opts.EventRecorder,
+			Metrics:        opts.Metrics,
+			Storage:        opts.Storage.storage,
+			ControllerName: opts.ControllerName,
+			TokenCache:     opts.TokenCache,
+		},
+		options: controller.OCIRepositoryReconcilerOptions{
+			DependencyRequeueInterval: opts.DependencyRequeueInterval,
+			RateLimiter:               opts.RateLimiter,
+			MaxFailureBackoff:         opts.MaxFailureBackoff,
+		},
+	}
+}
+
+// SetupWithManager sets up the reconciler with the given manager.
+func (r *OCIRepositoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return r.reconciler.SetupWithManagerAndOptions(mgr, r.options)
+}
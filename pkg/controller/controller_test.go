@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/gittestserver"
+	"github.com/fluxcd/pkg/runtime/conditions"
+	"github.com/fluxcd/pkg/runtime/testenv"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+func TestGitRepositoryReconciler_Embedded(t *testing.T) {
+	g := NewWithT(t)
+
+	utilruntime.Must(sourcev1.AddToScheme(scheme.Scheme))
+	env := testenv.New(testenv.WithCRDPath(filepath.Join("..", "..", "config", "crd", "bases")))
+
+	storage, err := NewStorage(t.TempDir(), "source-controller.example.com", time.Hour, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(NewGitRepositoryReconciler(Options{
+		Client:         env,
+		EventRecorder:  record.NewFakeRecorder(32),
+		Storage:        storage,
+		ControllerName: "embedding-manager",
+	}).SetupWithManager(env)).To(Succeed())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if err := env.Start(ctx); err != nil {
+			t.Errorf("failed to start the test environment: %v", err)
+		}
+	}()
+	<-env.Manager.Elected()
+	defer func() {
+		g.Expect(env.Stop()).To(Succeed())
+	}()
+
+	server, err := gittestserver.NewTempGitServer()
+	g.Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(server.Root())
+	server.AutoCreate()
+	g.Expect(server.StartHTTP()).To(Succeed())
+	defer server.StopHTTP()
+	g.Expect(pushCommit(server.HTTPAddressWithCredentials() + "/embedded.git")).To(Succeed())
+
+	obj := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "embedded-",
+			Namespace:    "default",
+		},
+		Spec: sourcev1.GitRepositorySpec{
+			Interval: metav1.Duration{Duration: time.Minute},
+			URL:      server.HTTPAddress() + "/embedded.git",
+		},
+	}
+	g.Expect(env.Create(ctx, obj)).To(Succeed())
+	defer func() {
+		g.Expect(env.Delete(ctx, obj)).To(Succeed())
+	}()
+
+	// The embedded reconciler stores the Artifact in the given Storage, and
+	// advertises it at the given hostname.
+	g.Eventually(func() bool {
+		if err := env.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return false
+		}
+		return conditions.IsTrue(obj, meta.ReadyCondition) && obj.GetArtifact() != nil
+	}, 30*time.Second, time.Second).Should(BeTrue())
+	g.Expect(obj.GetArtifact().URL).To(HavePrefix("http://source-controller.example.com/gitrepository/default/"))
+	g.Expect(storage.ArtifactExist(*obj.GetArtifact())).To(BeTrue())
+	g.Expect(storage.VerifyArtifact(*obj.GetArtifact())).To(Succeed())
+}
+
+// pushCommit pushes a commit with a single file to the default branch of the
+// Git repository at the given URL.
+func pushCommit(url string) error {
+	fs := memfs.New()
+	repo, err := gogit.Init(memory.NewStorage(), fs)
+	if err != nil {
+		return err
+	}
+	f, err := fs.Create("README.md")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write([]byte("embedded")); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	working, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	if _, err := working.Add("README.md"); err != nil {
+		return err
+	}
+	if _, err := working.Commit("Initial commit", &gogit.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Jane Doe",
+			Email: "jane@example.com",
+			When:  time.Now(),
+		},
+	}); err != nil {
+		return err
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{
+		Name: gogit.DefaultRemoteName,
+		URLs: []string{url},
+	}); err != nil {
+		return err
+	}
+	return repo.Push(&gogit.PushOptions{
+		RefSpecs: []config.RefSpec{"refs/heads/*:refs/heads/*"},
+	})
+}
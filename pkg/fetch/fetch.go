@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fetch provides a composable fetch, verify and store pipeline for
+// the Source kinds producing an Artifact, for use by the built-in
+// reconcilers and by operators implementing custom Source kinds.
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+// Stage is a stage of the Pipeline.
+type Stage string
+
+const (
+	// FetchStage fetches the content of the Source.
+	FetchStage Stage = "fetch"
+	// VerifyStage verifies the fetched content.
+	VerifyStage Stage = "verify"
+	// StoreStage archives and stores the fetched content as an Artifact.
+	StoreStage Stage = "store"
+)
+
+// Error is returned by Pipeline.Run when a Stage fails, allowing the caller
+// to report the failure on the matching condition.
+type Error struct {
+	// Stage which failed.
+	Stage Stage
+	// Err is the error returned by the Stage.
+	Err error
+}
+
+// Error returns the error message.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s failed: %s", e.Stage, e.Err)
+}
+
+// Unwrap returns the error returned by the Stage.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// StageOf returns the Stage at which the given error occurred, or an empty
+// Stage if it was not returned by a Pipeline.
+func StageOf(err error) Stage {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Stage
+	}
+	return ""
+}
+
+// Fetcher fetches the content of a Source.
+type Fetcher interface {
+	// Resolve returns the revision of the content of the Source, without
+	// fetching the content.
+	Resolve(ctx context.Context) (revision string, err error)
+	// Fetch writes the content of the Source with the given resolved
+	// revision to the given directory, and returns the revision of the
+	// written content. It may differ from the resolved revision when the
+	// content changed in the meantime.
+	Fetch(ctx context.Context, dir, revision string) (string, error)
+}
+
+// Verifier verifies the fetched content of a Source.
+type Verifier interface {
+	// Verify returns an error if the content in the given directory with
+	// the given revision can not be trusted.
+	Verify(ctx context.Context, dir, revision string) error
+}
+
+// VerifierFunc is a function implementing Verifier.
+type VerifierFunc func(ctx context.Context, dir, revision string) error
+
+// Verify calls f(ctx, dir, revision).
+func (f VerifierFunc) Verify(ctx context.Context, dir, revision string) error {
+	return f(ctx, dir, revision)
+}
+
+// Store stores the fetched content of a Source as an Artifact. The Storage
+// of the github.com/fluxcd/source-controller/pkg/controller package, in
+// which the built-in reconcilers store their Artifacts, implements it.
+type Store interface {
+	// Store archives the given directory to the path of the given
+	// Artifact, and sets its digest, size and last update time.
+	Store(ctx context.Context, artifact *sourcev1.Artifact, dir string) error
+}
+
+// Pipeline resolves the revision of the content of a Source and, when it
+// differs from the one of the current Artifact, fetches the content to a
+// directory, verifies it, and stores it as an Artifact.
+//
+// Run runs all the stages in a temporary directory. Reconcilers which split
+// the stages over several steps, like the built-in ones, call Resolve,
+// Fetch, Verify and StoreArtifact instead.
+type Pipeline struct {
+	// Fetcher fetches the content of the Source.
+	Fetcher Fetcher
+	// Verifiers verify the fetched content, in order.
+	Verifiers []Verifier
+	// Store stores the Artifact.
+	Store Store
+	// NewArtifact returns the Artifact to store for the given revision.
+	NewArtifact func(revision string) sourcev1.Artifact
+	// TempDir is the directory in which the temporary directory of Run is
+	// created, the default directory for temporary files if empty.
+	TempDir string
+}
+
+// Result is the result of a Pipeline run.
+type Result struct {
+	// Artifact is the stored Artifact, or the current Artifact if it is
+	// up-to-date.
+	Artifact sourcev1.Artifact
+	// Stored is true when a new Artifact was stored.
+	Stored bool
+}
+
+// Run runs the Pipeline. The given current Artifact may be nil. The content
+// is only fetched if the resolved revision differs from the one of the
+// current Artifact. When a Stage fails, the returned error is an *Error.
+func (p *Pipeline) Run(ctx context.Context, current *sourcev1.Artifact) (*Result, error) {
+	revision, upToDate, err := p.Resolve(ctx, current)
+	if err != nil {
+		return nil, &Error{Stage: FetchStage, Err: err}
+	}
+	if upToDate {
+		return &Result{Artifact: *current.DeepCopy()}, nil
+	}
+
+	dir, err := os.MkdirTemp(p.TempDir, "fetch-")
+	if err != nil {
+		return nil, &Error{Stage: FetchStage, Err: fmt.Errorf("failed to create temporary directory: %w", err)}
+	}
+	defer os.RemoveAll(dir)
+
+	revision, err = p.Fetch(ctx, dir, revision)
+	if err != nil {
+		return nil, &Error{Stage: FetchStage, Err: err}
+	}
+	if err := p.Verify(ctx, dir, revision); err != nil {
+		return nil, &Error{Stage: VerifyStage, Err: err}
+	}
+	artifact, err := p.StoreArtifact(ctx, dir, revision)
+	if err != nil {
+		return nil, &Error{Stage: StoreStage, Err: err}
+	}
+	return &Result{Artifact: artifact, Stored: true}, nil
+}
+
+// Resolve resolves the revision of the content of the Source, and returns
+// if the given current Artifact, which may be nil, is up-to-date with it.
+func (p *Pipeline) Resolve(ctx context.Context, current *sourcev1.Artifact) (revision string, upToDate bool, err error) {
+	revision, err = p.Fetcher.Resolve(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	return revision, current.HasRevision(revision), nil
+}
+
+// Fetch fetches the content with the given resolved revision to the given
+// directory, and returns the revision of the fetched content.
+func (p *Pipeline) Fetch(ctx context.Context, dir, revision string) (string, error) {
+	return p.Fetcher.Fetch(ctx, dir, revision)
+}
+
+// Verify verifies the content with the given revision in the given
+// directory with the Verifiers, in order.
+func (p *Pipeline) Verify(ctx context.Context, dir, revision string) error {
+	for _, v := range p.Verifiers {
+		if err := v.Verify(ctx, dir, revision); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StoreArtifact stores the content of the given directory as the Artifact
+// returned by NewArtifact for the given revision, and returns it.
+func (p *Pipeline) StoreArtifact(ctx context.Context, dir, revision string) (sourcev1.Artifact, error) {
+	artifact := p.NewArtifact(revision)
+	if err := p.Store.Store(ctx, &artifact, dir); err != nil {
+		return sourcev1.Artifact{}, err
+	}
+	return artifact, nil
+}
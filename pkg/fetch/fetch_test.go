@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+type mockStore struct {
+	stored []string
+	err    error
+}
+
+func (s *mockStore) Store(_ context.Context, artifact *sourcev1.Artifact, dir string) error {
+	if s.err != nil {
+		return s.err
+	}
+	if _, err := os.Stat(filepath.Join(dir, "file")); err != nil {
+		return err
+	}
+	artifact.Digest = "sha256:digest"
+	s.stored = append(s.stored, artifact.Revision)
+	return nil
+}
+
+type mockFetcher struct {
+	revision   string
+	resolveErr error
+	fetchErr   error
+	fetched    bool
+}
+
+func (f *mockFetcher) Resolve(context.Context) (string, error) {
+	return f.revision, f.resolveErr
+}
+
+func (f *mockFetcher) Fetch(_ context.Context, dir, revision string) (string, error) {
+	if f.fetchErr != nil {
+		return "", f.fetchErr
+	}
+	f.fetched = true
+	return revision, os.WriteFile(filepath.Join(dir, "file"), []byte("content"), 0o600)
+}
+
+func TestPipeline_Run(t *testing.T) {
+	newArtifact := func(revision string) sourcev1.Artifact {
+		return sourcev1.Artifact{Path: "kind/ns/name/" + revision + ".tar.gz", Revision: revision}
+	}
+
+	tests := []struct {
+		name        string
+		resolveErr  error
+		fetchErr    error
+		verifiers   []Verifier
+		storeErr    error
+		current     *sourcev1.Artifact
+		wantStage   Stage
+		wantFetched bool
+		wantStored  bool
+		wantRev     string
+	}{
+		{
+			name:        "stores new revision",
+			current:     &sourcev1.Artifact{Revision: "rev1"},
+			wantFetched: true,
+			wantStored:  true,
+			wantRev:     "rev2",
+		},
+		{
+			name:        "stores without current artifact",
+			wantFetched: true,
+			wantStored:  true,
+			wantRev:     "rev2",
+		},
+		{
+			name:    "up-to-date without fetching",
+			current: &sourcev1.Artifact{Revision: "rev2"},
+			wantRev: "rev2",
+		},
+		{
+			name:       "resolve failure",
+			resolveErr: errors.New("unreachable"),
+			wantStage:  FetchStage,
+		},
+		{
+			name:      "fetch failure",
+			fetchErr:  errors.New("unreachable"),
+			wantStage: FetchStage,
+		},
+		{
+			name: "verify failure",
+			verifiers: []Verifier{VerifierFunc(func(context.Context, string, string) error {
+				return errors.New("untrusted")
+			})},
+			wantStage: VerifyStage,
+		},
+		{
+			name:      "store failure",
+			storeErr:  errors.New("disk full"),
+			wantStage: StoreStage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fetcher := &mockFetcher{revision: "rev2", resolveErr: tt.resolveErr, fetchErr: tt.fetchErr}
+			store := &mockStore{err: tt.storeErr}
+			p := &Pipeline{
+				Fetcher:     fetcher,
+				Verifiers:   tt.verifiers,
+				Store:       store,
+				NewArtifact: newArtifact,
+				TempDir:     t.TempDir(),
+			}
+
+			result, err := p.Run(context.TODO(), tt.current)
+			if tt.wantStage != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(StageOf(err)).To(Equal(tt.wantStage))
+				g.Expect(store.stored).To(BeEmpty())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(fetcher.fetched).To(Equal(tt.wantFetched))
+			g.Expect(result.Stored).To(Equal(tt.wantStored))
+			g.Expect(result.Artifact.Revision).To(Equal(tt.wantRev))
+			if tt.wantStored {
+				g.Expect(store.stored).To(Equal([]string{tt.wantRev}))
+			}
+
+			entries, err := os.ReadDir(p.TempDir)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(entries).To(BeEmpty())
+		})
+	}
+}
@@ -19,6 +19,7 @@ package minio
 import (
 	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
 	"github.com/minio/minio-go/v7/pkg/s3utils"
 	corev1 "k8s.io/api/core/v1"
 
@@ -37,8 +39,17 @@ import (
 // storage APIs.
 type MinioClient struct {
 	*minio.Client
+
+	// sse holds the server-side encryption with customer provided keys
+	// (SSE-C) used to read objects, if any.
+	sse encrypt.ServerSide
 }
 
+// SSECustomerKeySecretKey is the key of the credentials Secret data holding
+// the 32 byte customer provided key, raw or base64 encoded, used to read
+// objects encrypted with SSE-C.
+const SSECustomerKeySecretKey = "ssecustomerkey"
+
 // options holds the configuration for the Minio client.
 type options struct {
 	secret       *corev1.Secret
@@ -110,6 +121,14 @@ func NewClient(bucket *sourcev1.Bucket, opts ...Option) (*MinioClient, error) {
 		minioOpts.Creds = newGenericCreds(bucket, &o)
 	}
 
+	var sse encrypt.ServerSide
+	if o.secret != nil {
+		var err error
+		if sse, err = sseFromSecret(o.secret); err != nil {
+			return nil, err
+		}
+	}
+
 	var transportOpts []func(*http.Transport)
 
 	if minioOpts.Secure && o.tlsConfig != nil {
@@ -139,7 +158,25 @@ func NewClient(bucket *sourcev1.Bucket, opts ...Option) (*MinioClient, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &MinioClient{Client: client}, nil
+	return &MinioClient{Client: client, sse: sse}, nil
+}
+
+// sseFromSecret returns the SSE-C configuration for the customer provided key
+// in the given Secret, or nil if the Secret does not contain one.
+func sseFromSecret(secret *corev1.Secret) (encrypt.ServerSide, error) {
+	key, ok := secret.Data[SSECustomerKeySecretKey]
+	if !ok {
+		return nil, nil
+	}
+	if len(key) != 32 {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(key)))
+		if err != nil || len(decoded) != 32 {
+			return nil, fmt.Errorf("invalid '%s' secret data: '%s' must be a 32 byte key, raw or base64 encoded",
+				secret.Name, SSECustomerKeySecretKey)
+		}
+		key = decoded
+	}
+	return encrypt.NewSSEC(key)
 }
 
 // newCredsFromSecret creates a new Minio credentials object from the provided
@@ -228,6 +265,9 @@ func ValidateSecret(secret *corev1.Secret) error {
 	if _, ok := secret.Data["secretkey"]; !ok {
 		return err
 	}
+	if _, err := sseFromSecret(secret); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -300,11 +340,11 @@ func validateSTSSecretForProvider(stsProvider string, secret *corev1.Secret, key
 // writes it to targetPath.
 // It returns the etag of the successfully fetched file, or any error.
 func (c *MinioClient) FGetObject(ctx context.Context, bucketName, objectName, localPath string) (string, error) {
-	stat, err := c.Client.StatObject(ctx, bucketName, objectName, minio.GetObjectOptions{})
+	stat, err := c.Client.StatObject(ctx, bucketName, objectName, minio.GetObjectOptions{ServerSideEncryption: c.sse})
 	if err != nil {
 		return "", err
 	}
-	opts := minio.GetObjectOptions{}
+	opts := minio.GetObjectOptions{ServerSideEncryption: c.sse}
 	if err = opts.SetMatchETag(stat.ETag); err != nil {
 		return "", err
 	}
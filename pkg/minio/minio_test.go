@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
@@ -581,6 +582,53 @@ func TestValidateSecret(t *testing.T) {
 	}
 }
 
+func TestSSEFromSecret(t *testing.T) {
+	t.Parallel()
+	rawKey := []byte("0123456789abcdef0123456789abcdef")
+	testCases := []struct {
+		name    string
+		data    map[string][]byte
+		wantSSE bool
+		error   bool
+	}{
+		{
+			name: "no customer key",
+			data: map[string][]byte{},
+		},
+		{
+			name:    "raw customer key",
+			data:    map[string][]byte{SSECustomerKeySecretKey: rawKey},
+			wantSSE: true,
+		},
+		{
+			name:    "base64 customer key",
+			data:    map[string][]byte{SSECustomerKeySecretKey: []byte(base64.StdEncoding.EncodeToString(rawKey) + "\n")},
+			wantSSE: true,
+		},
+		{
+			name:  "invalid customer key",
+			data:  map[string][]byte{SSECustomerKeySecretKey: []byte("too-short")},
+			error: true,
+		},
+	}
+	for _, testCase := range testCases {
+		tt := testCase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sse, err := sseFromSecret(&corev1.Secret{
+				ObjectMeta: v1.ObjectMeta{Name: "sse"},
+				Data:       tt.data,
+			})
+			if tt.error {
+				assert.Error(t, err, fmt.Sprintf("invalid 'sse' secret data: '%s' must be a 32 byte key, raw or base64 encoded", SSECustomerKeySecretKey))
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, sse != nil, tt.wantSSE)
+		})
+	}
+}
+
 func TestValidateSTSProvider(t *testing.T) {
 	t.Parallel()
 
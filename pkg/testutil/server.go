@@ -0,0 +1,212 @@
+/*
+Copyright 2025 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testutil provides utilities to test code consuming the Artifacts
+// produced by source-controller, without copying its internal test
+// scaffolding. It only depends on public packages, so that it can be
+// imported by other modules.
+package testutil
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+)
+
+const (
+	// fileMode is the mode of the regular files in the archives.
+	fileMode int64 = 0o600
+	// exeFileMode is the mode of the executable files in the archives.
+	exeFileMode int64 = 0o700
+	// dirMode is the mode of the directories in the archives.
+	dirMode int64 = 0o750
+)
+
+// ArtifactServer is an HTTP server serving Artifacts from a temporary
+// directory. The Artifacts are archived in the same format as by the
+// Storage of source-controller, and therefore have the same digest for the
+// same content.
+type ArtifactServer struct {
+	*httptest.Server
+
+	root string
+}
+
+// NewArtifactServer creates and starts a new ArtifactServer. The caller must
+// call Close when finished, to shut down the server and remove the stored
+// Artifacts.
+func NewArtifactServer() (*ArtifactServer, error) {
+	root, err := os.MkdirTemp("", "artifacts-")
+	if err != nil {
+		return nil, err
+	}
+	return &ArtifactServer{
+		Server: httptest.NewServer(http.FileServer(http.Dir(root))),
+		root:   root,
+	}, nil
+}
+
+// Root returns the directory the Artifacts are stored in.
+func (s *ArtifactServer) Root() string {
+	return s.root
+}
+
+// Close shuts down the server and removes the stored Artifacts.
+func (s *ArtifactServer) Close() {
+	s.Server.Close()
+	os.RemoveAll(s.root)
+}
+
+// ArtifactFromDir archives the given directory as an Artifact with the given
+// revision, for the object with the given kind, namespace and name.
+// The returned Artifact has its URL, digest and size set.
+func (s *ArtifactServer) ArtifactFromDir(kind, namespace, name, revision, dir string) (*sourcev1.Artifact, error) {
+	if f, err := os.Stat(dir); err != nil || !f.IsDir() {
+		return nil, fmt.Errorf("invalid dir path: %s", dir)
+	}
+
+	path := sourcev1.ArtifactPath(kind, namespace, name, fmt.Sprintf("%x.tar.gz", sha256.Sum256([]byte(revision))))
+	localPath := filepath.Join(s.root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(localPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	digester := digest.SHA256.Digester()
+	size := &writeCounter{}
+	if err := archive(io.MultiWriter(f, digester.Hash(), size), dir); err != nil {
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	return &sourcev1.Artifact{
+		Path:           path,
+		URL:            fmt.Sprintf("%s/%s", s.URL, path),
+		Revision:       revision,
+		Digest:         digester.Digest().String(),
+		LastUpdateTime: metav1.Now(),
+		Size:           &size.written,
+	}, nil
+}
+
+// ArtifactFromFiles writes the given files, keyed by their relative path, to
+// a temporary directory and archives it as an Artifact using
+// ArtifactFromDir.
+func (s *ArtifactServer) ArtifactFromFiles(kind, namespace, name, revision string, files map[string]string) (*sourcev1.Artifact, error) {
+	dir, err := os.MkdirTemp("", "artifact-files-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	for p, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			return nil, err
+		}
+	}
+	return s.ArtifactFromDir(kind, namespace, name, revision, dir)
+}
+
+// archive writes the regular files and directories of the given directory
+// to the given writer as a gzipped tarball, without the environment
+// specific data of the files.
+func archive(w io.Writer, dir string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	if err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if m := fi.Mode(); !(m.IsRegular() || m.IsDir()) {
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(fi, p)
+		if err != nil {
+			return err
+		}
+		if header.Name, err = filepath.Rel(dir, p); err != nil {
+			return err
+		}
+		header.Uid, header.Gid = 0, 0
+		header.Uname, header.Gname = "", ""
+		header.ModTime, header.AccessTime, header.ChangeTime = time.Time{}, time.Time{}, time.Time{}
+		switch {
+		case fi.IsDir():
+			header.Mode = dirMode
+		case fi.Mode()&0o111 != 0:
+			header.Mode = exeFileMode
+		default:
+			header.Mode = fileMode
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	}); err != nil {
+		tw.Close()
+		gw.Close()
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		gw.Close()
+		return err
+	}
+	return gw.Close()
+}
+
+// writeCounter counts the bytes written to it.
+type writeCounter struct {
+	written int64
+}
+
+func (wc *writeCounter) Write(p []byte) (int, error) {
+	n := len(p)
+	wc.written += int64(n)
+	return n, nil
+}
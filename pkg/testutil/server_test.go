@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testutil
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fluxcd/pkg/tar"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/fluxcd/source-controller/internal/controller"
+)
+
+func TestArtifactServer_ArtifactFromFiles(t *testing.T) {
+	g := NewWithT(t)
+
+	srv, err := NewArtifactServer()
+	g.Expect(err).ToNot(HaveOccurred())
+	defer srv.Close()
+
+	artifact, err := srv.ArtifactFromFiles(sourcev1.GitRepositoryKind, "default", "podinfo", "main@sha1:4ea3e7a", map[string]string{
+		"kustomization.yaml":  "resources: [deploy]",
+		"deploy/service.yaml": "kind: Service",
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(artifact.Path).To(HavePrefix("gitrepository/default/podinfo/"))
+	g.Expect(artifact.Revision).To(Equal("main@sha1:4ea3e7a"))
+	g.Expect(artifact.Size).ToNot(BeNil())
+
+	resp, err := http.Get(artifact.URL)
+	g.Expect(err).ToNot(HaveOccurred())
+	defer resp.Body.Close()
+	g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+	b, err := io.ReadAll(resp.Body)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(digest.Digest(artifact.Digest).Algorithm().FromBytes(b).String()).To(Equal(artifact.Digest))
+
+	dir := t.TempDir()
+	g.Expect(tar.Untar(bytes.NewReader(b), dir)).To(Succeed())
+
+	b, err = os.ReadFile(filepath.Join(dir, "deploy", "service.yaml"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(b)).To(Equal("kind: Service"))
+}
+
+func TestArtifactServer_ArtifactFromDir(t *testing.T) {
+	g := NewWithT(t)
+
+	srv, err := NewArtifactServer()
+	g.Expect(err).ToNot(HaveOccurred())
+	defer srv.Close()
+
+	dir := t.TempDir()
+	g.Expect(os.MkdirAll(filepath.Join(dir, "bin"), 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "bin", "run.sh"), []byte("#!/bin/sh"), 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte("# podinfo"), 0o644)).To(Succeed())
+
+	artifact, err := srv.ArtifactFromDir(sourcev1.GitRepositoryKind, "default", "podinfo", "main@sha1:4ea3e7a", dir)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(filepath.Join(srv.Root(), artifact.Path)).To(BeAnExistingFile())
+
+	// The Artifact has the same digest as the one of the Storage of
+	// source-controller.
+	storage, err := controller.NewStorage(t.TempDir(), "localhost", time.Minute, 1)
+	g.Expect(err).ToNot(HaveOccurred())
+	expected := storage.NewArtifactFor(sourcev1.GitRepositoryKind, &metav1.ObjectMeta{Namespace: "default", Name: "podinfo"}, "main@sha1:4ea3e7a", "podinfo.tar.gz")
+	g.Expect(storage.MkdirAll(expected)).To(Succeed())
+	g.Expect(storage.Archive(&expected, dir, nil)).To(Succeed())
+	g.Expect(artifact.Digest).To(Equal(expected.Digest))
+	g.Expect(*artifact.Size).To(Equal(*expected.Size))
+}